@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/http/i18n"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/infrastructure/container"
+	"github.com/spf13/cobra"
+)
+
+func newAccountsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "accounts",
+		Short: "Create and look up accounts",
+	}
+	cmd.AddCommand(newAccountsCreateCommand(), newAccountsGetCommand(), newAccountsBalancesCommand())
+	return cmd
+}
+
+// newAccountsCreateCommand opens accounts in one batch and fails unless
+// every document got a new account.
+func newAccountsCreateCommand() *cobra.Command {
+	var output, currency string
+	cmd := &cobra.Command{
+		Use:   "create DOCUMENT...",
+		Short: "Open an account for each document number",
+		Args:  cobra.RangeArgs(1, domain.MaxAccountBatch),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var report *domain.AccountBatchReport
+			err := withContainer(cmd, func(ctx context.Context, ctr *container.Container) (err error) {
+				report, err = ctr.AccountService().CreateAccounts(ctx, args, domain.Currency(currency))
+				return err
+			})
+			if err != nil {
+				return err
+			}
+
+			rows := make([][]string, len(report.Results))
+			for i, r := range report.Results {
+				if r.Reason != "" {
+					report.Results[i].Reason = i18n.Reason(i18n.English, r.Reason, nil)
+				}
+				rows[i] = []string{r.DocumentNumber, string(r.Status), formatID(r.AccountID), report.Results[i].Reason}
+			}
+			if err := printResult(cmd.OutOrStdout(), output, report, []string{"DOCUMENT", "STATUS", "ACCOUNT", "REASON"}, rows); err != nil {
+				return err
+			}
+			if report.Created < report.Total {
+				return fmt.Errorf("%d of %d accounts were not created", report.Total-report.Created, report.Total)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&currency, "currency", "", "ISO 4217 currency of the new accounts (default DEFAULT_CURRENCY)")
+	outputFlag(cmd, &output)
+	return cmd
+}
+
+func newAccountsGetCommand() *cobra.Command {
+	var output, document string
+	cmd := &cobra.Command{
+		Use:   "get [ACCOUNT_ID]",
+		Short: "Show an account by ID or by --document",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (len(args) == 1) == (document != "") {
+				return fmt.Errorf("pass either an account ID or --document")
+			}
+			var accountID int64
+			if len(args) == 1 {
+				id, err := parseID(args[0])
+				if err != nil {
+					return err
+				}
+				accountID = id
+			}
+
+			var account *domain.Account
+			err := withContainer(cmd, func(ctx context.Context, ctr *container.Container) (err error) {
+				if document != "" {
+					account, err = ctr.AccountService().GetAccountByDocument(ctx, document)
+				} else {
+					account, err = ctr.AccountService().GetAccountByID(ctx, accountID)
+				}
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			return printResult(cmd.OutOrStdout(), output, account,
+				[]string{"ACCOUNT", "DOCUMENT", "CURRENCY"}, [][]string{{formatID(account.ID), account.DocumentNumber, string(account.Currency)}})
+		},
+	}
+	cmd.Flags().StringVar(&document, "document", "", "look the account up by document number")
+	outputFlag(cmd, &output)
+	return cmd
+}
+
+// newAccountsBalancesCommand breaks an account's balance down by the
+// currencies its transactions were made in.
+func newAccountsBalancesCommand() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "balances ACCOUNT_ID",
+		Short: "Show an account's balance per transaction currency",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			accountID, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			var balances *domain.AccountBalances
+			err = withContainer(cmd, func(ctx context.Context, ctr *container.Container) (err error) {
+				balances, err = ctr.TransactionService().GetBalances(ctx, accountID)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+
+			rows := make([][]string, len(balances.Currencies))
+			for i, b := range balances.Currencies {
+				rows[i] = []string{
+					string(b.Currency),
+					strconv.FormatFloat(b.Amount, 'f', 2, 64),
+					strconv.FormatFloat(b.Booked, 'f', 2, 64) + " " + string(balances.Currency),
+				}
+			}
+			return printResult(cmd.OutOrStdout(), output, balances, []string{"CURRENCY", "AMOUNT", "BOOKED"}, rows)
+		},
+	}
+	outputFlag(cmd, &output)
+	return cmd
+}
+
+func parseID(arg string) (int64, error) {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID %q", arg)
+	}
+	return id, nil
+}
+
+// formatID leaves unset IDs blank.
+func formatID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/http/i18n"
+	config "github.com/evythrossell/account-management-api/internal/infrastructure"
+	"github.com/evythrossell/account-management-api/internal/infrastructure/container"
+	logger "github.com/evythrossell/account-management-api/pkg"
+	"github.com/spf13/cobra"
+)
+
+// withContainer loads the configuration the way the server does, starts the
+// container without any background worker and calls fn. The context carries
+// no principal, so services treat the CLI as a trusted caller.
+func withContainer(cmd *cobra.Command, fn func(ctx context.Context, ctr *container.Container) error) (err error) {
+	cfg, err := config.Load(config.WithFlags(cmd.Flags()))
+	if err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	appLogger := logger.NewSlogLogger(os.Stderr, cfg.Log.Level, cfg.Log.Format)
+
+	ctr, err := container.New(cfg, appLogger)
+	if err != nil {
+		return fmt.Errorf("initialize container: %w", err)
+	}
+	ctx := logger.WithLogger(cmd.Context(), appLogger)
+	if err := ctr.Start(ctx); err != nil {
+		return fmt.Errorf("start container: %w", err)
+	}
+	defer func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), cfg.Shutdown.Timeout)
+		defer cancel()
+		err = errors.Join(err, ctr.Stop(stopCtx))
+	}()
+
+	return describe(fn(ctx, ctr))
+}
+
+// describe replaces a domain error with its English message, which reads
+// better in a terminal than the error code.
+func describe(err error) error {
+	var de *logger.DomainError
+	if !errors.As(err, &de) || de.Key == "" {
+		return err
+	}
+	return errors.New(i18n.Reason(i18n.English, de.Key, de.Params))
+}
+
+// outputFlag registers -o for commands that print a table or JSON.
+func outputFlag(cmd *cobra.Command, output *string) {
+	cmd.Flags().StringVarP(output, "output", "o", "table", "output format: table or json")
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if *output != "table" && *output != "json" {
+			return fmt.Errorf("unsupported output %q: use table or json", *output)
+		}
+		return nil
+	}
+}
+
+// printResult writes v as indented JSON, or header and rows as an aligned
+// table.
+func printResult(w io.Writer, output string, v any, header []string, rows [][]string) error {
+	if output == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	config "github.com/evythrossell/account-management-api/internal/infrastructure"
+	"github.com/spf13/cobra"
+)
+
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the configuration",
+	}
+	cmd.AddCommand(newConfigPrintCommand())
+	return cmd
+}
+
+// newConfigPrintCommand prints the merged configuration with secrets
+// redacted, then fails if it would not pass validation.
+func newConfigPrintCommand() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "print",
+		Short: "Print the effective configuration with secrets redacted",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			values, err := config.Resolve(config.WithFlags(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			if err := values.Print(cmd.OutOrStdout(), output); err != nil {
+				return err
+			}
+			if _, err := values.Config(); err != nil {
+				return fmt.Errorf("invalid configuration:\n%w", err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "yaml", "output format: yaml or json")
+	return cmd
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/http/i18n"
+	"github.com/evythrossell/account-management-api/internal/adapter/importer"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/infrastructure/container"
+	"github.com/spf13/cobra"
+)
+
+// newImportCommand imports a file in the foreground, without the HTTP body
+// limit or a job queue, and fails unless every line was created.
+func newImportCommand() *cobra.Command {
+	var format, mode, output string
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Import a CSV or JSON Lines file of transactions",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "text" && output != "json" {
+				return fmt.Errorf("unsupported output %q: use text or json", output)
+			}
+			if format == "" {
+				format = strings.TrimPrefix(filepath.Ext(args[0]), ".")
+			}
+			f, err := importer.Detect(format, "")
+			if err != nil {
+				return fmt.Errorf("format must be one of %s: %w", importer.Formats(), err)
+			}
+			m, err := domain.ParseImportMode(mode)
+			if err != nil {
+				return fmt.Errorf("mode must be one of %s: %w", domain.ImportModes(), err)
+			}
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			rows, err := importer.Decode(f, file)
+			if err != nil {
+				return err
+			}
+
+			var report *domain.ImportReport
+			err = withContainer(cmd, func(ctx context.Context, ctr *container.Container) (err error) {
+				report, err = ctr.ImportService().Import(ctx, m, rows)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			if err := printReport(cmd.OutOrStdout(), output, report); err != nil {
+				return err
+			}
+			if report.Created < report.Total {
+				return fmt.Errorf("%d of %d lines were not imported", report.Total-report.Created, report.Total)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "", "file format: csv or jsonl (defaults to the file extension, then csv)")
+	cmd.Flags().StringVar(&mode, "mode", string(domain.ImportAllOrNothing), "import mode: all_or_nothing or best_effort")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "report format: text or json")
+	return cmd
+}
+
+// printReport lists the summary and every line that was not created, with
+// reasons in English.
+func printReport(w io.Writer, output string, report *domain.ImportReport) error {
+	for i, line := range report.Lines {
+		if line.Reason != "" {
+			report.Lines[i].Reason = i18n.Reason(i18n.English, line.Reason, nil)
+		}
+	}
+
+	if output == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	fmt.Fprintf(w, "total: %d  created: %d  rejected: %d  skipped: %d\n",
+		report.Total, report.Created, report.Rejected, report.Skipped)
+	for _, line := range report.Lines {
+		if line.Status != domain.ImportLineCreated {
+			fmt.Fprintf(w, "line %d: %s: %s\n", line.Line, line.Status, line.Reason)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/infrastructure/container"
+	"github.com/spf13/cobra"
+)
+
+func newKeysCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Manage the API keys clients send in the X-API-Key header",
+	}
+	cmd.AddCommand(newKeysCreateCommand(), newKeysListCommand(), newKeysRevokeCommand())
+	return cmd
+}
+
+// newKeysCreateCommand prints the secret once; only its hash is stored.
+func newKeysCreateCommand() *cobra.Command {
+	var (
+		output, subject string
+		roles, scopes   []string
+	)
+	cmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create an API key and print its secret",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				key    *domain.APIKey
+				secret string
+			)
+			err := withContainer(cmd, func(ctx context.Context, ctr *container.Container) (err error) {
+				key, secret, err = ctr.APIKeyService().Create(ctx, args[0], subject, roles, scopes)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.ErrOrStderr(), "Store the key now: it cannot be shown again.")
+			created := struct {
+				*domain.APIKey
+				Key string `json:"key"`
+			}{key, secret}
+			return printResult(cmd.OutOrStdout(), output, created,
+				append(keyHeader, "KEY"), [][]string{append(keyRow(key), secret)})
+		},
+	}
+	cmd.Flags().StringVar(&subject, "subject", "", `principal subject the key acts as (default "apikey:NAME")`)
+	cmd.Flags().StringSliceVar(&roles, "role", nil, "role granted to the key, e.g. admin (repeatable)")
+	cmd.Flags().StringSliceVar(&scopes, "scope", nil, "scope granted to the key (repeatable)")
+	outputFlag(cmd, &output)
+	return cmd
+}
+
+func newKeysListCommand() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List API keys, including revoked ones",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var keys []*domain.APIKey
+			err := withContainer(cmd, func(ctx context.Context, ctr *container.Container) (err error) {
+				keys, err = ctr.APIKeyService().List(ctx)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+
+			rows := make([][]string, len(keys))
+			for i, key := range keys {
+				rows[i] = keyRow(key)
+			}
+			return printResult(cmd.OutOrStdout(), output, keys, keyHeader, rows)
+		},
+	}
+	outputFlag(cmd, &output)
+	return cmd
+}
+
+func newKeysRevokeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke KEY_ID",
+		Short: "Revoke an API key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyID, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			err = withContainer(cmd, func(ctx context.Context, ctr *container.Container) error {
+				return ctr.APIKeyService().Revoke(ctx, keyID)
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "api key %d revoked\n", keyID)
+			return nil
+		},
+	}
+}
+
+var keyHeader = []string{"ID", "NAME", "PREFIX", "SUBJECT", "ROLES", "SCOPES", "CREATED", "REVOKED"}
+
+func keyRow(key *domain.APIKey) []string {
+	revoked := ""
+	if key.RevokedAt != nil {
+		revoked = key.RevokedAt.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		formatID(key.ID),
+		key.Name,
+		key.Prefix,
+		key.Subject,
+		strings.Join(key.Roles, ","),
+		strings.Join(key.Scopes, ","),
+		key.CreatedAt.UTC().Format(time.RFC3339),
+		revoked,
+	}
+}
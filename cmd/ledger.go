@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/infrastructure/container"
+	"github.com/spf13/cobra"
+)
+
+func newLedgerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ledger",
+		Short: "Inspect the double-entry ledger",
+	}
+	cmd.AddCommand(newLedgerCheckCommand(), newLedgerEntryCommand())
+	return cmd
+}
+
+// newLedgerCheckCommand prints the trial balance and fails when the ledger
+// is out of balance, so it can alert from cron.
+func newLedgerCheckCommand() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Verify that debits equal credits across the ledger",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var balance *domain.TrialBalance
+			err := withContainer(cmd, func(ctx context.Context, ctr *container.Container) (err error) {
+				balance, err = ctr.LedgerService().TrialBalance(ctx)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+
+			if output == "table" {
+				fmt.Fprintf(cmd.OutOrStdout(), "debits %s, credits %s, %d unbalanced entries, %d projection mismatches\n",
+					strconv.FormatFloat(balance.Debits, 'f', 2, 64), strconv.FormatFloat(balance.Credits, 'f', 2, 64),
+					balance.UnbalancedEntries, balance.ProjectionMismatches)
+			}
+			rows := make([][]string, len(balance.Ledgers))
+			for i, l := range balance.Ledgers {
+				rows[i] = []string{string(l.Ledger), strconv.FormatFloat(l.Debits, 'f', 2, 64), strconv.FormatFloat(l.Credits, 'f', 2, 64)}
+			}
+			if err := printResult(cmd.OutOrStdout(), output, balance, []string{"LEDGER", "DEBITS", "CREDITS"}, rows); err != nil {
+				return err
+			}
+			if !balance.Balanced() {
+				return fmt.Errorf("the ledger is out of balance")
+			}
+			return nil
+		},
+	}
+	outputFlag(cmd, &output)
+	return cmd
+}
+
+func newLedgerEntryCommand() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "entry TRANSACTION_ID",
+		Short: "Show the journal entry that booked a transaction",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			transactionID, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			var entry *domain.JournalEntry
+			err = withContainer(cmd, func(ctx context.Context, ctr *container.Container) (err error) {
+				entry, err = ctr.LedgerService().GetJournalEntry(ctx, transactionID)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+
+			rows := make([][]string, len(entry.Postings))
+			for i, p := range entry.Postings {
+				rows[i] = []string{string(p.Ledger), formatID(p.AccountID), string(p.Direction), strconv.FormatFloat(p.Amount, 'f', 2, 64)}
+			}
+			return printResult(cmd.OutOrStdout(), output, entry, []string{"LEDGER", "ACCOUNT", "DIRECTION", "AMOUNT"}, rows)
+		},
+	}
+	outputFlag(cmd, &output)
+	return cmd
+}
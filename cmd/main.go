@@ -14,70 +14,243 @@
 // @BasePath        /
 // @schemes         http
 
+// @securityDefinitions.apikey BearerAuth
+// @in                         header
+// @name                       Authorization
+// @description                Token JWT no formato "Bearer {token}"
+
 package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
+	"github.com/evythrossell/account-management-api/internal/adapter/auth"
 	"github.com/evythrossell/account-management-api/internal/adapter/http/handler"
+	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
+	"github.com/evythrossell/account-management-api/internal/adapter/http/tlsconfig"
+	"github.com/evythrossell/account-management-api/internal/adapter/tracing"
 	config "github.com/evythrossell/account-management-api/internal/infrastructure"
 	"github.com/evythrossell/account-management-api/internal/infrastructure/container"
+	"github.com/evythrossell/account-management-api/internal/infrastructure/lifecycle"
 	logger "github.com/evythrossell/account-management-api/pkg"
-	
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cobra"
+
 	_ "github.com/evythrossell/account-management-api/docs"
 )
 
 func main() {
-	cfg, err := config.Load()
+	if err := newRootCommand().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "account-management-api",
+		Short:         "Account Management API server",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(config.WithFlags(cmd.Flags()))
+			if err != nil {
+				return fmt.Errorf("invalid configuration:\n%w", err)
+			}
+
+			appLogger := logger.NewSlogLogger(os.Stdout, cfg.Log.Level, cfg.Log.Format)
+			logger.SetDefault(appLogger)
+			if cfg.Environment == config.EnvProduction {
+				gin.SetMode(gin.ReleaseMode)
+			}
+
+			if err := run(cfg, appLogger); err != nil {
+				appLogger.Error("server exited with error", logger.Err(err))
+				os.Exit(1)
+			}
+			appLogger.Info("server exited gracefully")
+			return nil
+		},
+	}
+	config.RegisterFlags(cmd.PersistentFlags())
+	cmd.AddCommand(newConfigCommand())
+	cmd.AddCommand(newImportCommand())
+	cmd.AddCommand(newAccountsCommand(), newTransactionsCommand(), newKeysCommand(), newMigrateCommand(), newReconcileCommand(), newLedgerCommand())
+	return cmd
+}
+
+// run owns every resource; returning instead of exiting lets the lifecycle
+// manager release whatever was already started.
+func run(cfg *config.Config, appLogger logger.Logger) (err error) {
+	lc := lifecycle.New(lifecycle.Config{
+		DrainPeriod:     cfg.Shutdown.DrainPeriod,
+		ShutdownTimeout: cfg.Shutdown.Timeout,
+	}, appLogger)
+	defer func() {
+		if err != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), cfg.Shutdown.Timeout)
+			defer cancel()
+			_ = lc.Shutdown(ctx)
+		}
+	}()
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		ServiceName: cfg.Tracing.ServiceName,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	}, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("initialize tracing: %w", err)
+	}
+	lc.Add(lifecycle.PhaseFlush, "tracing", shutdownTracing)
+
+	ctr, err := container.New(cfg, appLogger,
+		container.WithModule(container.NewStatementCloser(cfg.Statements.CloseInterval)),
+		container.WithModule(container.NewImportWorker(cfg.Imports.PollInterval)),
+		container.WithModule(container.NewBalanceReconciler(cfg.Reconciliation.Interval, cfg.Reconciliation.Repair)),
+	)
 	if err != nil {
-		panic("critical failure loading config: " + err.Error())
+		return fmt.Errorf("initialize container: %w", err)
+	}
+	if err := ctr.Start(context.Background()); err != nil {
+		return fmt.Errorf("start container: %w", err)
 	}
+	lc.Add(lifecycle.PhaseWorkers, "background modules", ctr.StopModules)
+	lc.Add(lifecycle.PhaseClose, "storage", ctr.Stop)
 
-	appLogger := logger.NewSimpleLogger(logger.InfoLevel)
-	ctr, err := container.New(cfg, appLogger)
+	routerOpts := []handler.RouterOption{
+		handler.WithLogger(appLogger),
+		handler.WithMetrics(ctr.Metrics()),
+		handler.WithTracing(),
+		handler.WithRequestTimeout(cfg.HTTP.RequestTimeout),
+		handler.WithExportTimeout(cfg.HTTP.ExportTimeout),
+		handler.WithBodyLimit(cfg.HTTP.MaxBodyBytes),
+		handler.WithAccessLog(middleware.AccessLogConfig{
+			SkipPaths:         cfg.Log.AccessLog.SkipPaths,
+			SampledRoutes:     cfg.Log.AccessLog.SampledRoutes,
+			SuccessSampleRate: cfg.Log.AccessLog.SuccessSampleRate,
+		}),
+	}
+	authOpts, err := authenticationOptions(cfg, ctr)
 	if err != nil {
-		appLogger.Fatal("failed to initialize container", logger.Err(err))
+		return err
 	}
-	defer ctr.Close()
+	routerOpts = append(routerOpts, authOpts...)
+	routerOpts = append(routerOpts, rateLimitOptions(cfg.RateLimit)...)
+
+	routerOpts = append(routerOpts,
+		handler.WithStatements(ctr.StatementHandler()),
+		handler.WithImports(ctr.ImportHandler()),
+		handler.WithLedger(ctr.LedgerHandler()),
+	)
 
 	router := handler.SetupRouter(
 		ctr.AccountHandler(),
 		ctr.HealthHandler(),
 		ctr.TransactionHandler(),
+		routerOpts...,
 	)
 
 	srv := &http.Server{
-		Addr:         ":" + cfg.ServerPort,
-		Handler:      router,
-		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 10 * time.Second,
+		Addr:              ":" + cfg.ServerPort,
+		Handler:           router,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+		MaxHeaderBytes:    int(cfg.HTTP.MaxHeaderBytes),
 	}
 
-	go func() {
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			appLogger.Fatal("server failed to start", logger.Err(err))
+	serve := srv.ListenAndServe
+	if cfg.TLS.Enabled() {
+		reloader, err := tlsconfig.NewReloader(tlsconfig.Config{
+			CertFile:       cfg.TLS.CertFile,
+			KeyFile:        cfg.TLS.KeyFile,
+			ClientCAFile:   cfg.TLS.ClientCAFile,
+			ClientAuth:     cfg.TLS.ClientAuth,
+			ReloadInterval: cfg.TLS.ReloadInterval,
+		}, appLogger)
+		if err != nil {
+			return fmt.Errorf("initialize tls: %w", err)
 		}
-	}()
+		srv.TLSConfig = reloader.TLSConfig()
+		serve = func() error { return srv.ListenAndServeTLS("", "") }
 
-	appLogger.Info("server started", logger.String("port", cfg.ServerPort))
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
-	<-stop
+		reloadCtx, stopReload := context.WithCancel(context.Background())
+		go reloader.Run(reloadCtx)
+		lc.Add(lifecycle.PhaseWorkers, "tls reloader", func(context.Context) error {
+			stopReload()
+			return nil
+		})
+	}
 
-	appLogger.Info("shutting down server...")
+	lc.Add(lifecycle.PhaseReadiness, "readiness", func(context.Context) error {
+		ctr.HealthService().SetNotReady()
+		return nil
+	})
+	lc.Add(lifecycle.PhaseDrain, "drain", lc.Drain())
+	lc.Add(lifecycle.PhaseHTTP, "http server", srv.Shutdown)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	if err := srv.Shutdown(ctx); err != nil {
-		appLogger.Error("server forced to shutdown", logger.Err(err))
-		os.Exit(1)
+	appLogger.Info("server started", logger.String("port", cfg.ServerPort), logger.Bool("tls", cfg.TLS.Enabled()))
+	return lc.Run(ctx, func() error {
+		if err := serve(); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	})
+}
+
+// authenticationOptions accepts mapped client certificates first, then API
+// keys, then bearer tokens.
+func authenticationOptions(cfg *config.Config, ctr *container.Container) ([]handler.RouterOption, error) {
+	var opts []handler.RouterOption
+	if cfg.TLS.ClientIdentitiesFile != "" {
+		identities, err := auth.LoadClientCertIdentities(cfg.TLS.ClientIdentitiesFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, handler.WithAuthentication(middleware.ClientCert(identities)))
+	}
+	opts = append(opts, handler.WithAuthentication(middleware.APIKey(ctr.APIKeyService())))
+	if verifier := ctr.TokenVerifier(); verifier != nil {
+		opts = append(opts, handler.WithAuthentication(middleware.Auth(verifier)))
+	}
+	return opts, nil
+}
+
+func rateLimitOptions(cfg config.RateLimitConfig) []handler.RouterOption {
+	store := middleware.NewMemoryRateLimitStore()
+	accountWrites := middleware.RateLimitPolicy{
+		Name:  "account-writes",
+		Limit: middleware.RateLimit{Rate: cfg.AccountWrites.RPS, Burst: cfg.AccountWrites.Burst},
+		Key:   middleware.AccountWriteKey,
 	}
 
-	appLogger.Info("server exited gracefully")
+	return []handler.RouterOption{
+		handler.WithGroupMiddleware(handler.AccountsGroup, middleware.RateLimiter(store,
+			middleware.RateLimitPolicy{
+				Name:  handler.AccountsGroup,
+				Limit: middleware.RateLimit{Rate: cfg.Accounts.RPS, Burst: cfg.Accounts.Burst},
+				Key:   middleware.ClientKey,
+			},
+		)),
+		handler.WithGroupMiddleware(handler.TransactionsGroup, middleware.RateLimiter(store,
+			middleware.RateLimitPolicy{
+				Name:  handler.TransactionsGroup,
+				Limit: middleware.RateLimit{Rate: cfg.Transactions.RPS, Burst: cfg.Transactions.Burst},
+				Key:   middleware.ClientKey,
+			},
+			accountWrites,
+		)),
+	}
 }
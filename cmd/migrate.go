@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/evythrossell/account-management-api/internal/infrastructure/container"
+	"github.com/spf13/cobra"
+)
+
+// newMigrateCommand applies the schema bundled with this build. The script
+// is idempotent, so running it on an up-to-date database is harmless.
+func newMigrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Create or upgrade the database schema",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withMigrator(cmd, func(ctx context.Context, m container.Migrator) error {
+				if err := m.Migrate(ctx); err != nil {
+					return err
+				}
+				current, _, err := m.SchemaVersion(ctx)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "schema at version %d\n", current)
+				return nil
+			})
+		},
+	}
+	cmd.AddCommand(newMigrateStatusCommand())
+	return cmd
+}
+
+// newMigrateStatusCommand fails while the database is behind, so scripts
+// can gate deployments on it.
+func newMigrateStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Compare the schema version with the one this build expects",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withMigrator(cmd, func(ctx context.Context, m container.Migrator) error {
+				current, expected, err := m.SchemaVersion(ctx)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "schema version %d, expected %d\n", current, expected)
+				if current < expected {
+					return fmt.Errorf("schema is %d version(s) behind: run migrate", expected-current)
+				}
+				return nil
+			})
+		},
+	}
+}
+
+func withMigrator(cmd *cobra.Command, fn func(ctx context.Context, m container.Migrator) error) error {
+	return withContainer(cmd, func(ctx context.Context, ctr *container.Container) error {
+		m, ok := ctr.Migrator()
+		if !ok {
+			return errors.New("the configured storage does not support migrations")
+		}
+		return fn(ctx, m)
+	})
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/infrastructure/container"
+	"github.com/spf13/cobra"
+)
+
+// newReconcileCommand runs one reconciliation pass and fails while any
+// discrepancy is left unrepaired, so it can alert from cron.
+func newReconcileCommand() *cobra.Command {
+	var (
+		output string
+		repair bool
+	)
+	cmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Compare stored balances with the transactions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var report *domain.ReconciliationReport
+			err := withContainer(cmd, func(ctx context.Context, ctr *container.Container) (err error) {
+				report, err = ctr.ReconciliationService().Reconcile(ctx, repair)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+
+			if output == "table" {
+				fmt.Fprintf(cmd.OutOrStdout(), "report %d: %d accounts, %d discrepancies, %d repaired\n",
+					report.ID, report.Accounts, len(report.Discrepancies), report.Repaired)
+			}
+			rows := make([][]string, len(report.Discrepancies))
+			for i, d := range report.Discrepancies {
+				rows[i] = []string{
+					formatID(d.AccountID),
+					strconv.FormatFloat(d.Stored, 'f', 2, 64),
+					strconv.FormatFloat(d.Ledger, 'f', 2, 64),
+					strconv.FormatFloat(d.Difference, 'f', 2, 64),
+					strconv.FormatBool(d.Repaired),
+				}
+			}
+			if err := printResult(cmd.OutOrStdout(), output, report,
+				[]string{"ACCOUNT", "STORED", "LEDGER", "DIFFERENCE", "REPAIRED"}, rows); err != nil {
+				return err
+			}
+			if n := report.Unresolved(); n > 0 {
+				return fmt.Errorf("%d balance(s) differ from the transactions", n)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&repair, "repair", false, "correct drifted balances instead of only reporting them")
+	outputFlag(cmd, &output)
+	return cmd
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/export"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/infrastructure/container"
+	"github.com/spf13/cobra"
+)
+
+func newTransactionsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transactions",
+		Short: "Post, look up and list transactions",
+	}
+	cmd.AddCommand(newTransactionsPostCommand(), newTransactionsGetCommand(), newTransactionsListCommand())
+	return cmd
+}
+
+// newTransactionsPostCommand records a manual adjustment through the same
+// service as the API, so validation and payment allocation apply.
+func newTransactionsPostCommand() *cobra.Command {
+	var (
+		output        string
+		accountID     int64
+		operationType int16
+		amount        float64
+		currency      string
+	)
+	cmd := &cobra.Command{
+		Use:   "post",
+		Short: "Post a transaction to an account",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var tx *domain.Transaction
+			err := withContainer(cmd, func(ctx context.Context, ctr *container.Container) (err error) {
+				tx, err = ctr.TransactionService().CreateTransaction(ctx, accountID, operationType, amount, domain.Currency(currency))
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			return printTransactions(cmd, output, tx, tx)
+		},
+	}
+	cmd.Flags().Int64Var(&accountID, "account", 0, "account ID")
+	cmd.Flags().Int16Var(&operationType, "operation-type", 0, "operation type ID: 1 purchase, 2 installment purchase, 3 withdrawal, 4 payment")
+	cmd.Flags().Float64Var(&amount, "amount", 0, "positive amount; the operation type sets the sign")
+	cmd.Flags().StringVar(&currency, "currency", "", "ISO 4217 currency of the amount, converted into the account's (default the account's)")
+	for _, name := range []string{"account", "operation-type", "amount"} {
+		_ = cmd.MarkFlagRequired(name)
+	}
+	outputFlag(cmd, &output)
+	return cmd
+}
+
+func newTransactionsGetCommand() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "get TRANSACTION_ID",
+		Short: "Show a transaction",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			transactionID, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			var tx *domain.Transaction
+			err = withContainer(cmd, func(ctx context.Context, ctr *container.Container) (err error) {
+				tx, err = ctr.TransactionService().GetByTransactionID(ctx, transactionID)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			return printTransactions(cmd, output, tx, tx)
+		},
+	}
+	outputFlag(cmd, &output)
+	return cmd
+}
+
+// newTransactionsListCommand streams an account's transactions; JSON output
+// uses the JSON Lines export format so long histories are not buffered.
+func newTransactionsListCommand() *cobra.Command {
+	var output, from, to string
+	cmd := &cobra.Command{
+		Use:   "list ACCOUNT_ID",
+		Short: "List an account's transactions, oldest first",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			accountID, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			start, err := parseTime(from, false)
+			if err != nil {
+				return fmt.Errorf("invalid --from: %w", err)
+			}
+			end, err := parseTime(to, true)
+			if err != nil {
+				return fmt.Errorf("invalid --to: %w", err)
+			}
+
+			if output == "json" {
+				w, err := export.NewWriter(export.JSONLines, cmd.OutOrStdout(), export.Header{AccountID: accountID, From: start, To: end})
+				if err != nil {
+					return err
+				}
+				err = withContainer(cmd, func(ctx context.Context, ctr *container.Container) error {
+					return ctr.TransactionService().ExportTransactions(ctx, accountID, start, end, nil, w.Write)
+				})
+				return errors.Join(err, w.Close())
+			}
+
+			var txs []*domain.Transaction
+			err = withContainer(cmd, func(ctx context.Context, ctr *container.Container) error {
+				return ctr.TransactionService().ExportTransactions(ctx, accountID, start, end, nil, func(tx *domain.Transaction) error {
+					txs = append(txs, tx)
+					return nil
+				})
+			})
+			if err != nil {
+				return err
+			}
+			return printTransactions(cmd, output, txs, txs...)
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "", "start of the period, inclusive (YYYY-MM-DD or RFC 3339)")
+	cmd.Flags().StringVar(&to, "to", "", "end of the period, exclusive; a plain date includes that day")
+	outputFlag(cmd, &output)
+	return cmd
+}
+
+func printTransactions(cmd *cobra.Command, output string, v any, txs ...*domain.Transaction) error {
+	rows := make([][]string, len(txs))
+	for i, tx := range txs {
+		rows[i] = []string{
+			formatID(tx.ID),
+			formatID(tx.AccountID),
+			strconv.Itoa(int(tx.OperationTypeID)),
+			strconv.FormatFloat(tx.Amount, 'f', 2, 64),
+			string(tx.Currency),
+			"",
+			tx.EventDate.UTC().Format(time.RFC3339),
+		}
+		if c := tx.Conversion; c != nil {
+			rows[i][5] = strconv.FormatFloat(c.Amount, 'f', 2, 64) + " " + string(c.Currency)
+		}
+	}
+	return printResult(cmd.OutOrStdout(), output, v,
+		[]string{"TRANSACTION", "ACCOUNT", "OPERATION", "AMOUNT", "CURRENCY", "ORIGINAL", "EVENT DATE"}, rows)
+}
+
+// parseTime accepts RFC 3339 timestamps or plain dates, like the export
+// endpoint; endOfDay moves a plain date to the start of the next day.
+func parseTime(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
@@ -26,28 +26,71 @@ const docTemplate = `{
     "paths": {
         "/health": {
             "get": {
-                "description": "Retorna o status de saúde da API e suas dependências",
-                "consumes": [
+                "description": "Executa as verificações registradas (banco de dados, migrações) e retorna o status e a latência de cada componente",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Verificar se a API está pronta",
+                "responses": {
+                    "200": {
+                        "description": "API pronta para receber tráfego",
+                        "schema": {
+                            "$ref": "#/definitions/domain.HealthReport"
+                        }
+                    },
+                    "503": {
+                        "description": "Alguma dependência indisponível ou API encerrando",
+                        "schema": {
+                            "$ref": "#/definitions/domain.HealthReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "Retorna 200 enquanto o processo estiver em execução, sem consultar dependências",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Verificar se o processo está vivo",
+                "responses": {
+                    "200": {
+                        "description": "Processo em execução",
+                        "schema": {
+                            "$ref": "#/definitions/domain.HealthReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Executa as verificações registradas (banco de dados, migrações) e retorna o status e a latência de cada componente",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Health"
                 ],
-                "summary": "Verificar saúde da API",
+                "summary": "Verificar se a API está pronta",
                 "responses": {
                     "200": {
-                        "description": "API está operacional",
+                        "description": "API pronta para receber tráfego",
                         "schema": {
-                            "$ref": "#/definitions/handler.HealthResponse"
+                            "$ref": "#/definitions/domain.HealthReport"
                         }
                     },
                     "503": {
-                        "description": "API indisponível",
+                        "description": "Alguma dependência indisponível ou API encerrando",
                         "schema": {
-                            "$ref": "#/definitions/handler.ServiceUnavailableError"
+                            "$ref": "#/definitions/domain.HealthReport"
                         }
                     }
                 }
@@ -55,7 +98,7 @@ const docTemplate = `{
         },
         "/v1/accounts": {
             "post": {
-                "description": "Cria uma nova conta bancária com CPF ou CNPJ",
+                "description": "Cria uma nova conta bancária com CPF ou CNPJ. A moeda da conta é fixa; sem \"currency\", vale a moeda padrão do serviço",
                 "consumes": [
                     "application/json"
                 ],
@@ -90,13 +133,93 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.BadRequestError"
                         }
                     },
+                    "413": {
+                        "description": "Corpo da requisição muito grande",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PayloadTooLargeError"
+                        }
+                    },
                     "500": {
                         "description": "Erro interno do servidor",
                         "schema": {
                             "$ref": "#/definitions/handler.InternalServerError"
                         }
+                    },
+                    "504": {
+                        "description": "Tempo limite da requisição excedido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TimeoutError"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/accounts/batch": {
+            "post": {
+                "description": "Cria uma conta para cada documento novo e informa o resultado de cada item: criada, duplicada (com o ID da conta existente, quando visível) ou inválida. Duplicados e documentos inválidos não impedem a criação dos demais. Todas as contas do lote usam a mesma moeda",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Accounts"
+                ],
+                "summary": "Criar contas em lote",
+                "parameters": [
+                    {
+                        "description": "Documentos das contas",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateAccountsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "207": {
+                        "description": "Resultado por item",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AccountBatchReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BadRequestError"
+                        }
+                    },
+                    "413": {
+                        "description": "Corpo da requisição muito grande",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PayloadTooLargeError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternalServerError"
+                        }
+                    },
+                    "504": {
+                        "description": "Tempo limite da requisição excedido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TimeoutError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/v1/accounts/{accountId}": {
@@ -146,13 +269,83 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.InternalServerError"
                         }
+                    },
+                    "504": {
+                        "description": "Tempo limite da requisição excedido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TimeoutError"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/v1/transactions": {
-            "post": {
-                "description": "Cria uma nova transação bancária (débito/crédito)",
+        "/v1/accounts/{accountId}/balances": {
+            "get": {
+                "description": "Retorna o saldo da conta na sua moeda e, para cada moeda em que houve transações, o total no valor original e o total contabilizado na moeda da conta",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Accounts"
+                ],
+                "summary": "Obter saldos por moeda",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "ID da conta",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Saldos da conta",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AccountBalances"
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Conta não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handler.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternalServerError"
+                        }
+                    },
+                    "504": {
+                        "description": "Tempo limite da requisição excedido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TimeoutError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/accounts/{accountId}/billing-cycle": {
+            "get": {
+                "description": "Retorna o dia do mês em que as faturas da conta fecham",
                 "consumes": [
                     "application/json"
                 ],
@@ -160,25 +353,93 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Statements"
                 ],
-                "summary": "Criar transação",
+                "summary": "Obter ciclo de faturamento",
                 "parameters": [
                     {
-                        "description": "Dados da transação",
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "ID da conta",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ciclo de faturamento",
+                        "schema": {
+                            "$ref": "#/definitions/domain.BillingCycle"
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Conta não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handler.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternalServerError"
+                        }
+                    },
+                    "504": {
+                        "description": "Tempo limite da requisição excedido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TimeoutError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Define o dia do mês (1 a 28) em que as faturas da conta fecham. Vale a partir do próximo fechamento",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statements"
+                ],
+                "summary": "Alterar ciclo de faturamento",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "ID da conta",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dia de fechamento",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.createTransactionRequest"
+                            "$ref": "#/definitions/handler.setBillingCycleRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Transação criada com sucesso",
+                    "200": {
+                        "description": "Ciclo de faturamento atualizado",
                         "schema": {
-                            "$ref": "#/definitions/domain.Transaction"
+                            "$ref": "#/definitions/domain.BillingCycle"
                         }
                     },
                     "400": {
@@ -193,18 +454,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.NotFoundError"
                         }
                     },
+                    "413": {
+                        "description": "Corpo da requisição muito grande",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PayloadTooLargeError"
+                        }
+                    },
                     "500": {
                         "description": "Erro interno do servidor",
                         "schema": {
                             "$ref": "#/definitions/handler.InternalServerError"
                         }
+                    },
+                    "504": {
+                        "description": "Tempo limite da requisição excedido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TimeoutError"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/v1/transactions/{transactionId}": {
+        "/v1/accounts/{accountId}/statements": {
             "get": {
-                "description": "Retorna os detalhes de uma transação específica",
+                "description": "Retorna as faturas fechadas da conta, da mais recente para a mais antiga",
                 "consumes": [
                     "application/json"
                 ],
@@ -212,24 +490,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Statements"
                 ],
-                "summary": "Obter transação por ID",
+                "summary": "Listar faturas da conta",
                 "parameters": [
                     {
                         "type": "integer",
                         "format": "int64",
-                        "description": "ID da transação",
-                        "name": "transactionId",
+                        "description": "ID da conta",
+                        "name": "accountId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Transação encontrada",
+                        "description": "Faturas da conta",
                         "schema": {
-                            "$ref": "#/definitions/domain.Transaction"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Statement"
+                            }
                         }
                     },
                     "400": {
@@ -239,7 +520,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Transação não encontrada",
+                        "description": "Conta não encontrada",
                         "schema": {
                             "$ref": "#/definitions/handler.NotFoundError"
                         }
@@ -249,29 +530,872 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.InternalServerError"
                         }
+                    },
+                    "504": {
+                        "description": "Tempo limite da requisição excedido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TimeoutError"
+                        }
                     }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "domain.Account": {
-            "type": "object",
-            "properties": {
-                "account_id": {
-                    "type": "integer"
                 },
-                "document_number": {
-                    "type": "string"
-                }
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "domain.OperationType": {
-            "type": "integer",
-            "format": "int32",
-            "enum": [
-                1,
-                2,
+        "/v1/accounts/{accountId}/statements/{statementId}": {
+            "get": {
+                "description": "Retorna uma fatura com as transações incluídas e os pagamentos que a quitaram",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statements"
+                ],
+                "summary": "Obter fatura",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "ID da conta",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "ID da fatura",
+                        "name": "statementId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Fatura encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Statement"
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Fatura não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handler.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternalServerError"
+                        }
+                    },
+                    "504": {
+                        "description": "Tempo limite da requisição excedido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TimeoutError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/accounts/{accountId}/transactions/export": {
+            "get": {
+                "description": "Baixa as transações da conta no período [from, to) em CSV, OFX 2.2 ou JSON Lines, em ordem cronológica. As datas aceitam AAAA-MM-DD ou RFC 3339; um \"to\" só com a data inclui o dia inteiro",
+                "produces": [
+                    "text/csv",
+                    "application/x-ofx",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Exportar transações da conta",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "ID da conta",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "ofx",
+                            "jsonl"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Formato do arquivo",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-03-01",
+                        "description": "Início do período (inclusivo)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-03-31",
+                        "description": "Fim do período (exclusivo)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Arquivo com as transações",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Parâmetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Conta não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handler.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternalServerError"
+                        }
+                    },
+                    "504": {
+                        "description": "Tempo limite da requisição excedido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TimeoutError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/transactions": {
+            "post": {
+                "description": "Cria uma nova transação bancária (débito/crédito). Um valor em outra moeda que não a da conta é convertido pela cotação vigente, que fica registrada na transação",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Criar transação",
+                "parameters": [
+                    {
+                        "description": "Dados da transação",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.createTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Transação criada com sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Conta não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handler.NotFoundError"
+                        }
+                    },
+                    "413": {
+                        "description": "Corpo da requisição muito grande",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PayloadTooLargeError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternalServerError"
+                        }
+                    },
+                    "504": {
+                        "description": "Tempo limite da requisição excedido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TimeoutError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/transactions/imports": {
+            "post": {
+                "description": "Enfileira um arquivo CSV ou JSON Lines de transações para importação em segundo plano. O formato vem do parâmetro format ou do Content-Type (CSV por padrão). Acompanhe o resultado pela URL do cabeçalho Location",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Importar transações em lote",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "jsonl"
+                        ],
+                        "type": "string",
+                        "description": "Formato do arquivo",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "all_or_nothing",
+                            "best_effort"
+                        ],
+                        "type": "string",
+                        "default": "all_or_nothing",
+                        "description": "Modo de importação",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Arquivo com as transações",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Importação enfileirada",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ImportJob"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL da importação"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Arquivo, formato ou modo inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BadRequestError"
+                        }
+                    },
+                    "413": {
+                        "description": "Corpo da requisição muito grande",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PayloadTooLargeError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternalServerError"
+                        }
+                    },
+                    "504": {
+                        "description": "Tempo limite da requisição excedido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TimeoutError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/transactions/imports/{jobId}": {
+            "get": {
+                "description": "Retorna a situação de uma importação e, quando terminada, o relatório por linha. Os motivos são traduzidos conforme o Accept-Language",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Obter importação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "ID da importação",
+                        "name": "jobId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Importação encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Importação não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handler.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternalServerError"
+                        }
+                    },
+                    "504": {
+                        "description": "Tempo limite da requisição excedido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TimeoutError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/transactions/{transactionId}": {
+            "get": {
+                "description": "Retorna os detalhes de uma transação específica",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Obter transação por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "ID da transação",
+                        "name": "transactionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transação encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Transação não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handler.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternalServerError"
+                        }
+                    },
+                    "504": {
+                        "description": "Tempo limite da requisição excedido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TimeoutError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/transactions/{transactionId}/journal": {
+            "get": {
+                "description": "Retorna o lançamento de partidas dobradas que registrou a transação, com seus débitos e créditos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Obter lançamento contábil da transação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "ID da transação",
+                        "name": "transactionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lançamento encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/domain.JournalEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Transação ou lançamento não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handler.NotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternalServerError"
+                        }
+                    },
+                    "504": {
+                        "description": "Tempo limite da requisição excedido",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TimeoutError"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "domain.Account": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "document_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AccountBalances": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "currencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CurrencyBalance"
+                    }
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                }
+            }
+        },
+        "domain.AccountBatchReport": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "duplicates": {
+                    "type": "integer"
+                },
+                "invalid": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.AccountBatchResult"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.AccountBatchResult": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "document_number": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.AccountBatchStatus"
+                }
+            }
+        },
+        "domain.AccountBatchStatus": {
+            "type": "string",
+            "enum": [
+                "created",
+                "duplicate",
+                "invalid"
+            ],
+            "x-enum-varnames": [
+                "AccountBatchCreated",
+                "AccountBatchDuplicate",
+                "AccountBatchInvalid"
+            ]
+        },
+        "domain.BillingCycle": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "billing_day": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ComponentHealth": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error is a coarse reason (\"timeout\" or \"unavailable\"); the underlying\nerror is only logged.",
+                    "type": "string",
+                    "example": "timeout"
+                },
+                "latency_ms": {
+                    "type": "number",
+                    "example": 1.25
+                },
+                "name": {
+                    "type": "string",
+                    "example": "database"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "up"
+                }
+            }
+        },
+        "domain.Conversion": {
+            "type": "object",
+            "properties": {
+                "original_amount": {
+                    "type": "number",
+                    "example": -20
+                },
+                "original_currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "rate": {
+                    "type": "number",
+                    "example": 5.42
+                },
+                "rate_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CurrencyBalance": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": -20
+                },
+                "booked": {
+                    "type": "number",
+                    "example": -108.4
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                }
+            }
+        },
+        "domain.Direction": {
+            "type": "string",
+            "enum": [
+                "debit",
+                "credit"
+            ],
+            "x-enum-varnames": [
+                "Debit",
+                "Credit"
+            ]
+        },
+        "domain.HealthReport": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ComponentHealth"
+                    }
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "shutting down"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "up"
+                }
+            }
+        },
+        "domain.ImportJob": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "$ref": "#/definitions/domain.ImportMode"
+                },
+                "report": {
+                    "$ref": "#/definitions/domain.ImportReport"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.ImportStatus"
+                },
+                "submitted_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ImportLineResult": {
+            "type": "object",
+            "properties": {
+                "line": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.ImportLineStatus"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ImportLineStatus": {
+            "type": "string",
+            "enum": [
+                "created",
+                "rejected",
+                "skipped"
+            ],
+            "x-enum-varnames": [
+                "ImportLineCreated",
+                "ImportLineRejected",
+                "ImportLineSkipped"
+            ]
+        },
+        "domain.ImportMode": {
+            "type": "string",
+            "enum": [
+                "all_or_nothing",
+                "best_effort"
+            ],
+            "x-enum-varnames": [
+                "ImportAllOrNothing",
+                "ImportBestEffort"
+            ]
+        },
+        "domain.ImportReport": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ImportLineResult"
+                    }
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ImportStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ImportPending",
+                "ImportRunning",
+                "ImportSucceeded",
+                "ImportFailed"
+            ]
+        },
+        "domain.JournalEntry": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "posted_at": {
+                    "type": "string"
+                },
+                "postings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Posting"
+                    }
+                },
+                "transaction_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.LedgerAccount": {
+            "type": "string",
+            "enum": [
+                "customer",
+                "merchant_settlement",
+                "cash",
+                "fees"
+            ],
+            "x-enum-varnames": [
+                "CustomerLedger",
+                "MerchantSettlementLedger",
+                "CashLedger",
+                "FeesLedger"
+            ]
+        },
+        "domain.OperationType": {
+            "type": "integer",
+            "format": "int32",
+            "enum": [
+                1,
+                2,
                 3,
                 4
             ],
@@ -282,6 +1406,103 @@ const docTemplate = `{
                 "Payment"
             ]
         },
+        "domain.PaymentAllocation": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "statement_id": {
+                    "type": "integer"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Posting": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "direction": {
+                    "$ref": "#/definitions/domain.Direction"
+                },
+                "ledger_account": {
+                    "$ref": "#/definitions/domain.LedgerAccount"
+                }
+            }
+        },
+        "domain.Statement": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "amount_paid": {
+                    "type": "number"
+                },
+                "closing_balance": {
+                    "type": "number"
+                },
+                "credits": {
+                    "type": "number"
+                },
+                "debits": {
+                    "type": "number"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "minimum_payment": {
+                    "type": "number"
+                },
+                "opening_balance": {
+                    "type": "number"
+                },
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PaymentAllocation"
+                    }
+                },
+                "period_end": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "statement_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.StatementStatus"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Transaction"
+                    }
+                }
+            }
+        },
+        "domain.StatementStatus": {
+            "type": "string",
+            "enum": [
+                "paid",
+                "open",
+                "overdue"
+            ],
+            "x-enum-varnames": [
+                "StatementPaid",
+                "StatementOpen",
+                "StatementOverdue"
+            ]
+        },
         "domain.Transaction": {
             "type": "object",
             "properties": {
@@ -291,6 +1512,12 @@ const docTemplate = `{
                 "amount": {
                     "type": "number"
                 },
+                "conversion": {
+                    "$ref": "#/definitions/domain.Conversion"
+                },
+                "currency": {
+                    "type": "string"
+                },
                 "operation_type_id": {
                     "$ref": "#/definitions/domain.OperationType"
                 },
@@ -306,9 +1533,19 @@ const docTemplate = `{
                     "type": "string",
                     "example": "VALIDATION_ERROR"
                 },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.FieldError"
+                    }
+                },
                 "message": {
                     "type": "string",
                     "example": "document_number is required"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "4f9c1b7e-2d0a-4c39-9a8e-1f2b3c4d5e6f"
                 }
             }
         },
@@ -318,22 +1555,48 @@ const docTemplate = `{
                 "document_number"
             ],
             "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
                 "document_number": {
                     "type": "string",
                     "example": "12345678901"
                 }
             }
         },
-        "handler.HealthResponse": {
+        "handler.CreateAccountsRequest": {
             "type": "object",
+            "required": [
+                "document_numbers"
+            ],
             "properties": {
-                "detail": {
+                "currency": {
                     "type": "string",
-                    "example": "database connection failed"
+                    "example": "BRL"
                 },
-                "status": {
+                "document_numbers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "12345678901",
+                        "12345678000199"
+                    ]
+                }
+            }
+        },
+        "handler.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
                     "type": "string",
-                    "example": "up"
+                    "example": "document_number"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "is required"
                 }
             }
         },
@@ -347,6 +1610,10 @@ const docTemplate = `{
                 "message": {
                     "type": "string",
                     "example": "unexpected error on internal service"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "4f9c1b7e-2d0a-4c39-9a8e-1f2b3c4d5e6f"
                 }
             }
         },
@@ -360,19 +1627,44 @@ const docTemplate = `{
                 "message": {
                     "type": "string",
                     "example": "account not found"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "4f9c1b7e-2d0a-4c39-9a8e-1f2b3c4d5e6f"
                 }
             }
         },
-        "handler.ServiceUnavailableError": {
+        "handler.PayloadTooLargeError": {
             "type": "object",
             "properties": {
-                "detail": {
+                "code": {
                     "type": "string",
-                    "example": "database connection failed"
+                    "example": "PAYLOAD_TOO_LARGE"
                 },
-                "status": {
+                "message": {
+                    "type": "string",
+                    "example": "request body too large"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "4f9c1b7e-2d0a-4c39-9a8e-1f2b3c4d5e6f"
+                }
+            }
+        },
+        "handler.TimeoutError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "TIMEOUT"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "the request took too long to complete"
+                },
+                "request_id": {
                     "type": "string",
-                    "example": "unavailable"
+                    "example": "4f9c1b7e-2d0a-4c39-9a8e-1f2b3c4d5e6f"
                 }
             }
         },
@@ -392,11 +1684,35 @@ const docTemplate = `{
                     "type": "number",
                     "example": 100.5
                 },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
                 "operation_type_id": {
                     "type": "integer",
                     "example": 1
                 }
             }
+        },
+        "handler.setBillingCycleRequest": {
+            "type": "object",
+            "required": [
+                "billing_day"
+            ],
+            "properties": {
+                "billing_day": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Token JWT no formato \"Bearer {token}\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
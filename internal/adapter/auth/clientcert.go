@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+)
+
+// ClientCertIdentities maps client certificate subjects to principals. Keys
+// are either the full subject distinguished name, e.g.
+// "CN=billing,O=Acme", or just the common name; the full name wins.
+type ClientCertIdentities struct {
+	identities map[string]domain.Principal
+}
+
+// LoadClientCertIdentities reads a JSON object whose keys are subjects and
+// whose values use the principal's token claim names (sub, tenant, scopes,
+// roles).
+func LoadClientCertIdentities(path string) (*ClientCertIdentities, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read client identities: %w", err)
+	}
+	return ParseClientCertIdentities(data)
+}
+
+func ParseClientCertIdentities(data []byte) (*ClientCertIdentities, error) {
+	var identities map[string]domain.Principal
+	if err := json.Unmarshal(data, &identities); err != nil {
+		return nil, fmt.Errorf("decode client identities: %w", err)
+	}
+	for subject, p := range identities {
+		if p.Subject == "" {
+			return nil, fmt.Errorf("client identity %q has no sub", subject)
+		}
+	}
+	return &ClientCertIdentities{identities: identities}, nil
+}
+
+func (m *ClientCertIdentities) Principal(cert *x509.Certificate) (*domain.Principal, bool) {
+	for _, key := range []string{cert.Subject.String(), cert.Subject.CommonName} {
+		if p, ok := m.identities[key]; ok && key != "" {
+			return &p, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,63 @@
+package auth_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCertIdentities(t *testing.T) {
+	identities, err := auth.ParseClientCertIdentities([]byte(`{
+		"CN=billing,O=Acme": {"sub": "svc-billing", "tenant": "acme", "roles": ["admin"]},
+		"billing": {"sub": "svc-billing-any-org"},
+		"reports": {"sub": "svc-reports", "scopes": ["accounts:read"]}
+	}`))
+	require.NoError(t, err)
+
+	t.Run("should prefer the full subject", func(t *testing.T) {
+		p, ok := identities.Principal(&x509.Certificate{Subject: pkix.Name{CommonName: "billing", Organization: []string{"Acme"}}})
+
+		require.True(t, ok)
+		assert.Equal(t, "svc-billing", p.Subject)
+		assert.True(t, p.IsAdmin())
+	})
+
+	t.Run("should fall back to the common name", func(t *testing.T) {
+		p, ok := identities.Principal(&x509.Certificate{Subject: pkix.Name{CommonName: "reports", Organization: []string{"Other"}}})
+
+		require.True(t, ok)
+		assert.Equal(t, "svc-reports", p.Subject)
+		assert.True(t, p.HasScope("accounts:read"))
+	})
+
+	t.Run("should not map unknown subjects", func(t *testing.T) {
+		_, ok := identities.Principal(&x509.Certificate{Subject: pkix.Name{CommonName: "stranger"}})
+
+		assert.False(t, ok)
+	})
+
+	t.Run("should reject identities without a subject", func(t *testing.T) {
+		_, err := auth.ParseClientCertIdentities([]byte(`{"billing": {"roles": ["admin"]}}`))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should load from file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "identities.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"billing": {"sub": "svc-billing"}}`), 0o600))
+
+		loaded, err := auth.LoadClientCertIdentities(path)
+		require.NoError(t, err)
+		_, ok := loaded.Principal(&x509.Certificate{Subject: pkix.Name{CommonName: "billing"}})
+		assert.True(t, ok)
+
+		_, err = auth.LoadClientCertIdentities(path + ".missing")
+		assert.Error(t, err)
+	})
+}
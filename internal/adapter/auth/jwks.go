@@ -0,0 +1,325 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	ErrKeyNotFound = errors.New("signing key not found in key set")
+	ErrEmptyKeySet = errors.New("key set contains no usable keys")
+)
+
+const (
+	defaultCacheTTL        = 10 * time.Minute
+	defaultMinRefreshDelay = 30 * time.Second
+	maxJWKSResponseBytes   = 1 << 20
+)
+
+// JSONWebKey is a verification key decoded from a JWKS document. Key holds
+// an *rsa.PublicKey, an *ecdsa.PublicKey or a []byte HMAC secret.
+type JSONWebKey struct {
+	KeyID     string
+	Algorithm string
+	Key       interface{}
+}
+
+type KeyProvider interface {
+	Key(ctx context.Context, kid string) (*JSONWebKey, error)
+}
+
+// JWKSProvider serves keys from a JWKS document loaded from a file or URL.
+// The document is cached for the configured TTL and re-fetched early when a
+// token references an unknown key ID, so rotated keys are picked up without
+// a restart. Refreshes triggered by unknown key IDs are throttled, and so
+// are retries after a failed refresh.
+type JWKSProvider struct {
+	fetch           func(ctx context.Context) ([]byte, error)
+	ttl             time.Duration
+	minRefreshDelay time.Duration
+	now             func() time.Time
+
+	mu        sync.Mutex
+	keys      map[string]*JSONWebKey
+	fetchedAt time.Time
+	fetchErr  error
+	failedAt  time.Time
+	inflight  chan struct{}
+}
+
+func NewJWKSFileProvider(path string, ttl time.Duration) *JWKSProvider {
+	return newJWKSProvider(func(ctx context.Context) ([]byte, error) {
+		return os.ReadFile(path)
+	}, ttl)
+}
+
+func NewJWKSURLProvider(url string, client *http.Client, ttl time.Duration) *JWKSProvider {
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	return newJWKSProvider(func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status fetching jwks: %d", resp.StatusCode)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, maxJWKSResponseBytes))
+	}, ttl)
+}
+
+func newJWKSProvider(fetch func(ctx context.Context) ([]byte, error), ttl time.Duration) *JWKSProvider {
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	return &JWKSProvider{
+		fetch:           fetch,
+		ttl:             ttl,
+		minRefreshDelay: defaultMinRefreshDelay,
+		now:             time.Now,
+	}
+}
+
+func (p *JWKSProvider) Key(ctx context.Context, kid string) (*JSONWebKey, error) {
+	p.mu.Lock()
+	keys, fetchedAt := p.keys, p.fetchedAt
+	p.mu.Unlock()
+
+	now := p.now()
+	stale := keys == nil || now.Sub(fetchedAt) >= p.ttl
+	if stale {
+		// Only callers with nothing cached wait for the fetch; the others
+		// keep using the stale keys until it lands.
+		done := p.refresh(ctx)
+		if keys == nil {
+			var err error
+			if keys, err = p.await(ctx, done); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if key, ok := lookup(keys, kid); ok {
+		return key, nil
+	}
+
+	if stale || now.Sub(fetchedAt) >= p.minRefreshDelay {
+		keys, err := p.await(ctx, p.refresh(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if key, ok := lookup(keys, kid); ok {
+			return key, nil
+		}
+	}
+
+	return nil, ErrKeyNotFound
+}
+
+func lookup(keys map[string]*JSONWebKey, kid string) (*JSONWebKey, bool) {
+	if kid == "" {
+		if len(keys) == 1 {
+			for _, key := range keys {
+				return key, true
+			}
+		}
+		return nil, false
+	}
+	key, ok := keys[kid]
+	return key, ok
+}
+
+// refresh starts fetching the document unless a fetch is already running
+// and returns a channel closed when it is done. After a failure no fetch
+// starts for minRefreshDelay, so an outage of the identity provider costs
+// one fetch per delay rather than one per request; refresh then returns
+// nil.
+func (p *JWKSProvider) refresh(ctx context.Context) <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.inflight != nil {
+		return p.inflight
+	}
+	if p.fetchErr != nil && p.now().Sub(p.failedAt) < p.minRefreshDelay {
+		return nil
+	}
+
+	done := make(chan struct{})
+	p.inflight = done
+	// Other callers may join the fetch, so it outlives the one that
+	// started it.
+	go p.load(context.WithoutCancel(ctx), done)
+	return done
+}
+
+// load keeps the previously cached keys when the source is unavailable,
+// so a transient outage of the identity provider does not reject every token.
+func (p *JWKSProvider) load(ctx context.Context, done chan struct{}) {
+	keys, err := p.fetchKeys(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err != nil {
+		p.fetchErr, p.failedAt = err, p.now()
+	} else {
+		p.keys, p.fetchedAt, p.fetchErr = keys, p.now(), nil
+	}
+	p.inflight = nil
+	close(done)
+}
+
+func (p *JWKSProvider) fetchKeys(ctx context.Context) (map[string]*JSONWebKey, error) {
+	data, err := p.fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+	return ParseJWKS(data)
+}
+
+// await waits for the fetch behind done, if any, and returns the cached
+// keys or the error of the last fetch when it failed.
+func (p *JWKSProvider) await(ctx context.Context, done <-chan struct{}) (map[string]*JSONWebKey, error) {
+	if done != nil {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.fetchErr != nil {
+		return nil, p.fetchErr
+	}
+	return p.keys, nil
+}
+
+type rawJWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// ParseJWKS decodes a JWKS document, skipping keys that are not meant for
+// signatures or use unsupported key types.
+func ParseJWKS(data []byte) (map[string]*JSONWebKey, error) {
+	var doc struct {
+		Keys []rawJWK `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode jwks: %w", err)
+	}
+
+	keys := make(map[string]*JSONWebKey, len(doc.Keys))
+	for _, raw := range doc.Keys {
+		if raw.Use != "" && raw.Use != "sig" {
+			continue
+		}
+
+		key, err := raw.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("decode jwk %q: %w", raw.Kid, err)
+		}
+		if key == nil {
+			continue
+		}
+
+		keys[raw.Kid] = &JSONWebKey{KeyID: raw.Kid, Algorithm: raw.Alg, Key: key}
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrEmptyKeySet
+	}
+	return keys, nil
+}
+
+func (raw rawJWK) publicKey() (interface{}, error) {
+	switch raw.Kty {
+	case "RSA":
+		n, err := decodeBigInt(raw.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(raw.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		curve, err := curveByName(raw.Crv)
+		if err != nil {
+			return nil, err
+		}
+		x, err := decodeBigInt(raw.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(raw.Y)
+		if err != nil {
+			return nil, err
+		}
+		pub := &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+		if _, err := pub.ECDH(); err != nil {
+			return nil, err
+		}
+		return pub, nil
+	case "oct":
+		return base64.RawURLEncoding.DecodeString(raw.K)
+	default:
+		return nil, nil
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, errors.New("missing key parameter")
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func curveByName(name string) (elliptic.Curve, error) {
+	switch name {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", name)
+	}
+}
@@ -0,0 +1,288 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"alg": "RS256",
+		"use": "sig",
+		"n":   b64(key.N.Bytes()),
+		"e":   b64(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "EC",
+		"kid": kid,
+		"alg": "ES256",
+		"crv": "P-256",
+		"x":   b64(key.X.FillBytes(make([]byte, 32))),
+		"y":   b64(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func octJWK(kid string, secret []byte) map[string]string {
+	return map[string]string{"kty": "oct", "kid": kid, "alg": "HS256", "k": b64(secret)}
+}
+
+func jwksDocument(t *testing.T, keys ...map[string]string) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string]interface{}{"keys": keys})
+	require.NoError(t, err)
+	return data
+}
+
+func TestParseJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("should decode RSA, EC and oct keys", func(t *testing.T) {
+		doc := jwksDocument(t,
+			rsaJWK("rsa-1", &rsaKey.PublicKey),
+			ecJWK("ec-1", &ecKey.PublicKey),
+			octJWK("hs-1", []byte("secret")),
+		)
+
+		keys, err := auth.ParseJWKS(doc)
+
+		require.NoError(t, err)
+		assert.Len(t, keys, 3)
+		assert.True(t, rsaKey.PublicKey.Equal(keys["rsa-1"].Key))
+		assert.True(t, ecKey.PublicKey.Equal(keys["ec-1"].Key))
+		assert.Equal(t, []byte("secret"), keys["hs-1"].Key)
+		assert.Equal(t, "RS256", keys["rsa-1"].Algorithm)
+	})
+
+	t.Run("should skip encryption keys and unknown key types", func(t *testing.T) {
+		enc := rsaJWK("enc-1", &rsaKey.PublicKey)
+		enc["use"] = "enc"
+		doc := jwksDocument(t, enc, map[string]string{"kty": "OKP", "kid": "ed-1"}, rsaJWK("rsa-1", &rsaKey.PublicKey))
+
+		keys, err := auth.ParseJWKS(doc)
+
+		require.NoError(t, err)
+		assert.Len(t, keys, 1)
+		assert.Contains(t, keys, "rsa-1")
+	})
+
+	t.Run("should reject EC point not on curve", func(t *testing.T) {
+		bad := ecJWK("ec-1", &ecKey.PublicKey)
+		bad["y"] = b64([]byte{1})
+
+		_, err := auth.ParseJWKS(jwksDocument(t, bad))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail on empty key set", func(t *testing.T) {
+		_, err := auth.ParseJWKS([]byte(`{"keys":[]}`))
+		assert.ErrorIs(t, err, auth.ErrEmptyKeySet)
+	})
+
+	t.Run("should fail on malformed document", func(t *testing.T) {
+		_, err := auth.ParseJWKS([]byte(`not json`))
+		assert.Error(t, err)
+	})
+}
+
+func TestJWKSURLProvider(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should cache keys between lookups", func(t *testing.T) {
+		var hits int32
+		doc := jwksDocument(t, octJWK("k1", []byte("one")))
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&hits, 1)
+			w.Write(doc)
+		}))
+		defer srv.Close()
+
+		p := auth.NewJWKSURLProvider(srv.URL, srv.Client(), time.Hour)
+
+		for i := 0; i < 3; i++ {
+			key, err := p.Key(ctx, "k1")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("one"), key.Key)
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+	})
+
+	t.Run("should refetch after TTL to pick up rotated keys", func(t *testing.T) {
+		var current atomic.Value
+		current.Store(jwksDocument(t, octJWK("k1", []byte("one"))))
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write(current.Load().([]byte))
+		}))
+		defer srv.Close()
+
+		p := auth.NewJWKSURLProvider(srv.URL, srv.Client(), 10*time.Millisecond)
+		_, err := p.Key(ctx, "k1")
+		require.NoError(t, err)
+
+		current.Store(jwksDocument(t, octJWK("k2", []byte("two"))))
+		time.Sleep(20 * time.Millisecond)
+
+		key, err := p.Key(ctx, "k2")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("two"), key.Key)
+
+		_, err = p.Key(ctx, "k1")
+		assert.ErrorIs(t, err, auth.ErrKeyNotFound)
+	})
+
+	t.Run("should keep cached keys when endpoint fails", func(t *testing.T) {
+		var failing atomic.Bool
+		doc := jwksDocument(t, octJWK("k1", []byte("one")))
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if failing.Load() {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Write(doc)
+		}))
+		defer srv.Close()
+
+		p := auth.NewJWKSURLProvider(srv.URL, srv.Client(), 10*time.Millisecond)
+		_, err := p.Key(ctx, "k1")
+		require.NoError(t, err)
+
+		failing.Store(true)
+		time.Sleep(20 * time.Millisecond)
+
+		key, err := p.Key(ctx, "k1")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("one"), key.Key)
+	})
+
+	t.Run("should back off after a failed refresh", func(t *testing.T) {
+		var hits int32
+		var failing atomic.Bool
+		doc := jwksDocument(t, octJWK("k1", []byte("one")))
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&hits, 1)
+			if failing.Load() {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Write(doc)
+		}))
+		defer srv.Close()
+
+		p := auth.NewJWKSURLProvider(srv.URL, srv.Client(), 10*time.Millisecond)
+		_, err := p.Key(ctx, "k1")
+		require.NoError(t, err)
+
+		failing.Store(true)
+		time.Sleep(20 * time.Millisecond)
+
+		_, err = p.Key(ctx, "k1")
+		require.NoError(t, err)
+		_, err = p.Key(ctx, "unknown")
+		assert.Error(t, err)
+		assert.Eventually(t, func() bool { return atomic.LoadInt32(&hits) == 2 }, time.Second, time.Millisecond)
+
+		for i := 0; i < 3; i++ {
+			key, err := p.Key(ctx, "k1")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("one"), key.Key)
+			_, err = p.Key(ctx, "unknown")
+			assert.Error(t, err)
+		}
+		assert.Equal(t, int32(2), atomic.LoadInt32(&hits))
+	})
+
+	t.Run("should serve cached keys while a refresh is slow", func(t *testing.T) {
+		var hits int32
+		release := make(chan struct{})
+		doc := jwksDocument(t, octJWK("k1", []byte("one")))
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&hits, 1) > 1 {
+				<-release
+			}
+			w.Write(doc)
+		}))
+		defer srv.Close()
+		defer close(release)
+
+		p := auth.NewJWKSURLProvider(srv.URL, srv.Client(), 10*time.Millisecond)
+		_, err := p.Key(ctx, "k1")
+		require.NoError(t, err)
+
+		time.Sleep(20 * time.Millisecond)
+
+		for i := 0; i < 3; i++ {
+			key, err := p.Key(ctx, "k1")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("one"), key.Key)
+		}
+		assert.Eventually(t, func() bool { return atomic.LoadInt32(&hits) == 2 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("should fail when endpoint is unavailable and nothing is cached", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+
+		p := auth.NewJWKSURLProvider(srv.URL, srv.Client(), time.Hour)
+		_, err := p.Key(ctx, "k1")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should use the only key when token has no kid", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write(jwksDocument(t, octJWK("k1", []byte("one"))))
+		}))
+		defer srv.Close()
+
+		p := auth.NewJWKSURLProvider(srv.URL, srv.Client(), time.Hour)
+		key, err := p.Key(ctx, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "k1", key.KeyID)
+	})
+}
+
+func TestJWKSFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwksDocument(t, octJWK("file-key", []byte("secret"))), 0o600))
+
+	p := auth.NewJWKSFileProvider(path, time.Minute)
+	key, err := p.Key(context.Background(), "file-key")
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), key.Key)
+
+	_, err = auth.NewJWKSFileProvider(filepath.Join(t.TempDir(), "missing.json"), time.Minute).Key(context.Background(), "x")
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var errKeyAlgorithmMismatch = errors.New("key is not valid for token algorithm")
+
+var supportedAlgorithms = []string{"RS256", "ES256", "HS256"}
+
+type JWTVerifier struct {
+	keys   KeyProvider
+	parser *jwt.Parser
+}
+
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Tenant string   `json:"tenant,omitempty"`
+	Scope  string   `json:"scope,omitempty"`
+	Scp    []string `json:"scp,omitempty"`
+	Roles  []string `json:"roles,omitempty"`
+}
+
+func NewJWTVerifier(keys KeyProvider, issuer, audience string, leeway time.Duration) *JWTVerifier {
+	return &JWTVerifier{
+		keys: keys,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods(supportedAlgorithms),
+			jwt.WithIssuer(issuer),
+			jwt.WithAudience(audience),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(leeway),
+		),
+	}
+}
+
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (*domain.Principal, error) {
+	var claims tokenClaims
+	_, err := v.parser.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		jwk, err := v.keys.Key(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		if !keyMatchesAlgorithm(jwk, t.Method.Alg()) {
+			return nil, errKeyAlgorithmMismatch
+		}
+		return jwk.Key, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrInvalidToken, err)
+	}
+
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", common.ErrInvalidToken)
+	}
+
+	return &domain.Principal{
+		Subject: claims.Subject,
+		Tenant:  claims.Tenant,
+		Scopes:  claims.scopes(),
+		Roles:   claims.Roles,
+	}, nil
+}
+
+// scopes merges the space-delimited OAuth2 "scope" claim with the array
+// form ("scp") some identity providers emit.
+func (c *tokenClaims) scopes() []string {
+	scopes := strings.Fields(c.Scope)
+	for _, s := range c.Scp {
+		if s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+func keyMatchesAlgorithm(jwk *JSONWebKey, alg string) bool {
+	if jwk.Algorithm != "" && jwk.Algorithm != alg {
+		return false
+	}
+
+	switch jwk.Key.(type) {
+	case *rsa.PublicKey:
+		return alg == "RS256"
+	case *ecdsa.PublicKey:
+		return alg == "ES256"
+	case []byte:
+		return alg == "HS256"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,188 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/auth"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIssuer   = "https://gateway.example.com"
+	testAudience = "account-management-api"
+)
+
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":    testIssuer,
+		"aud":    testAudience,
+		"sub":    "client-42",
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"tenant": "acme",
+		"scope":  "accounts:read transactions:write",
+		"roles":  []string{"customer"},
+	}
+}
+
+func TestJWTVerifier(t *testing.T) {
+	ctx := context.Background()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	hmacSecret := []byte("a-very-secret-shared-key")
+
+	doc := jwksDocument(t,
+		rsaJWK("rsa-1", &rsaKey.PublicKey),
+		ecJWK("ec-1", &ecKey.PublicKey),
+		octJWK("hs-1", hmacSecret),
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(doc)
+	}))
+	defer srv.Close()
+
+	verifier := auth.NewJWTVerifier(
+		auth.NewJWKSURLProvider(srv.URL, srv.Client(), time.Hour),
+		testIssuer,
+		testAudience,
+		0,
+	)
+
+	t.Run("should accept RS256 token and map claims", func(t *testing.T) {
+		token := signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, validClaims())
+
+		p, err := verifier.Verify(ctx, token)
+
+		require.NoError(t, err)
+		assert.Equal(t, "client-42", p.Subject)
+		assert.Equal(t, "acme", p.Tenant)
+		assert.Equal(t, []string{"accounts:read", "transactions:write"}, p.Scopes)
+		assert.Equal(t, []string{"customer"}, p.Roles)
+	})
+
+	t.Run("should accept ES256 token", func(t *testing.T) {
+		token := signToken(t, jwt.SigningMethodES256, "ec-1", ecKey, validClaims())
+
+		p, err := verifier.Verify(ctx, token)
+
+		require.NoError(t, err)
+		assert.Equal(t, "client-42", p.Subject)
+	})
+
+	t.Run("should accept HS256 token and scp array claim", func(t *testing.T) {
+		claims := validClaims()
+		delete(claims, "scope")
+		claims["scp"] = []string{"accounts:write"}
+		token := signToken(t, jwt.SigningMethodHS256, "hs-1", hmacSecret, claims)
+
+		p, err := verifier.Verify(ctx, token)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"accounts:write"}, p.Scopes)
+	})
+
+	t.Run("should reject expired token", func(t *testing.T) {
+		claims := validClaims()
+		claims["exp"] = time.Now().Add(-time.Minute).Unix()
+		token := signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+
+		_, err := verifier.Verify(ctx, token)
+
+		assert.ErrorIs(t, err, common.ErrInvalidToken)
+	})
+
+	t.Run("should reject token without exp", func(t *testing.T) {
+		claims := validClaims()
+		delete(claims, "exp")
+		token := signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+
+		_, err := verifier.Verify(ctx, token)
+
+		assert.ErrorIs(t, err, common.ErrInvalidToken)
+	})
+
+	t.Run("should reject wrong issuer", func(t *testing.T) {
+		claims := validClaims()
+		claims["iss"] = "https://evil.example.com"
+		token := signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+
+		_, err := verifier.Verify(ctx, token)
+
+		assert.ErrorIs(t, err, common.ErrInvalidToken)
+	})
+
+	t.Run("should reject wrong audience", func(t *testing.T) {
+		claims := validClaims()
+		claims["aud"] = "another-service"
+		token := signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+
+		_, err := verifier.Verify(ctx, token)
+
+		assert.ErrorIs(t, err, common.ErrInvalidToken)
+	})
+
+	t.Run("should reject token without subject", func(t *testing.T) {
+		claims := validClaims()
+		delete(claims, "sub")
+		token := signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+
+		_, err := verifier.Verify(ctx, token)
+
+		assert.ErrorIs(t, err, common.ErrInvalidToken)
+	})
+
+	t.Run("should reject token signed by unknown key", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		token := signToken(t, jwt.SigningMethodRS256, "rsa-1", otherKey, validClaims())
+
+		_, err = verifier.Verify(ctx, token)
+
+		assert.ErrorIs(t, err, common.ErrInvalidToken)
+	})
+
+	t.Run("should reject algorithm that does not match the key", func(t *testing.T) {
+		token := signToken(t, jwt.SigningMethodHS256, "rsa-1", hmacSecret, validClaims())
+
+		_, err := verifier.Verify(ctx, token)
+
+		assert.ErrorIs(t, err, common.ErrInvalidToken)
+	})
+
+	t.Run("should reject unsupported algorithm", func(t *testing.T) {
+		token := signToken(t, jwt.SigningMethodHS512, "hs-1", hmacSecret, validClaims())
+
+		_, err := verifier.Verify(ctx, token)
+
+		assert.ErrorIs(t, err, common.ErrInvalidToken)
+	})
+
+	t.Run("should reject malformed token", func(t *testing.T) {
+		_, err := verifier.Verify(ctx, "not-a-jwt")
+		assert.ErrorIs(t, err, common.ErrInvalidToken)
+	})
+}
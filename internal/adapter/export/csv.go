@@ -0,0 +1,51 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+)
+
+// The original_* and fx_rate columns are empty for transactions made in the
+// account's currency.
+var csvColumns = []string{
+	"transaction_id", "account_id", "event_date", "operation_type_id", "operation_type", "amount", "currency",
+	"original_amount", "original_currency", "fx_rate",
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	cw := &csvWriter{w: csv.NewWriter(w)}
+	if err := cw.w.Write(csvColumns); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (cw *csvWriter) Write(tx *domain.Transaction) error {
+	record := []string{
+		strconv.FormatInt(tx.ID, 10),
+		strconv.FormatInt(tx.AccountID, 10),
+		tx.EventDate.UTC().Format(time.RFC3339),
+		strconv.Itoa(int(tx.OperationTypeID)),
+		operationName(tx.OperationTypeID),
+		formatAmount(tx.Amount),
+		string(tx.Currency),
+		"", "", "",
+	}
+	if c := tx.Conversion; c != nil {
+		record[7], record[8], record[9] = formatAmount(c.Amount), string(c.Currency), formatRate(c.Rate)
+	}
+	return cw.w.Write(record)
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
@@ -0,0 +1,123 @@
+// Package export renders account transactions as downloadable files. Writers
+// emit each transaction as it is written so exports never hold the whole
+// history in memory.
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+type Format string
+
+const (
+	CSV       Format = "csv"
+	OFX       Format = "ofx"
+	JSONLines Format = "jsonl"
+)
+
+var formats = []Format{CSV, OFX, JSONLines}
+
+var contentTypes = map[Format]string{
+	CSV:       "text/csv; charset=utf-8",
+	OFX:       "application/x-ofx",
+	JSONLines: "application/x-ndjson",
+}
+
+// Formats lists the supported formats, comma separated.
+func Formats() string {
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ", ")
+}
+
+// ParseFormat resolves a format name; an empty name selects CSV.
+func ParseFormat(name string) (Format, error) {
+	if name == "" {
+		return CSV, nil
+	}
+	f := Format(strings.ToLower(name))
+	if _, ok := contentTypes[f]; !ok {
+		return "", fmt.Errorf("%w: %q", common.ErrInvalidExportFormat, name)
+	}
+	return f, nil
+}
+
+func (f Format) ContentType() string {
+	return contentTypes[f]
+}
+
+// Header describes the export; From or To may be zero for an open period.
+// Currency is the account's, which every amount is booked in.
+type Header struct {
+	AccountID   int64
+	Currency    domain.Currency
+	From        time.Time
+	To          time.Time
+	GeneratedAt time.Time
+}
+
+// Filename names the download after the account and the period's first and
+// last day, e.g. account-1-transactions-20260301-20260331.csv.
+func (h Header) Filename(f Format) string {
+	from, to := "start", "now"
+	if !h.From.IsZero() {
+		from = h.From.UTC().Format("20060102")
+	}
+	if !h.To.IsZero() {
+		to = h.To.Add(-time.Nanosecond).UTC().Format("20060102")
+	}
+	return fmt.Sprintf("account-%d-transactions-%s-%s.%s", h.AccountID, from, to, f)
+}
+
+type Writer interface {
+	Write(tx *domain.Transaction) error
+	// Close writes any trailer and flushes buffered output.
+	Close() error
+}
+
+// NewWriter starts an export in format f, writing its preamble to w.
+func NewWriter(f Format, w io.Writer, h Header) (Writer, error) {
+	switch f {
+	case CSV:
+		return newCSVWriter(w)
+	case OFX:
+		return newOFXWriter(w, h)
+	case JSONLines:
+		return newJSONLinesWriter(w), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", common.ErrInvalidExportFormat, f)
+	}
+}
+
+var operationNames = map[domain.OperationType]string{
+	domain.Purchase:            "purchase",
+	domain.InstallmentPurchase: "installment_purchase",
+	domain.Withdrawal:          "withdrawal",
+	domain.Payment:             "payment",
+}
+
+func operationName(op domain.OperationType) string {
+	if name, ok := operationNames[op]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// formatAmount renders amounts with exactly two decimals, as stored.
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+// formatRate renders rates with as many decimals as they need.
+func formatRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', -1, 64)
+}
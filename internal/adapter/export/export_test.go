@@ -0,0 +1,94 @@
+package export_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/export"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	from = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to   = time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func transactions() []*domain.Transaction {
+	return []*domain.Transaction{
+		{ID: 10, AccountID: 1, OperationTypeID: domain.Purchase, Amount: -50.5, EventDate: from.Add(13 * time.Hour), Currency: "BRL"},
+		{ID: 11, AccountID: 1, OperationTypeID: domain.Withdrawal, Amount: -20, EventDate: from.AddDate(0, 0, 1), Currency: "BRL"},
+		{
+			ID: 12, AccountID: 1, OperationTypeID: domain.Payment, Amount: 100.1, EventDate: from.AddDate(0, 0, 2), Currency: "BRL",
+			Conversion: &domain.Conversion{Currency: "USD", Amount: 20, Rate: 5.005, RateAt: from},
+		},
+	}
+}
+
+func render(t *testing.T, f export.Format) string {
+	var out bytes.Buffer
+	w, err := export.NewWriter(f, &out, export.Header{AccountID: 1, Currency: "BRL", From: from, To: to, GeneratedAt: to})
+	require.NoError(t, err)
+	for _, tx := range transactions() {
+		require.NoError(t, w.Write(tx))
+	}
+	require.NoError(t, w.Close())
+	return out.String()
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Run("Success - Defaults to CSV", func(t *testing.T) {
+		f, err := export.ParseFormat("")
+		assert.NoError(t, err)
+		assert.Equal(t, export.CSV, f)
+	})
+
+	t.Run("Success - Case insensitive", func(t *testing.T) {
+		f, err := export.ParseFormat("OFX")
+		assert.NoError(t, err)
+		assert.Equal(t, export.OFX, f)
+	})
+
+	t.Run("Error - Unsupported format", func(t *testing.T) {
+		_, err := export.ParseFormat("xlsx")
+		assert.ErrorIs(t, err, common.ErrInvalidExportFormat)
+	})
+}
+
+func TestHeaderFilename(t *testing.T) {
+	h := export.Header{AccountID: 1, From: from, To: to}
+	assert.Equal(t, "account-1-transactions-20260301-20260331.ofx", h.Filename(export.OFX))
+	assert.Equal(t, "account-1-transactions-start-now.jsonl", export.Header{AccountID: 1}.Filename(export.JSONLines))
+}
+
+func TestCSVWriter(t *testing.T) {
+	assert.Equal(t, "transaction_id,account_id,event_date,operation_type_id,operation_type,amount,currency,original_amount,original_currency,fx_rate\n"+
+		"10,1,2026-03-01T13:00:00Z,1,purchase,-50.50,BRL,,,\n"+
+		"11,1,2026-03-02T00:00:00Z,3,withdrawal,-20.00,BRL,,,\n"+
+		"12,1,2026-03-03T00:00:00Z,4,payment,100.10,BRL,20.00,USD,5.005\n", render(t, export.CSV))
+}
+
+func TestJSONLinesWriter(t *testing.T) {
+	out := render(t, export.JSONLines)
+
+	assert.Equal(t, 3, bytes.Count([]byte(out), []byte("\n")))
+	assert.Contains(t, out, `{"transaction_id":10,"account_id":1,"event_date":"2026-03-01T13:00:00Z","operation_type_id":1,"operation_type":"purchase","amount":-50.50,"currency":"BRL"}`+"\n")
+	assert.Contains(t, out, `"amount":100.10,"currency":"BRL","original_amount":20.00,"original_currency":"USD","fx_rate":5.005}`)
+}
+
+func TestOFXWriter(t *testing.T) {
+	out := render(t, export.OFX)
+
+	assert.Contains(t, out, `<?OFX OFXHEADER="200" VERSION="220"`)
+	assert.Contains(t, out, "<CURDEF>BRL</CURDEF><CCACCTFROM><ACCTID>1</ACCTID></CCACCTFROM>")
+	assert.Contains(t, out, "<DTSTART>20260301000000.000[0:GMT]</DTSTART><DTEND>20260401000000.000[0:GMT]</DTEND>")
+	assert.Contains(t, out, "<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20260301130000.000[0:GMT]</DTPOSTED><TRNAMT>-50.50</TRNAMT><FITID>10</FITID>")
+	assert.Contains(t, out, "<TRNTYPE>ATM</TRNTYPE>")
+	assert.Contains(t, out, "<TRNTYPE>CREDIT</TRNTYPE>")
+	assert.Contains(t, out, "<NAME>payment</NAME><ORIGCURRENCY><CURRATE>5.005</CURRATE><CURSYM>USD</CURSYM></ORIGCURRENCY></STMTTRN>")
+	assert.Contains(t, out, "<LEDGERBAL><BALAMT>29.60</BALAMT>")
+	assert.True(t, bytes.HasSuffix([]byte(out), []byte("</OFX>\n")))
+}
@@ -0,0 +1,55 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+)
+
+type jsonLine struct {
+	ID               int64       `json:"transaction_id"`
+	AccountID        int64       `json:"account_id"`
+	EventDate        time.Time   `json:"event_date"`
+	OperationTypeID  int16       `json:"operation_type_id"`
+	OperationType    string      `json:"operation_type"`
+	Amount           json.Number `json:"amount"`
+	Currency         string      `json:"currency"`
+	OriginalAmount   json.Number `json:"original_amount,omitempty"`
+	OriginalCurrency string      `json:"original_currency,omitempty"`
+	FXRate           json.Number `json:"fx_rate,omitempty"`
+}
+
+type jsonLinesWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func newJSONLinesWriter(w io.Writer) *jsonLinesWriter {
+	buf := bufio.NewWriter(w)
+	return &jsonLinesWriter{buf: buf, enc: json.NewEncoder(buf)}
+}
+
+func (jw *jsonLinesWriter) Write(tx *domain.Transaction) error {
+	line := jsonLine{
+		ID:              tx.ID,
+		AccountID:       tx.AccountID,
+		EventDate:       tx.EventDate.UTC(),
+		OperationTypeID: int16(tx.OperationTypeID),
+		OperationType:   operationName(tx.OperationTypeID),
+		Amount:          json.Number(formatAmount(tx.Amount)),
+		Currency:        string(tx.Currency),
+	}
+	if c := tx.Conversion; c != nil {
+		line.OriginalAmount = json.Number(formatAmount(c.Amount))
+		line.OriginalCurrency = string(c.Currency)
+		line.FXRate = json.Number(formatRate(c.Rate))
+	}
+	return jw.enc.Encode(line)
+}
+
+func (jw *jsonLinesWriter) Close() error {
+	return jw.buf.Flush()
+}
@@ -0,0 +1,102 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+)
+
+// ofxTime is the OFX datetime format, always written in GMT.
+const ofxTime = "20060102150405.000[0:GMT]"
+
+const ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS><DTSERVER>%s</DTSERVER><LANGUAGE>POR</LANGUAGE></SONRS></SIGNONMSGSRSV1>
+<CREDITCARDMSGSRSV1><CCSTMTTRNRS><TRNUID>0</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<CCSTMTRS><CURDEF>%s</CURDEF><CCACCTFROM><ACCTID>%d</ACCTID></CCACCTFROM>
+<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>
+`
+
+const ofxTrailer = `</BANKTRANLIST>
+<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>
+</CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+// ofxWriter writes an OFX 2.2 credit card statement. Personal-finance
+// software deduplicates on FITID, so re-importing an overlapping period is
+// safe.
+type ofxWriter struct {
+	buf     *bufio.Writer
+	end     time.Time
+	balance float64
+}
+
+func newOFXWriter(w io.Writer, h Header) (*ofxWriter, error) {
+	// DTSTART is mandatory; an open period starts at the epoch.
+	start := h.From
+	if start.IsZero() {
+		start = time.Unix(0, 0)
+	}
+	end := h.To
+	if end.IsZero() {
+		end = h.GeneratedAt
+	}
+
+	ow := &ofxWriter{buf: bufio.NewWriter(w), end: end}
+	_, err := fmt.Fprintf(ow.buf, ofxHeader, ofxDate(h.GeneratedAt), h.Currency, h.AccountID, ofxDate(start), ofxDate(end))
+	if err != nil {
+		return nil, err
+	}
+	return ow, nil
+}
+
+// Write reports converted transactions with ORIGCURRENCY, whose CURRATE
+// turns the original amount into TRNAMT.
+func (ow *ofxWriter) Write(tx *domain.Transaction) error {
+	ow.balance += tx.Amount
+	var orig string
+	if c := tx.Conversion; c != nil {
+		orig = fmt.Sprintf("<ORIGCURRENCY><CURRATE>%s</CURRATE><CURSYM>%s</CURSYM></ORIGCURRENCY>", formatRate(c.Rate), c.Currency)
+	}
+	_, err := fmt.Fprintf(ow.buf,
+		"<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%s</FITID><NAME>%s</NAME>%s</STMTTRN>\n",
+		ofxType(tx.OperationTypeID),
+		ofxDate(tx.EventDate),
+		formatAmount(tx.Amount),
+		strconv.FormatInt(tx.ID, 10),
+		operationName(tx.OperationTypeID),
+		orig,
+	)
+	return err
+}
+
+// Close writes the ledger balance as the net of the exported transactions.
+func (ow *ofxWriter) Close() error {
+	balance := math.Round(ow.balance*100) / 100
+	if _, err := fmt.Fprintf(ow.buf, ofxTrailer, formatAmount(balance), ofxDate(ow.end)); err != nil {
+		return err
+	}
+	return ow.buf.Flush()
+}
+
+func ofxType(op domain.OperationType) string {
+	switch {
+	case op == domain.Withdrawal:
+		return "ATM"
+	case op.IsCredit():
+		return "CREDIT"
+	default:
+		return "DEBIT"
+	}
+}
+
+func ofxDate(t time.Time) string {
+	return t.UTC().Format(ofxTime)
+}
@@ -0,0 +1,103 @@
+// Package fx quotes exchange rates from a fixed table, so conversions work
+// offline. The table is loaded from a JSON file such as
+//
+//	{"base": "BRL", "as_of": "2026-10-01T00:00:00Z", "rates": {"USD": 0.1845, "EUR": 0.1702}}
+//
+// where each rate is how many units of that currency one unit of base buys.
+package fx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+// ratePrecision matches the decimals stored with each conversion.
+const ratePrecision = 1e10
+
+type StaticRates struct {
+	asOf  time.Time
+	rates map[domain.Currency]float64
+}
+
+// NewStaticRates quotes rates against base, which is always supported.
+func NewStaticRates(base domain.Currency, asOf time.Time, rates map[domain.Currency]float64) *StaticRates {
+	table := make(map[domain.Currency]float64, len(rates)+1)
+	for currency, rate := range rates {
+		table[currency] = rate
+	}
+	table[base] = 1
+	return &StaticRates{asOf: asOf, rates: table}
+}
+
+type rateFile struct {
+	Base  string             `json:"base"`
+	AsOf  time.Time          `json:"as_of"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// LoadFile reads a rate table, rejecting unknown fields, malformed codes,
+// rates that are not positive and tables without an as_of date.
+func LoadFile(path string) (*StaticRates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read fx rates: %w", err)
+	}
+
+	var file rateFile
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("parse fx rates %s: %w", path, err)
+	}
+
+	base, err := domain.ParseCurrency(file.Base)
+	if err != nil {
+		return nil, fmt.Errorf("fx rates %s: base %q: %w", path, file.Base, err)
+	}
+	if file.AsOf.IsZero() {
+		return nil, fmt.Errorf("fx rates %s: as_of is required", path)
+	}
+	rates := make(map[domain.Currency]float64, len(file.Rates))
+	for code, rate := range file.Rates {
+		currency, err := domain.ParseCurrency(code)
+		if err != nil {
+			return nil, fmt.Errorf("fx rates %s: %q: %w", path, code, err)
+		}
+		if !(rate > 0) || math.IsInf(rate, 0) {
+			return nil, fmt.Errorf("fx rates %s: rate for %s must be positive", path, currency)
+		}
+		rates[currency] = rate
+	}
+	return NewStaticRates(base, file.AsOf, rates), nil
+}
+
+func (s *StaticRates) Supports(currency domain.Currency) bool {
+	_, ok := s.rates[currency]
+	return ok
+}
+
+// Rate crosses both currencies through the base.
+func (s *StaticRates) Rate(_ context.Context, from, to domain.Currency) (domain.FXRate, error) {
+	fromRate, ok := s.rates[from]
+	if !ok {
+		return domain.FXRate{}, fmt.Errorf("%w: %s", common.ErrUnsupportedCurrency, from)
+	}
+	toRate, ok := s.rates[to]
+	if !ok {
+		return domain.FXRate{}, fmt.Errorf("%w: %s", common.ErrUnsupportedCurrency, to)
+	}
+	return domain.FXRate{
+		From: from,
+		To:   to,
+		Rate: math.Round(toRate/fromRate*ratePrecision) / ratePrecision,
+		AsOf: s.asOf,
+	}, nil
+}
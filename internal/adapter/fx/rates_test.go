@@ -0,0 +1,103 @@
+package fx_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/fx"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRates(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rates.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestStaticRates(t *testing.T) {
+	ctx := context.Background()
+	asOf := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	rates := fx.NewStaticRates("BRL", asOf, map[domain.Currency]float64{"USD": 0.2, "EUR": 0.16})
+
+	t.Run("Rate - From Base", func(t *testing.T) {
+		rate, err := rates.Rate(ctx, "BRL", "USD")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.FXRate{From: "BRL", To: "USD", Rate: 0.2, AsOf: asOf}, rate)
+	})
+
+	t.Run("Rate - Into Base", func(t *testing.T) {
+		rate, err := rates.Rate(ctx, "USD", "BRL")
+
+		require.NoError(t, err)
+		assert.Equal(t, 5.0, rate.Rate)
+		assert.Equal(t, -54.2, rate.Convert(-10.84))
+	})
+
+	t.Run("Rate - Crosses Through Base", func(t *testing.T) {
+		rate, err := rates.Rate(ctx, "EUR", "USD")
+
+		require.NoError(t, err)
+		assert.Equal(t, 1.25, rate.Rate)
+	})
+
+	t.Run("Rate - Unsupported Currency", func(t *testing.T) {
+		_, err := rates.Rate(ctx, "JPY", "BRL")
+
+		assert.ErrorIs(t, err, common.ErrUnsupportedCurrency)
+		assert.False(t, rates.Supports("JPY"))
+		assert.True(t, rates.Supports("BRL"))
+	})
+}
+
+func TestLoadFile(t *testing.T) {
+	t.Run("LoadFile - Success", func(t *testing.T) {
+		path := writeRates(t, `{"base": "brl", "as_of": "2026-10-01T00:00:00Z", "rates": {"usd": 0.2}}`)
+
+		rates, err := fx.LoadFile(path)
+
+		require.NoError(t, err)
+		assert.True(t, rates.Supports("BRL"))
+		assert.True(t, rates.Supports("USD"))
+		rate, err := rates.Rate(context.Background(), "USD", "BRL")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), rate.AsOf)
+	})
+
+	t.Run("LoadFile - Missing File", func(t *testing.T) {
+		_, err := fx.LoadFile(filepath.Join(t.TempDir(), "missing.json"))
+
+		assert.ErrorContains(t, err, "read fx rates")
+	})
+
+	t.Run("LoadFile - Invalid Code", func(t *testing.T) {
+		_, err := fx.LoadFile(writeRates(t, `{"base": "BRL", "as_of": "2026-10-01T00:00:00Z", "rates": {"US": 0.2}}`))
+
+		assert.ErrorIs(t, err, common.ErrInvalidCurrency)
+	})
+
+	t.Run("LoadFile - Non Positive Rate", func(t *testing.T) {
+		_, err := fx.LoadFile(writeRates(t, `{"base": "BRL", "as_of": "2026-10-01T00:00:00Z", "rates": {"USD": 0}}`))
+
+		assert.ErrorContains(t, err, "must be positive")
+	})
+
+	t.Run("LoadFile - Missing As Of", func(t *testing.T) {
+		_, err := fx.LoadFile(writeRates(t, `{"base": "BRL", "rates": {"USD": 0.2}}`))
+
+		assert.ErrorContains(t, err, "as_of is required")
+	})
+
+	t.Run("LoadFile - Unknown Field", func(t *testing.T) {
+		_, err := fx.LoadFile(writeRates(t, `{"base": "BRL", "rate": {"USD": 0.2}}`))
+
+		assert.Error(t, err)
+	})
+}
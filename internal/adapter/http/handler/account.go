@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/evythrossell/account-management-api/internal/adapter/http/i18n"
 	"github.com/evythrossell/account-management-api/internal/core/domain"
 	"github.com/evythrossell/account-management-api/internal/core/port"
 	"github.com/gin-gonic/gin"
@@ -23,42 +24,71 @@ func NewAccountHandler(service port.AccountService) *AccountHandler {
 
 type CreateAccountRequest struct {
 	DocumentNumber string `json:"document_number" binding:"required" example:"12345678901"`
+	Currency       string `json:"currency,omitempty" example:"BRL"`
+}
+
+type CreateAccountsRequest struct {
+	DocumentNumbers []string `json:"document_numbers" binding:"required" example:"12345678901,12345678000199"`
+	Currency        string   `json:"currency,omitempty" example:"BRL"`
 }
 
 type BadRequestError struct {
-	Code    string `json:"code" example:"VALIDATION_ERROR"`
-	Message string `json:"message" example:"document_number is required"`
+	Code      string       `json:"code" example:"VALIDATION_ERROR"`
+	Message   string       `json:"message" example:"document_number is required"`
+	RequestID string       `json:"request_id,omitempty" example:"4f9c1b7e-2d0a-4c39-9a8e-1f2b3c4d5e6f"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+type FieldError struct {
+	Field  string `json:"field" example:"document_number"`
+	Reason string `json:"reason" example:"is required"`
 }
 
 type NotFoundError struct {
-	Code    string `json:"code" example:"NOT_FOUND"`
-	Message string `json:"message" example:"account not found"`
+	Code      string `json:"code" example:"NOT_FOUND"`
+	Message   string `json:"message" example:"account not found"`
+	RequestID string `json:"request_id,omitempty" example:"4f9c1b7e-2d0a-4c39-9a8e-1f2b3c4d5e6f"`
+}
+
+type PayloadTooLargeError struct {
+	Code      string `json:"code" example:"PAYLOAD_TOO_LARGE"`
+	Message   string `json:"message" example:"request body too large"`
+	RequestID string `json:"request_id,omitempty" example:"4f9c1b7e-2d0a-4c39-9a8e-1f2b3c4d5e6f"`
+}
+
+type TimeoutError struct {
+	Code      string `json:"code" example:"TIMEOUT"`
+	Message   string `json:"message" example:"the request took too long to complete"`
+	RequestID string `json:"request_id,omitempty" example:"4f9c1b7e-2d0a-4c39-9a8e-1f2b3c4d5e6f"`
 }
 
 type InternalServerError struct {
-	Code    string `json:"code" example:"INTERNAL_ERROR"`
-	Message string `json:"message" example:"unexpected error on internal service"`
+	Code      string `json:"code" example:"INTERNAL_ERROR"`
+	Message   string `json:"message" example:"unexpected error on internal service"`
+	RequestID string `json:"request_id,omitempty" example:"4f9c1b7e-2d0a-4c39-9a8e-1f2b3c4d5e6f"`
 }
 
 // CreateAccount godoc
 // @Summary      Criar nova conta
-// @Description  Cria uma nova conta bancária com CPF ou CNPJ
+// @Description  Cria uma nova conta bancária com CPF ou CNPJ. A moeda da conta é fixa; sem "currency", vale a moeda padrão do serviço
 // @Tags         Accounts
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        body body CreateAccountRequest true "Dados da conta"
 // @Success      201 {object} domain.Account "Conta criada com sucesso"
 // @Failure      400 {object} BadRequestError "Erro de validação"
+// @Failure      413 {object} PayloadTooLargeError "Corpo da requisição muito grande"
 // @Failure      500 {object} InternalServerError "Erro interno do servidor"
+// @Failure      504 {object} TimeoutError "Tempo limite da requisição excedido"
 // @Router       /v1/accounts [post]
 func (h *AccountHandler) CreateAccount(c *gin.Context) {
 	var req CreateAccountRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"code": domain.ErrCodeInvalidBody, "message": domain.ErrMsgInvalidBodyRequest})
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	account, err := h.service.CreateAccount(c.Request.Context(), req.DocumentNumber)
+	account, err := h.service.CreateAccount(c.Request.Context(), req.DocumentNumber, domain.Currency(req.Currency))
 	if err != nil {
 		c.Error(err)
 		return
@@ -67,23 +97,60 @@ func (h *AccountHandler) CreateAccount(c *gin.Context) {
 	c.JSON(http.StatusCreated, account)
 }
 
+// CreateAccounts godoc
+// @Summary      Criar contas em lote
+// @Description  Cria uma conta para cada documento novo e informa o resultado de cada item: criada, duplicada (com o ID da conta existente, quando visível) ou inválida. Duplicados e documentos inválidos não impedem a criação dos demais. Todas as contas do lote usam a mesma moeda
+// @Tags         Accounts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body body CreateAccountsRequest true "Documentos das contas"
+// @Success      207 {object} domain.AccountBatchReport "Resultado por item"
+// @Failure      400 {object} BadRequestError "Erro de validação"
+// @Failure      413 {object} PayloadTooLargeError "Corpo da requisição muito grande"
+// @Failure      500 {object} InternalServerError "Erro interno do servidor"
+// @Failure      504 {object} TimeoutError "Tempo limite da requisição excedido"
+// @Router       /v1/accounts/batch [post]
+func (h *AccountHandler) CreateAccounts(c *gin.Context) {
+	var req CreateAccountsRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	report, err := h.service.CreateAccounts(c.Request.Context(), req.DocumentNumbers, domain.Currency(req.Currency))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	locale := i18n.Negotiate(c.GetHeader("Accept-Language"))
+	for i, result := range report.Results {
+		if result.Reason != "" {
+			report.Results[i].Reason = i18n.Reason(locale, result.Reason, nil)
+		}
+	}
+	c.JSON(http.StatusMultiStatus, report)
+}
+
 // GetAccount godoc
 // @Summary      Obter conta por ID
 // @Description  Retorna os detalhes de uma conta específica
 // @Tags         Accounts
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        accountId path int64 true "ID da conta"
 // @Success      200 {object} domain.Account "Conta encontrada"
 // @Failure      400 {object} BadRequestError "ID inválido"
 // @Failure      404 {object} NotFoundError "Conta não encontrada"
 // @Failure      500 {object} InternalServerError "Erro interno do servidor"
+// @Failure      504 {object} TimeoutError "Tempo limite da requisição excedido"
 // @Router       /v1/accounts/{accountId} [get]
 func (h *AccountHandler) GetAccount(c *gin.Context) {
 	idParam := c.Param("accountId")
 	id, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
-		c.Error(common.NewValidationError(domain.ErrMsgAccountIDInvalid, err))
+		c.Error(common.NewValidationError(domain.ErrMsgAccountIDInvalid, err).WithField("accountId", domain.ErrMsgAccountIDInvalid))
 		return
 	}
 
@@ -12,6 +12,7 @@ import (
 	"github.com/evythrossell/account-management-api/internal/adapter/http/handler"
 	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
 	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -37,14 +38,22 @@ func (m *MockAccountService) GetAccountByDocument(ctx context.Context, documentN
 	return args.Get(0).(*domain.Account), args.Error(1)
 }
 
-func (m *MockAccountService) CreateAccount(ctx context.Context, doc string) (*domain.Account, error) {
-	args := m.Called(ctx, doc)
+func (m *MockAccountService) CreateAccount(ctx context.Context, doc string, currency domain.Currency) (*domain.Account, error) {
+	args := m.Called(ctx, doc, currency)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Account), args.Error(1)
 }
 
+func (m *MockAccountService) CreateAccounts(ctx context.Context, docs []string, currency domain.Currency) (*domain.AccountBatchReport, error) {
+	args := m.Called(ctx, docs, currency)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AccountBatchReport), args.Error(1)
+}
+
 func (m *MockAccountService) GetAccountByID(ctx context.Context, id int64) (*domain.Account, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -63,7 +72,7 @@ func TestAccountHandler(t *testing.T) {
 		r.POST("/accounts", h.CreateAccount)
 
 		acc := &domain.Account{ID: 1, DocumentNumber: "123"}
-		svc.On("CreateAccount", mock.Anything, "123").Return(acc, nil)
+		svc.On("CreateAccount", mock.Anything, "123", domain.Currency("")).Return(acc, nil)
 
 		body, _ := json.Marshal(handler.CreateAccountRequest{DocumentNumber: "123"})
 		req, _ := http.NewRequest("POST", "/accounts", bytes.NewBuffer(body))
@@ -77,6 +86,7 @@ func TestAccountHandler(t *testing.T) {
 	t.Run("CreateAccount - Invalid JSON", func(t *testing.T) {
 		h := handler.NewAccountHandler(nil)
 		r := gin.Default()
+		r.Use(middleware.Error())
 		r.POST("/accounts", h.CreateAccount)
 
 		req, _ := http.NewRequest("POST", "/accounts", bytes.NewBufferString("{invalid}"))
@@ -84,6 +94,25 @@ func TestAccountHandler(t *testing.T) {
 		r.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), common.CodeInvalidBody)
+	})
+
+	t.Run("CreateAccount - Missing Field", func(t *testing.T) {
+		h := handler.NewAccountHandler(nil)
+		r := gin.New()
+		r.Use(middleware.Error())
+		r.POST("/accounts", h.CreateAccount)
+
+		req, _ := http.NewRequest("POST", "/accounts", bytes.NewBufferString(`{}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{
+			"code": "INVALID_BODY",
+			"message": "invalid request body or missing required fields",
+			"errors": [{"field": "document_number", "reason": "is required"}]
+		}`, w.Body.String())
 	})
 
 	t.Run("CreateAccount - Service Error", func(t *testing.T) {
@@ -93,7 +122,7 @@ func TestAccountHandler(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
-		svc.On("CreateAccount", mock.Anything, "123").Return(nil, errors.New("db error"))
+		svc.On("CreateAccount", mock.Anything, "123", domain.Currency("")).Return(nil, errors.New("db error"))
 
 		body, _ := json.Marshal(handler.CreateAccountRequest{DocumentNumber: "123"})
 		c.Request, _ = http.NewRequest("POST", "/accounts", bytes.NewBuffer(body))
@@ -105,6 +134,49 @@ func TestAccountHandler(t *testing.T) {
 		svc.AssertExpectations(t)
 	})
 
+	t.Run("CreateAccounts - Per Item Results", func(t *testing.T) {
+		svc := new(MockAccountService)
+		h := handler.NewAccountHandler(svc)
+		r := gin.New()
+		r.Use(middleware.Error())
+		r.POST("/accounts/batch", h.CreateAccounts)
+
+		report := &domain.AccountBatchReport{Total: 2, Created: 1, Invalid: 1, Results: []domain.AccountBatchResult{
+			{Index: 0, DocumentNumber: "12345678901", Status: domain.AccountBatchCreated, AccountID: 1},
+			{Index: 1, DocumentNumber: "123", Status: domain.AccountBatchInvalid, Reason: domain.ErrMsgDocumentInvalid},
+		}}
+		svc.On("CreateAccounts", mock.Anything, []string{"12345678901", "123"}, domain.Currency("USD")).Return(report, nil)
+
+		req, _ := http.NewRequest("POST", "/accounts/batch", bytes.NewBufferString(`{"document_numbers": ["12345678901", "123"], "currency": "USD"}`))
+		req.Header.Set("Accept-Language", "pt-BR")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusMultiStatus, w.Code)
+		assert.JSONEq(t, `{
+			"total": 2, "created": 1, "duplicates": 0, "invalid": 1,
+			"results": [
+				{"index": 0, "document_number": "12345678901", "status": "created", "account_id": 1},
+				{"index": 1, "document_number": "123", "status": "invalid", "reason": "o documento deve ter entre 11 e 14 dígitos"}
+			]
+		}`, w.Body.String())
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("CreateAccounts - Missing Field", func(t *testing.T) {
+		h := handler.NewAccountHandler(nil)
+		r := gin.New()
+		r.Use(middleware.Error())
+		r.POST("/accounts/batch", h.CreateAccounts)
+
+		req, _ := http.NewRequest("POST", "/accounts/batch", bytes.NewBufferString(`{}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `{"field":"document_numbers","reason":"is required"}`)
+	})
+
 	t.Run("GetAccount - Success", func(t *testing.T) {
 		svc := new(MockAccountService)
 		h := handler.NewAccountHandler(svc)
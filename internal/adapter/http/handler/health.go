@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 
+	"github.com/evythrossell/account-management-api/internal/core/domain"
 	"github.com/evythrossell/account-management-api/internal/core/port"
 	"github.com/gin-gonic/gin"
 )
@@ -11,39 +12,38 @@ type HealthHandler struct {
 	service port.HealthService
 }
 
-type HealthResponse struct {
-	Status string `json:"status" example:"up"`
-	Detail string `json:"detail,omitempty" example:"database connection failed"`
-}
-
-type ServiceUnavailableError struct {
-	Status string `json:"status" example:"unavailable"`
-	Detail string `json:"detail" example:"database connection failed"`
-}
-
 func NewHealthHandler(service port.HealthService) *HealthHandler {
 	return &HealthHandler{
 		service: service,
 	}
 }
 
-// Check godoc
-// @Summary      Verificar saúde da API
-// @Description  Retorna o status de saúde da API e suas dependências
+// Live godoc
+// @Summary      Verificar se o processo está vivo
+// @Description  Retorna 200 enquanto o processo estiver em execução, sem consultar dependências
+// @Tags         Health
+// @Produce      json
+// @Success      200 {object} domain.HealthReport "Processo em execução"
+// @Router       /health/live [get]
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, h.service.Live(c.Request.Context()))
+}
+
+// Ready godoc
+// @Summary      Verificar se a API está pronta
+// @Description  Executa as verificações registradas (banco de dados, migrações) e retorna o status e a latência de cada componente
 // @Tags         Health
-// @Accept       json
 // @Produce      json
-// @Success      200 {object} HealthResponse "API está operacional"
-// @Failure      503 {object} ServiceUnavailableError "API indisponível"
+// @Success      200 {object} domain.HealthReport "API pronta para receber tráfego"
+// @Failure      503 {object} domain.HealthReport "Alguma dependência indisponível ou API encerrando"
+// @Router       /health/ready [get]
 // @Router       /health [get]
-func (h *HealthHandler) Check(c *gin.Context) {
-	if err := h.service.Check(c.Request.Context()); err != nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{
-			"status": "unavailable",
-			"detail": err.Error(),
-		})
-		return
-	}
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.service.Ready(c.Request.Context())
 
-	c.JSON(http.StatusOK, gin.H{"status": "up"})
+	status := http.StatusOK
+	if report.Status != domain.HealthStatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
 }
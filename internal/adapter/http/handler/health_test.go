@@ -2,12 +2,14 @@ package handler_test
 
 import (
 	"context"
-	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/evythrossell/account-management-api/internal/adapter/http/handler"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -17,45 +19,85 @@ type MockHealthService struct {
 	mock.Mock
 }
 
-func (m *MockHealthService) Check(ctx context.Context) error {
+func (m *MockHealthService) Register(name string, timeout time.Duration, check port.HealthCheckFunc) {
+	m.Called(name, timeout, check)
+}
+
+func (m *MockHealthService) Live(ctx context.Context) domain.HealthReport {
+	args := m.Called(ctx)
+	return args.Get(0).(domain.HealthReport)
+}
+
+func (m *MockHealthService) Ready(ctx context.Context) domain.HealthReport {
 	args := m.Called(ctx)
-	return args.Error(0)
+	return args.Get(0).(domain.HealthReport)
 }
 
-func TestHealthHandler_Check(t *testing.T) {
+func (m *MockHealthService) SetNotReady() {
+	m.Called()
+}
+
+func TestHealthHandler(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	t.Run("should return 200 when service is healthy", func(t *testing.T) {
+	t.Run("Live - should return 200", func(t *testing.T) {
+		svc := new(MockHealthService)
+		h := handler.NewHealthHandler(svc)
+
+		svc.On("Live", mock.Anything).Return(domain.HealthReport{Status: domain.HealthStatusUp})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/health/live", nil)
+
+		h.Live(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"up"`)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("Ready - should return 200 with component report", func(t *testing.T) {
 		svc := new(MockHealthService)
 		h := handler.NewHealthHandler(svc)
 
-		svc.On("Check", mock.Anything).Return(nil)
+		svc.On("Ready", mock.Anything).Return(domain.HealthReport{
+			Status: domain.HealthStatusUp,
+			Components: []domain.ComponentHealth{
+				{Name: "database", Status: domain.HealthStatusUp, LatencyMs: 1.5},
+			},
+		})
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request = httptest.NewRequest("GET", "/health", nil)
+		c.Request = httptest.NewRequest("GET", "/health/ready", nil)
 
-		h.Check(c)
+		h.Ready(c)
 
 		assert.Equal(t, http.StatusOK, w.Code)
-		assert.Contains(t, w.Body.String(), "up")
+		assert.Contains(t, w.Body.String(), `{"name":"database","status":"up","latency_ms":1.5}`)
 		svc.AssertExpectations(t)
 	})
 
-	t.Run("should return 503 when service fails", func(t *testing.T) {
+	t.Run("Ready - should return 503 when a component is down", func(t *testing.T) {
 		svc := new(MockHealthService)
 		h := handler.NewHealthHandler(svc)
 
-		svc.On("Check", mock.Anything).Return(errors.New("db connection failed"))
+		svc.On("Ready", mock.Anything).Return(domain.HealthReport{
+			Status: domain.HealthStatusDown,
+			Components: []domain.ComponentHealth{
+				{Name: "database", Status: domain.HealthStatusDown, Error: "unavailable"},
+			},
+		})
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request = httptest.NewRequest("GET", "/health", nil)
+		c.Request = httptest.NewRequest("GET", "/health/ready", nil)
 
-		h.Check(c)
+		h.Ready(c)
 
 		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
-		assert.Contains(t, w.Body.String(), "unavailable")
+		assert.Contains(t, w.Body.String(), `"status":"down"`)
 		svc.AssertExpectations(t)
 	})
 }
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/http/i18n"
+	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
+	"github.com/evythrossell/account-management-api/internal/adapter/importer"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	"github.com/gin-gonic/gin"
+
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+type ImportHandler struct {
+	service port.ImportService
+}
+
+func NewImportHandler(service port.ImportService) *ImportHandler {
+	return &ImportHandler{
+		service: service,
+	}
+}
+
+// SubmitImport godoc
+// @Summary      Importar transações em lote
+// @Description  Enfileira um arquivo CSV ou JSON Lines de transações para importação em segundo plano. O formato vem do parâmetro format ou do Content-Type (CSV por padrão). Acompanhe o resultado pela URL do cabeçalho Location
+// @Tags         Imports
+// @Accept       text/csv,application/x-ndjson
+// @Produce      json
+// @Security     BearerAuth
+// @Param        format query string false "Formato do arquivo" Enums(csv, jsonl)
+// @Param        mode query string false "Modo de importação" Enums(all_or_nothing, best_effort) default(all_or_nothing)
+// @Param        body body string true "Arquivo com as transações"
+// @Success      202 {object} domain.ImportJob "Importação enfileirada"
+// @Header       202 {string} Location "URL da importação"
+// @Failure      400 {object} BadRequestError "Arquivo, formato ou modo inválido"
+// @Failure      413 {object} PayloadTooLargeError "Corpo da requisição muito grande"
+// @Failure      500 {object} InternalServerError "Erro interno do servidor"
+// @Failure      504 {object} TimeoutError "Tempo limite da requisição excedido"
+// @Router       /v1/transactions/imports [post]
+func (h *ImportHandler) SubmitImport(c *gin.Context) {
+	format, err := importer.Detect(c.Query("format"), c.ContentType())
+	if err != nil {
+		c.Error(common.NewValidationError(domain.ErrMsgImportFormatInvalid, err).
+			WithParam("formats", importer.Formats()).
+			WithField("format", domain.ErrMsgImportFormatInvalid))
+		return
+	}
+	mode, err := domain.ParseImportMode(c.Query("mode"))
+	if err != nil {
+		c.Error(common.NewValidationError(domain.ErrMsgImportModeInvalid, err).
+			WithParam("modes", domain.ImportModes()).
+			WithField("mode", domain.ErrMsgImportModeInvalid))
+		return
+	}
+
+	rows, err := importer.Decode(format, c.Request.Body)
+	switch {
+	case middleware.IsBodyTooLarge(err):
+		c.Error(common.NewPayloadTooLargeError(domain.ErrMsgBodyTooLarge, err))
+		return
+	case errors.Is(err, common.ErrInvalidImportFile):
+		c.Error(common.NewInvalidBodyError(domain.ErrMsgImportFileInvalid, err))
+		return
+	case err != nil:
+		c.Error(common.NewInvalidBodyError(domain.ErrMsgInvalidBodyRequest, err))
+		return
+	}
+
+	job, err := h.service.Submit(c.Request.Context(), mode, rows)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Location", "/v1/transactions/imports/"+strconv.FormatInt(job.ID, 10))
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetImport godoc
+// @Summary      Obter importação
+// @Description  Retorna a situação de uma importação e, quando terminada, o relatório por linha. Os motivos são traduzidos conforme o Accept-Language
+// @Tags         Imports
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        jobId path int64 true "ID da importação"
+// @Success      200 {object} domain.ImportJob "Importação encontrada"
+// @Failure      400 {object} BadRequestError "ID inválido"
+// @Failure      404 {object} NotFoundError "Importação não encontrada"
+// @Failure      500 {object} InternalServerError "Erro interno do servidor"
+// @Failure      504 {object} TimeoutError "Tempo limite da requisição excedido"
+// @Router       /v1/transactions/imports/{jobId} [get]
+func (h *ImportHandler) GetImport(c *gin.Context) {
+	jobID, ok := pathID(c, "jobId", domain.ErrMsgImportJobIDInvalid)
+	if !ok {
+		return
+	}
+
+	job, err := h.service.GetJob(c.Request.Context(), jobID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, localizeJob(i18n.Negotiate(c.GetHeader("Accept-Language")), job))
+}
+
+// localizeJob returns a copy of job with its message keys rendered in
+// locale.
+func localizeJob(locale string, job *domain.ImportJob) *domain.ImportJob {
+	out := *job
+	if out.Error != "" {
+		out.Error = i18n.Reason(locale, out.Error, nil)
+	}
+	if job.Report != nil {
+		report := *job.Report
+		report.Lines = make([]domain.ImportLineResult, len(job.Report.Lines))
+		for i, line := range job.Report.Lines {
+			if line.Reason != "" {
+				line.Reason = i18n.Reason(locale, line.Reason, nil)
+			}
+			report.Lines[i] = line
+		}
+		out.Report = &report
+	}
+	return &out
+}
@@ -0,0 +1,160 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/http/handler"
+	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImportService struct {
+	mock.Mock
+}
+
+func (m *MockImportService) Import(ctx context.Context, mode domain.ImportMode, rows []domain.ImportRow) (*domain.ImportReport, error) {
+	args := m.Called(ctx, mode, rows)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportReport), args.Error(1)
+}
+
+func (m *MockImportService) Submit(ctx context.Context, mode domain.ImportMode, rows []domain.ImportRow) (*domain.ImportJob, error) {
+	args := m.Called(ctx, mode, rows)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportJob), args.Error(1)
+}
+
+func (m *MockImportService) GetJob(ctx context.Context, jobID int64) (*domain.ImportJob, error) {
+	args := m.Called(ctx, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportJob), args.Error(1)
+}
+
+func (m *MockImportService) RunPending(ctx context.Context) ([]*domain.ImportJob, error) {
+	args := m.Called(ctx)
+	jobs, _ := args.Get(0).([]*domain.ImportJob)
+	return jobs, args.Error(1)
+}
+
+func importRouter(svc *MockImportService, opts ...gin.HandlerFunc) *gin.Engine {
+	h := handler.NewImportHandler(svc)
+	r := gin.New()
+	r.Use(middleware.Error())
+	r.Use(opts...)
+	r.POST("/transactions/imports", h.SubmitImport)
+	r.GET("/transactions/imports/:jobId", h.GetImport)
+	return r
+}
+
+func TestImportHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("SubmitImport - Accepted", func(t *testing.T) {
+		svc := new(MockImportService)
+		svc.On("Submit", mock.Anything, domain.ImportBestEffort, []domain.ImportRow{
+			{Line: 1, AccountID: 1, OperationTypeID: 1, Amount: 10},
+		}).Return(&domain.ImportJob{ID: 7, Status: domain.ImportPending, Mode: domain.ImportBestEffort, Lines: 1}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/transactions/imports?mode=best_effort",
+			strings.NewReader(`{"account_id": 1, "operation_type_id": 1, "amount": 10}`))
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		importRouter(svc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Equal(t, "/v1/transactions/imports/7", w.Header().Get("Location"))
+		assert.Contains(t, w.Body.String(), `"job_id":7`)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("SubmitImport - Invalid Mode", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/transactions/imports?mode=some", strings.NewReader("account_id,operation_type_id,amount\n"))
+		importRouter(new(MockImportService)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `{"field":"mode","reason":"mode must be one of all_or_nothing, best_effort"}`)
+	})
+
+	t.Run("SubmitImport - Invalid Format", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/transactions/imports?format=xlsx", strings.NewReader(""))
+		importRouter(new(MockImportService)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `{"field":"format","reason":"format must be one of csv, jsonl"}`)
+	})
+
+	t.Run("SubmitImport - Missing Column", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/transactions/imports", strings.NewReader("account_id,amount\n1,10\n"))
+		importRouter(new(MockImportService)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), string(common.CodeInvalidBody))
+		assert.Contains(t, w.Body.String(), "account_id, operation_type_id and amount")
+	})
+
+	t.Run("SubmitImport - Body Too Large", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := "account_id,operation_type_id,amount\n" + strings.Repeat("1,1,10\n", 100)
+		req := httptest.NewRequest("POST", "/transactions/imports", strings.NewReader(body))
+		req.ContentLength = -1
+		importRouter(new(MockImportService), middleware.BodyLimit(64)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+
+	t.Run("GetImport - Localized Report", func(t *testing.T) {
+		svc := new(MockImportService)
+		svc.On("GetJob", mock.Anything, int64(7)).Return(&domain.ImportJob{
+			ID:     7,
+			Status: domain.ImportSucceeded,
+			Report: &domain.ImportReport{Total: 1, Rejected: 1, Lines: []domain.ImportLineResult{
+				{Line: 2, Status: domain.ImportLineRejected, Reason: domain.ErrMsgImportLineMalformed},
+			}},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/transactions/imports/7", nil)
+		req.Header.Set("Accept-Language", "pt-BR")
+		importRouter(svc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"reason":"a linha está malformada ou tem um campo do tipo errado"`)
+	})
+
+	t.Run("GetImport - Invalid ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		importRouter(new(MockImportService)).ServeHTTP(w, httptest.NewRequest("GET", "/transactions/imports/x", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), string(common.CodeInvalidID))
+	})
+
+	t.Run("GetImport - Not Found", func(t *testing.T) {
+		svc := new(MockImportService)
+		svc.On("GetJob", mock.Anything, int64(9)).Return(nil,
+			common.NewNotFoundError(domain.ErrMsgImportJobNotFound, common.ErrImportJobNotFound))
+
+		w := httptest.NewRecorder()
+		importRouter(svc).ServeHTTP(w, httptest.NewRequest("GET", "/transactions/imports/9", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "import job not found")
+	})
+}
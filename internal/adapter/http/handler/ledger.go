@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	"github.com/gin-gonic/gin"
+)
+
+type LedgerHandler struct {
+	service port.LedgerService
+}
+
+func NewLedgerHandler(service port.LedgerService) *LedgerHandler {
+	return &LedgerHandler{
+		service: service,
+	}
+}
+
+// GetJournalEntry godoc
+// @Summary      Obter lançamento contábil da transação
+// @Description  Retorna o lançamento de partidas dobradas que registrou a transação, com seus débitos e créditos
+// @Tags         Transactions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        transactionId path int64 true "ID da transação"
+// @Success      200 {object} domain.JournalEntry "Lançamento encontrado"
+// @Failure      400 {object} BadRequestError "ID inválido"
+// @Failure      404 {object} NotFoundError "Transação ou lançamento não encontrado"
+// @Failure      500 {object} InternalServerError "Erro interno do servidor"
+// @Failure      504 {object} TimeoutError "Tempo limite da requisição excedido"
+// @Router       /v1/transactions/{transactionId}/journal [get]
+func (h *LedgerHandler) GetJournalEntry(c *gin.Context) {
+	transactionID, ok := pathID(c, "transactionId", domain.ErrMsgTransactionIDInvalid)
+	if !ok {
+		return
+	}
+
+	entry, err := h.service.GetJournalEntry(c.Request.Context(), transactionID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
@@ -0,0 +1,83 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/http/handler"
+	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLedgerService struct {
+	mock.Mock
+}
+
+func (m *MockLedgerService) GetJournalEntry(ctx context.Context, transactionID int64) (*domain.JournalEntry, error) {
+	args := m.Called(ctx, transactionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.JournalEntry), args.Error(1)
+}
+
+func (m *MockLedgerService) TrialBalance(ctx context.Context) (*domain.TrialBalance, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TrialBalance), args.Error(1)
+}
+
+func ledgerRouter(svc *MockLedgerService) *gin.Engine {
+	h := handler.NewLedgerHandler(svc)
+	r := gin.New()
+	r.Use(middleware.Error())
+	r.GET("/transactions/:transactionId/journal", h.GetJournalEntry)
+	return r
+}
+
+func TestLedgerHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("GetJournalEntry - Success", func(t *testing.T) {
+		rule, _ := domain.Withdrawal.PostingRule()
+		svc := new(MockLedgerService)
+		svc.On("GetJournalEntry", mock.Anything, int64(40)).
+			Return(&domain.JournalEntry{TransactionID: 40, Postings: rule.Post(1, 20)}, nil)
+
+		w := httptest.NewRecorder()
+		ledgerRouter(svc).ServeHTTP(w, httptest.NewRequest("GET", "/transactions/40/journal", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"transaction_id":40`)
+		assert.Contains(t, w.Body.String(), `{"ledger_account":"customer","account_id":1,"direction":"debit","amount":20}`)
+		assert.Contains(t, w.Body.String(), `{"ledger_account":"cash","direction":"credit","amount":20}`)
+	})
+
+	t.Run("GetJournalEntry - Invalid Transaction ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ledgerRouter(new(MockLedgerService)).ServeHTTP(w, httptest.NewRequest("GET", "/transactions/abc/journal", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), string(common.CodeInvalidID))
+	})
+
+	t.Run("GetJournalEntry - Not Found", func(t *testing.T) {
+		svc := new(MockLedgerService)
+		svc.On("GetJournalEntry", mock.Anything, int64(40)).
+			Return(nil, common.NewNotFoundError(domain.ErrMsgJournalEntryNotFound, common.ErrJournalEntryNotFound))
+
+		w := httptest.NewRecorder()
+		ledgerRouter(svc).ServeHTTP(w, httptest.NewRequest("GET", "/transactions/40/journal", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "journal entry not found")
+	})
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// Report validation failures with JSON field names rather than Go ones.
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(f reflect.StructField) string {
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			return name
+		})
+	}
+}
+
+// bindJSON decodes the request body into req. On failure it records an
+// error naming the offending fields and aborts the request.
+func bindJSON(c *gin.Context, req any) bool {
+	err := c.ShouldBindJSON(req)
+	if err == nil {
+		return true
+	}
+
+	if middleware.IsBodyTooLarge(err) {
+		c.Error(common.NewPayloadTooLargeError(domain.ErrMsgBodyTooLarge, err))
+		c.Abort()
+		return false
+	}
+
+	invalid := common.NewInvalidBodyError(domain.ErrMsgInvalidBodyRequest, err)
+	invalid.Fields = fieldErrors(err)
+	c.Error(invalid)
+	c.Abort()
+	return false
+}
+
+func fieldErrors(err error) []common.FieldError {
+	var (
+		validationErrs validator.ValidationErrors
+		typeErr        *json.UnmarshalTypeError
+		syntaxErr      *json.SyntaxError
+	)
+	switch {
+	case errors.As(err, &validationErrs):
+		fields := make([]common.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			// Drop the request struct name from the namespace.
+			_, path, _ := strings.Cut(fe.Namespace(), ".")
+			reason, params := validationReason(fe)
+			fields = append(fields, common.FieldError{Field: path, Reason: reason, Params: params})
+		}
+		return fields
+	case errors.As(err, &typeErr):
+		return []common.FieldError{{Field: typeErr.Field, Reason: jsonType(typeErr.Type)}}
+	case errors.As(err, &syntaxErr):
+		return []common.FieldError{{Field: "", Reason: domain.ReasonSyntax}}
+	default:
+		return nil
+	}
+}
+
+func validationReason(fe validator.FieldError) (string, map[string]any) {
+	switch fe.Tag() {
+	case "required":
+		return domain.ReasonRequired, nil
+	case "min", "gte":
+		return domain.ReasonMin, map[string]any{"param": fe.Param()}
+	case "max", "lte":
+		return domain.ReasonMax, map[string]any{"param": fe.Param()}
+	case "gt":
+		return domain.ReasonGreaterThan, map[string]any{"param": fe.Param()}
+	case "lt":
+		return domain.ReasonLessThan, map[string]any{"param": fe.Param()}
+	case "oneof":
+		return domain.ReasonOneOf, map[string]any{"param": fe.Param()}
+	default:
+		return domain.ReasonRule, map[string]any{"rule": fe.Tag()}
+	}
+}
+
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return domain.ReasonInteger
+	case reflect.Float32, reflect.Float64:
+		return domain.ReasonNumber
+	case reflect.Bool:
+		return domain.ReasonBoolean
+	case reflect.String:
+		return domain.ReasonString
+	case reflect.Slice, reflect.Array:
+		return domain.ReasonArray
+	default:
+		return domain.ReasonObject
+	}
+}
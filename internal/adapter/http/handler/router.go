@@ -1,39 +1,216 @@
 package handler
 
 import (
+	"time"
+
 	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
+	"github.com/evythrossell/account-management-api/internal/adapter/metrics"
+	"github.com/evythrossell/account-management-api/internal/adapter/tracing"
+	common "github.com/evythrossell/account-management-api/pkg"
 	"github.com/gin-gonic/gin"
-	ginSwagger "github.com/swaggo/gin-swagger"
 	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+const (
+	AccountsGroup     = "accounts"
+	TransactionsGroup = "transactions"
+)
+
+type RouterOption func(*routerConfig)
+
+type routerConfig struct {
+	logger         common.Logger
+	metrics        *metrics.Metrics
+	tracing        bool
+	accessLog      middleware.AccessLogConfig
+	requestTimeout time.Duration
+	exportTimeout  time.Duration
+	authentication []gin.HandlerFunc
+	limits         []gin.HandlerFunc
+	groups         map[string][]gin.HandlerFunc
+	statements     *StatementHandler
+	imports        *ImportHandler
+	ledger         *LedgerHandler
+}
+
+// WithLogger sets the logger bound to each request context. Defaults to a
+// no-op logger.
+func WithLogger(l common.Logger) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.logger = l
+	}
+}
+
+// WithAccessLog replaces the default access log settings, which skip the
+// health, metrics and documentation routes and log every other request.
+func WithAccessLog(accessLog middleware.AccessLogConfig) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.accessLog = accessLog
+	}
+}
+
+// WithMetrics records HTTP metrics for every route and exposes them on
+// GET /metrics.
+func WithMetrics(m *metrics.Metrics) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.metrics = m
+	}
+}
+
+// WithTracing starts a server span per request and adds its trace ID to the
+// request logger.
+func WithTracing() RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.tracing = true
+	}
+}
+
+// WithBodyLimit caps /v1 request bodies at max bytes; larger bodies are
+// rejected with 413.
+func WithBodyLimit(max int64) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.limits = append(cfg.limits, middleware.BodyLimit(max))
+	}
+}
+
+// WithRequestTimeout sets a deadline on every /v1 request except transaction
+// exports. Requests that run past it are answered with 504.
+func WithRequestTimeout(d time.Duration) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.requestTimeout = d
+	}
+}
+
+// WithExportTimeout sets the deadline of transaction exports, which stream
+// for as long as the account's history takes and so are not bound by the
+// request timeout.
+func WithExportTimeout(d time.Duration) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.exportTimeout = d
+	}
+}
+
+// WithAuthentication protects every /v1 route with the given middleware.
+// Health and documentation routes stay public.
+func WithAuthentication(mw gin.HandlerFunc) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.authentication = append(cfg.authentication, mw)
+	}
+}
+
+// WithGroupMiddleware adds middleware to a single /v1 route group, such as a
+// rate limiter tuned for that group. It runs after authentication.
+func WithGroupMiddleware(group string, mw ...gin.HandlerFunc) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.groups[group] = append(cfg.groups[group], mw...)
+	}
+}
+
+// WithStatements serves account statements and billing cycles under
+// /v1/accounts/:accountId.
+func WithStatements(h *StatementHandler) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.statements = h
+	}
+}
+
+// WithImports serves batch transaction imports under
+// /v1/transactions/imports.
+func WithImports(h *ImportHandler) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.imports = h
+	}
+}
+
+// WithLedger serves the journal entry behind each transaction under
+// /v1/transactions/:transactionId/journal.
+func WithLedger(h *LedgerHandler) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.ledger = h
+	}
+}
+
 func SetupRouter(
 	accountHandler *AccountHandler,
 	healthHandler *HealthHandler,
 	transactionHandler *TransactionHandler,
+	opts ...RouterOption,
 ) *gin.Engine {
+	cfg := &routerConfig{
+		logger:    common.NewNoOpLogger(),
+		accessLog: middleware.DefaultAccessLogConfig(),
+		groups:    make(map[string][]gin.HandlerFunc),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
 
-	router := gin.Default()
+	router := gin.New()
+	router.Use(middleware.RequestID(cfg.logger))
+	if cfg.tracing {
+		router.Use(tracing.Middleware())
+	}
+	if cfg.metrics != nil {
+		router.Use(cfg.metrics.Middleware())
+	}
+	router.Use(middleware.AccessLog(cfg.accessLog))
 	router.Use(middleware.Error())
+	router.Use(middleware.Recovery())
+
+	if cfg.metrics != nil {
+		router.GET("/metrics", gin.WrapH(cfg.metrics.Handler()))
+	}
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	router.GET("/health", healthHandler.Check)
+	router.GET("/health", healthHandler.Ready)
+	router.GET("/health/live", healthHandler.Live)
+	router.GET("/health/ready", healthHandler.Ready)
 
-	v1 := router.Group("/v1")
+	v1 := router.Group("/v1", cfg.v1(cfg.requestTimeout)...)
 	{
-		accounts := v1.Group("/accounts")
+		exports := router.Group("/v1/accounts", append(cfg.v1(cfg.exportTimeout), cfg.groups[AccountsGroup]...)...)
+		exports.GET("/:accountId/transactions/export", transactionHandler.ExportTransactions)
+
+		accounts := v1.Group("/accounts", cfg.groups[AccountsGroup]...)
 		{
 			accounts.POST("", accountHandler.CreateAccount)
+			accounts.POST("/batch", accountHandler.CreateAccounts)
 			accounts.GET("/:accountId", accountHandler.GetAccount)
+			accounts.GET("/:accountId/balances", transactionHandler.GetBalances)
+			if h := cfg.statements; h != nil {
+				accounts.GET("/:accountId/statements", h.ListStatements)
+				accounts.GET("/:accountId/statements/:statementId", h.GetStatement)
+				accounts.GET("/:accountId/billing-cycle", h.GetBillingCycle)
+				accounts.PUT("/:accountId/billing-cycle", h.SetBillingCycle)
+			}
 		}
 
-		transactions := v1.Group("/transactions")
+		transactions := v1.Group("/transactions", cfg.groups[TransactionsGroup]...)
 		{
 			transactions.POST("", transactionHandler.CreateTransaction)
 			transactions.GET("/:transactionId", transactionHandler.GetTransaction)
+			if h := cfg.imports; h != nil {
+				transactions.POST("/imports", h.SubmitImport)
+				transactions.GET("/imports/:jobId", h.GetImport)
+			}
+			if h := cfg.ledger; h != nil {
+				transactions.GET("/:transactionId/journal", h.GetJournalEntry)
+			}
 		}
 	}
 
 	return router
 }
+
+// v1 returns the middleware every /v1 route runs before its group's, with
+// the given deadline when it is set.
+func (cfg *routerConfig) v1(timeout time.Duration) []gin.HandlerFunc {
+	var chain []gin.HandlerFunc
+	if timeout > 0 {
+		chain = append(chain, middleware.Timeout(timeout))
+	}
+	chain = append(chain, cfg.limits...)
+	return append(chain, cfg.authentication...)
+}
@@ -1,11 +1,22 @@
 package handler_test
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/evythrossell/account-management-api/internal/adapter/http/handler"
+	"github.com/evythrossell/account-management-api/internal/adapter/metrics"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSetupRouter(t *testing.T) {
@@ -23,8 +34,12 @@ func TestSetupRouter(t *testing.T) {
 		routes := r.Routes()
 		expectedRoutes := []string{
 			"/health",
+			"/health/live",
+			"/health/ready",
 			"/v1/accounts",
+			"/v1/accounts/batch",
 			"/v1/accounts/:accountId",
+			"/v1/accounts/:accountId/transactions/export",
 			"/v1/transactions",
 			"/v1/transactions/:transactionId",
 		}
@@ -40,4 +55,216 @@ func TestSetupRouter(t *testing.T) {
 			assert.True(t, found, "route %s not found", expected)
 		}
 	})
+
+	t.Run("should serve statements only when configured", func(t *testing.T) {
+		svc := new(MockStatementService)
+		svc.On("ListStatements", mock.Anything, int64(1)).Return([]*domain.Statement{}, nil)
+
+		r := handler.SetupRouter(handler.NewAccountHandler(nil), handler.NewHealthHandler(nil), handler.NewTransactionHandler(nil))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/accounts/1/statements", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		r = handler.SetupRouter(
+			handler.NewAccountHandler(nil),
+			handler.NewHealthHandler(nil),
+			handler.NewTransactionHandler(nil),
+			handler.WithStatements(handler.NewStatementHandler(svc)),
+		)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/accounts/1/statements", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("should serve imports only when configured", func(t *testing.T) {
+		svc := new(MockImportService)
+		svc.On("GetJob", mock.Anything, int64(7)).Return(&domain.ImportJob{ID: 7}, nil)
+
+		r := handler.SetupRouter(handler.NewAccountHandler(nil), handler.NewHealthHandler(nil), handler.NewTransactionHandler(nil))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/transactions/imports/7", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		r = handler.SetupRouter(
+			handler.NewAccountHandler(nil),
+			handler.NewHealthHandler(nil),
+			handler.NewTransactionHandler(nil),
+			handler.WithImports(handler.NewImportHandler(svc)),
+		)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/transactions/imports/7", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("should serve journal entries only when configured", func(t *testing.T) {
+		svc := new(MockLedgerService)
+		svc.On("GetJournalEntry", mock.Anything, int64(7)).Return(&domain.JournalEntry{TransactionID: 7}, nil)
+
+		r := handler.SetupRouter(handler.NewAccountHandler(nil), handler.NewHealthHandler(nil), handler.NewTransactionHandler(nil))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/transactions/7/journal", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		r = handler.SetupRouter(
+			handler.NewAccountHandler(nil),
+			handler.NewHealthHandler(nil),
+			handler.NewTransactionHandler(nil),
+			handler.WithLedger(handler.NewLedgerHandler(svc)),
+		)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/transactions/7/journal", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("should apply authentication only to v1 routes", func(t *testing.T) {
+		r := handler.SetupRouter(
+			handler.NewAccountHandler(nil),
+			handler.NewHealthHandler(nil),
+			handler.NewTransactionHandler(nil),
+			handler.WithAuthentication(func(c *gin.Context) {
+				c.AbortWithStatus(http.StatusUnauthorized)
+			}),
+		)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/accounts/1", nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/swagger/doc.json", nil))
+		assert.NotEqual(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("should apply group middleware only to its group", func(t *testing.T) {
+		svc := new(MockTransactionService)
+		svc.On("GetByTransactionID", mock.Anything, int64(1)).Return(&domain.Transaction{ID: 1}, nil)
+
+		r := handler.SetupRouter(
+			handler.NewAccountHandler(nil),
+			handler.NewHealthHandler(nil),
+			handler.NewTransactionHandler(svc),
+			handler.WithGroupMiddleware(handler.AccountsGroup, func(c *gin.Context) {
+				c.AbortWithStatus(http.StatusTooManyRequests)
+			}),
+		)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/accounts/1", nil))
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/transactions/1", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should recover from handler panics with JSON error", func(t *testing.T) {
+		r := handler.SetupRouter(
+			handler.NewAccountHandler(nil),
+			handler.NewHealthHandler(nil),
+			handler.NewTransactionHandler(nil),
+		)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/accounts/1", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "an unexpected error occurred")
+		assert.NotEmpty(t, w.Header().Get("X-Request-ID"))
+	})
+
+	t.Run("should expose metrics when configured", func(t *testing.T) {
+		r := handler.SetupRouter(
+			handler.NewAccountHandler(nil),
+			handler.NewHealthHandler(nil),
+			handler.NewTransactionHandler(nil),
+			handler.WithMetrics(metrics.New()),
+		)
+
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/swagger/doc.json", nil))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `http_requests_total{method="GET",route="/swagger/*any"`)
+	})
+
+	t.Run("should reject oversized bodies with 413", func(t *testing.T) {
+		r := handler.SetupRouter(
+			handler.NewAccountHandler(nil),
+			handler.NewHealthHandler(nil),
+			handler.NewTransactionHandler(nil),
+			handler.WithBodyLimit(16),
+		)
+		body := `{"document_number": "12345678900"}`
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("POST", "/v1/accounts", strings.NewReader(body)))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), common.CodePayloadTooLarge)
+
+		// Without Content-Length the limit is enforced while binding.
+		req := httptest.NewRequest("POST", "/v1/accounts", io.MultiReader(strings.NewReader(body)))
+		req.ContentLength = -1
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), "request body too large")
+		assert.NotEmpty(t, w.Header().Get("X-Request-ID"))
+	})
+
+	t.Run("should answer 504 when the request deadline expires", func(t *testing.T) {
+		svc := new(MockAccountService)
+		svc.On("GetAccountByID", mock.Anything, int64(1)).Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).Return(nil, common.NewInternalError(domain.ErrMsgDatabaseError, context.DeadlineExceeded))
+
+		r := handler.SetupRouter(
+			handler.NewAccountHandler(svc),
+			handler.NewHealthHandler(nil),
+			handler.NewTransactionHandler(nil),
+			handler.WithRequestTimeout(20*time.Millisecond),
+		)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/v1/accounts/1", nil))
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.Contains(t, w.Body.String(), common.CodeTimeout)
+	})
+
+	t.Run("should stream exports past the request and write timeouts", func(t *testing.T) {
+		svc := new(MockTransactionService)
+		rows := []*domain.Transaction{{ID: 1, AccountID: 1, OperationTypeID: domain.Payment, Amount: 10, Currency: "BRL"}}
+		svc.On("ExportTransactions", mock.Anything, int64(1), time.Time{}, time.Time{}).Run(func(args mock.Arguments) {
+			deadline, ok := args.Get(0).(context.Context).Deadline()
+			assert.True(t, ok)
+			assert.Greater(t, time.Until(deadline), time.Second)
+			time.Sleep(100 * time.Millisecond)
+		}).Return(rows, nil)
+
+		srv := httptest.NewUnstartedServer(handler.SetupRouter(
+			handler.NewAccountHandler(nil),
+			handler.NewHealthHandler(nil),
+			handler.NewTransactionHandler(svc),
+			handler.WithRequestTimeout(20*time.Millisecond),
+			handler.WithExportTimeout(time.Minute),
+		))
+		srv.Config.WriteTimeout = 50 * time.Millisecond
+		srv.Start()
+		defer srv.Close()
+
+		resp, err := http.Get(srv.URL + "/v1/accounts/1/transactions/export?format=jsonl")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, string(body), `"transaction_id":1`)
+		svc.AssertExpectations(t)
+	})
 }
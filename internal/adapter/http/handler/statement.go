@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	"github.com/gin-gonic/gin"
+
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+type setBillingCycleRequest struct {
+	BillingDay int `json:"billing_day" binding:"required" example:"10"`
+}
+
+type StatementHandler struct {
+	service port.StatementService
+}
+
+func NewStatementHandler(service port.StatementService) *StatementHandler {
+	return &StatementHandler{
+		service: service,
+	}
+}
+
+// ListStatements godoc
+// @Summary      Listar faturas da conta
+// @Description  Retorna as faturas fechadas da conta, da mais recente para a mais antiga
+// @Tags         Statements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        accountId path int64 true "ID da conta"
+// @Success      200 {array} domain.Statement "Faturas da conta"
+// @Failure      400 {object} BadRequestError "ID inválido"
+// @Failure      404 {object} NotFoundError "Conta não encontrada"
+// @Failure      500 {object} InternalServerError "Erro interno do servidor"
+// @Failure      504 {object} TimeoutError "Tempo limite da requisição excedido"
+// @Router       /v1/accounts/{accountId}/statements [get]
+func (h *StatementHandler) ListStatements(c *gin.Context) {
+	accountID, ok := pathID(c, "accountId", domain.ErrMsgAccountIDInvalid)
+	if !ok {
+		return
+	}
+
+	statements, err := h.service.ListStatements(c.Request.Context(), accountID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if statements == nil {
+		statements = []*domain.Statement{}
+	}
+
+	c.JSON(http.StatusOK, statements)
+}
+
+// GetStatement godoc
+// @Summary      Obter fatura
+// @Description  Retorna uma fatura com as transações incluídas e os pagamentos que a quitaram
+// @Tags         Statements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        accountId path int64 true "ID da conta"
+// @Param        statementId path int64 true "ID da fatura"
+// @Success      200 {object} domain.Statement "Fatura encontrada"
+// @Failure      400 {object} BadRequestError "ID inválido"
+// @Failure      404 {object} NotFoundError "Fatura não encontrada"
+// @Failure      500 {object} InternalServerError "Erro interno do servidor"
+// @Failure      504 {object} TimeoutError "Tempo limite da requisição excedido"
+// @Router       /v1/accounts/{accountId}/statements/{statementId} [get]
+func (h *StatementHandler) GetStatement(c *gin.Context) {
+	accountID, ok := pathID(c, "accountId", domain.ErrMsgAccountIDInvalid)
+	if !ok {
+		return
+	}
+	statementID, ok := pathID(c, "statementId", domain.ErrMsgStatementIDInvalid)
+	if !ok {
+		return
+	}
+
+	statement, err := h.service.GetStatement(c.Request.Context(), accountID, statementID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statement)
+}
+
+// GetBillingCycle godoc
+// @Summary      Obter ciclo de faturamento
+// @Description  Retorna o dia do mês em que as faturas da conta fecham
+// @Tags         Statements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        accountId path int64 true "ID da conta"
+// @Success      200 {object} domain.BillingCycle "Ciclo de faturamento"
+// @Failure      400 {object} BadRequestError "ID inválido"
+// @Failure      404 {object} NotFoundError "Conta não encontrada"
+// @Failure      500 {object} InternalServerError "Erro interno do servidor"
+// @Failure      504 {object} TimeoutError "Tempo limite da requisição excedido"
+// @Router       /v1/accounts/{accountId}/billing-cycle [get]
+func (h *StatementHandler) GetBillingCycle(c *gin.Context) {
+	accountID, ok := pathID(c, "accountId", domain.ErrMsgAccountIDInvalid)
+	if !ok {
+		return
+	}
+
+	cycle, err := h.service.GetBillingCycle(c.Request.Context(), accountID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cycle)
+}
+
+// SetBillingCycle godoc
+// @Summary      Alterar ciclo de faturamento
+// @Description  Define o dia do mês (1 a 28) em que as faturas da conta fecham. Vale a partir do próximo fechamento
+// @Tags         Statements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        accountId path int64 true "ID da conta"
+// @Param        body body setBillingCycleRequest true "Dia de fechamento"
+// @Success      200 {object} domain.BillingCycle "Ciclo de faturamento atualizado"
+// @Failure      400 {object} BadRequestError "Erro de validação"
+// @Failure      404 {object} NotFoundError "Conta não encontrada"
+// @Failure      413 {object} PayloadTooLargeError "Corpo da requisição muito grande"
+// @Failure      500 {object} InternalServerError "Erro interno do servidor"
+// @Failure      504 {object} TimeoutError "Tempo limite da requisição excedido"
+// @Router       /v1/accounts/{accountId}/billing-cycle [put]
+func (h *StatementHandler) SetBillingCycle(c *gin.Context) {
+	accountID, ok := pathID(c, "accountId", domain.ErrMsgAccountIDInvalid)
+	if !ok {
+		return
+	}
+
+	var req setBillingCycleRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	cycle, err := h.service.SetBillingCycle(c.Request.Context(), accountID, req.BillingDay)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cycle)
+}
+
+// pathID parses an integer path parameter, reporting INVALID_ID with key
+// when it is malformed.
+func pathID(c *gin.Context, param, key string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(param), 10, 64)
+	if err != nil {
+		c.Error(common.NewInvalidIDError(key, err).WithField(param, key))
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,174 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/http/handler"
+	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStatementService struct {
+	mock.Mock
+}
+
+func (m *MockStatementService) GetBillingCycle(ctx context.Context, accountID int64) (*domain.BillingCycle, error) {
+	args := m.Called(ctx, accountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BillingCycle), args.Error(1)
+}
+
+func (m *MockStatementService) SetBillingCycle(ctx context.Context, accountID int64, billingDay int) (*domain.BillingCycle, error) {
+	args := m.Called(ctx, accountID, billingDay)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BillingCycle), args.Error(1)
+}
+
+func (m *MockStatementService) ListStatements(ctx context.Context, accountID int64) ([]*domain.Statement, error) {
+	args := m.Called(ctx, accountID)
+	statements, _ := args.Get(0).([]*domain.Statement)
+	return statements, args.Error(1)
+}
+
+func (m *MockStatementService) GetStatement(ctx context.Context, accountID, statementID int64) (*domain.Statement, error) {
+	args := m.Called(ctx, accountID, statementID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Statement), args.Error(1)
+}
+
+func (m *MockStatementService) CloseDue(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStatementService) AllocatePayments(ctx context.Context, accountID int64) error {
+	return m.Called(ctx, accountID).Error(0)
+}
+
+func statementRouter(svc *MockStatementService) *gin.Engine {
+	h := handler.NewStatementHandler(svc)
+	r := gin.New()
+	r.Use(middleware.Error())
+	r.GET("/accounts/:accountId/statements", h.ListStatements)
+	r.GET("/accounts/:accountId/statements/:statementId", h.GetStatement)
+	r.GET("/accounts/:accountId/billing-cycle", h.GetBillingCycle)
+	r.PUT("/accounts/:accountId/billing-cycle", h.SetBillingCycle)
+	return r
+}
+
+func TestStatementHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("ListStatements - Empty", func(t *testing.T) {
+		svc := new(MockStatementService)
+		svc.On("ListStatements", mock.Anything, int64(1)).Return(nil, nil)
+
+		w := httptest.NewRecorder()
+		statementRouter(svc).ServeHTTP(w, httptest.NewRequest("GET", "/accounts/1/statements", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[]`, w.Body.String())
+	})
+
+	t.Run("GetStatement - Success", func(t *testing.T) {
+		svc := new(MockStatementService)
+		svc.On("GetStatement", mock.Anything, int64(1), int64(7)).Return(&domain.Statement{
+			ID:             7,
+			AccountID:      1,
+			ClosingBalance: -50,
+			Status:         domain.StatementOpen,
+			Transactions:   []domain.Transaction{{ID: 40, AccountID: 1, OperationTypeID: domain.Purchase, Amount: -50}},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		statementRouter(svc).ServeHTTP(w, httptest.NewRequest("GET", "/accounts/1/statements/7", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"statement_id":7`)
+		assert.Contains(t, w.Body.String(), `"status":"open"`)
+		assert.Contains(t, w.Body.String(), `"transactions":[{"transaction_id":40`)
+	})
+
+	t.Run("GetStatement - Invalid Statement ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		statementRouter(new(MockStatementService)).ServeHTTP(w, httptest.NewRequest("GET", "/accounts/1/statements/abc", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), string(common.CodeInvalidID))
+		assert.Contains(t, w.Body.String(), `{"field":"statementId","reason":"the statement ID must be a valid integer"}`)
+	})
+
+	t.Run("GetStatement - Not Found", func(t *testing.T) {
+		svc := new(MockStatementService)
+		svc.On("GetStatement", mock.Anything, int64(1), int64(7)).
+			Return(nil, common.NewNotFoundError(domain.ErrMsgStatementNotFound, common.ErrStatementNotFound))
+
+		w := httptest.NewRecorder()
+		statementRouter(svc).ServeHTTP(w, httptest.NewRequest("GET", "/accounts/1/statements/7", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "statement not found")
+	})
+
+	t.Run("GetBillingCycle - Invalid Account ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		statementRouter(new(MockStatementService)).ServeHTTP(w, httptest.NewRequest("GET", "/accounts/x/billing-cycle", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), string(common.CodeInvalidID))
+	})
+
+	t.Run("SetBillingCycle - Success", func(t *testing.T) {
+		svc := new(MockStatementService)
+		svc.On("SetBillingCycle", mock.Anything, int64(1), 15).Return(&domain.BillingCycle{AccountID: 1, BillingDay: 15}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("PUT", "/accounts/1/billing-cycle", bytes.NewBufferString(`{"billing_day": 15}`))
+		statementRouter(svc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"account_id":1,"billing_day":15}`, w.Body.String())
+	})
+
+	t.Run("SetBillingCycle - Missing Day", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("PUT", "/accounts/1/billing-cycle", bytes.NewBufferString(`{}`))
+		statementRouter(new(MockStatementService)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `{"field":"billing_day","reason":"is required"}`)
+	})
+
+	t.Run("SetBillingCycle - Day Out Of Range", func(t *testing.T) {
+		svc := new(MockStatementService)
+		svc.On("SetBillingCycle", mock.Anything, int64(1), 30).Return(nil,
+			common.NewValidationError(domain.ErrMsgBillingDayInvalid, common.ErrInvalidBillingDay).
+				WithParam("min", domain.MinBillingDay).
+				WithParam("max", domain.MaxBillingDay).
+				WithField("billing_day", domain.ErrMsgBillingDayInvalid))
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("PUT", "/accounts/1/billing-cycle", bytes.NewBufferString(`{"billing_day": 30}`))
+		req.Header.Set("Accept-Language", "pt-BR")
+		statementRouter(svc).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), string(common.CodeValidation))
+		assert.Contains(t, w.Body.String(), `{"field":"billing_day","reason":"o dia de fechamento da fatura deve estar entre 1 e 28"}`)
+	})
+}
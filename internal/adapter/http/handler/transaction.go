@@ -1,18 +1,24 @@
 package handler
 
 import (
+	"mime"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/evythrossell/account-management-api/internal/adapter/export"
 	"github.com/evythrossell/account-management-api/internal/core/domain"
 	"github.com/evythrossell/account-management-api/internal/core/port"
 	"github.com/gin-gonic/gin"
+
+	common "github.com/evythrossell/account-management-api/pkg"
 )
 
 type createTransactionRequest struct {
 	AccountID     int64   `json:"account_id" binding:"required" example:"123"`
 	OperationType int16   `json:"operation_type_id" binding:"required" example:"1"`
 	Amount        float64 `json:"amount" binding:"required" example:"100.50"`
+	Currency      string  `json:"currency,omitempty" example:"USD"`
 }
 
 // Tipos de erro específicos para cada status code
@@ -30,27 +36,26 @@ func NewTransactionHandler(service port.TransactionService) *TransactionHandler
 
 // CreateTransaction godoc
 // @Summary      Criar transação
-// @Description  Cria uma nova transação bancária (débito/crédito)
+// @Description  Cria uma nova transação bancária (débito/crédito). Um valor em outra moeda que não a da conta é convertido pela cotação vigente, que fica registrada na transação
 // @Tags         Transactions
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        body body createTransactionRequest true "Dados da transação"
 // @Success      201 {object} domain.Transaction "Transação criada com sucesso"
 // @Failure      400 {object} BadRequestError "Erro de validação"
 // @Failure      404 {object} NotFoundError "Conta não encontrada"
+// @Failure      413 {object} PayloadTooLargeError "Corpo da requisição muito grande"
 // @Failure      500 {object} InternalServerError "Erro interno do servidor"
+// @Failure      504 {object} TimeoutError "Tempo limite da requisição excedido"
 // @Router       /v1/transactions [post]
 func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 	var req createTransactionRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"code":    domain.ErrCodeInvalidBody,
-			"message": domain.ErrMsgInvalidBodyRequest,
-		})
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	tx, err := h.service.CreateTransaction(c.Request.Context(), req.AccountID, req.OperationType, req.Amount)
+	tx, err := h.service.CreateTransaction(c.Request.Context(), req.AccountID, req.OperationType, req.Amount, domain.Currency(req.Currency))
 	if err != nil {
 		c.Error(err)
 		return
@@ -65,19 +70,18 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 // @Tags         Transactions
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        transactionId path int64 true "ID da transação"
 // @Success      200 {object} domain.Transaction "Transação encontrada"
 // @Failure      400 {object} BadRequestError "ID inválido"
 // @Failure      404 {object} NotFoundError "Transação não encontrada"
 // @Failure      500 {object} InternalServerError "Erro interno do servidor"
+// @Failure      504 {object} TimeoutError "Tempo limite da requisição excedido"
 // @Router       /v1/transactions/{transactionId} [get]
 func (h *TransactionHandler) GetTransaction(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("transactionId"), 10, 64)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"code":    domain.ErrCodeInvalidID,
-			"message": domain.ErrMsgTransactionIDInvalid,
-		})
+		c.Error(common.NewInvalidIDError(domain.ErrMsgTransactionIDInvalid, err).WithField("transactionId", domain.ErrMsgTransactionIDInvalid))
 		return
 	}
 
@@ -89,3 +93,171 @@ func (h *TransactionHandler) GetTransaction(c *gin.Context) {
 
 	c.JSON(http.StatusOK, transaction)
 }
+
+// ExportTransactions godoc
+// @Summary      Exportar transações da conta
+// @Description  Baixa as transações da conta no período [from, to) em CSV, OFX 2.2 ou JSON Lines, em ordem cronológica. As datas aceitam AAAA-MM-DD ou RFC 3339; um "to" só com a data inclui o dia inteiro
+// @Tags         Transactions
+// @Produce      text/csv
+// @Produce      application/x-ofx
+// @Produce      application/x-ndjson
+// @Security     BearerAuth
+// @Param        accountId path int64 true "ID da conta"
+// @Param        format query string false "Formato do arquivo" Enums(csv, ofx, jsonl) default(csv)
+// @Param        from query string false "Início do período (inclusivo)" example(2026-03-01)
+// @Param        to query string false "Fim do período (exclusivo)" example(2026-03-31)
+// @Success      200 {file} file "Arquivo com as transações"
+// @Failure      400 {object} BadRequestError "Parâmetros inválidos"
+// @Failure      404 {object} NotFoundError "Conta não encontrada"
+// @Failure      500 {object} InternalServerError "Erro interno do servidor"
+// @Failure      504 {object} TimeoutError "Tempo limite da requisição excedido"
+// @Router       /v1/accounts/{accountId}/transactions/export [get]
+func (h *TransactionHandler) ExportTransactions(c *gin.Context) {
+	accountID, ok := pathID(c, "accountId", domain.ErrMsgAccountIDInvalid)
+	if !ok {
+		return
+	}
+
+	format, err := export.ParseFormat(c.Query("format"))
+	if err != nil {
+		c.Error(common.NewValidationError(domain.ErrMsgExportFormatInvalid, err).
+			WithParam("formats", export.Formats()).
+			WithField("format", domain.ErrMsgExportFormatInvalid))
+		return
+	}
+
+	from, to, ok := queryPeriod(c)
+	if !ok {
+		return
+	}
+
+	header := export.Header{AccountID: accountID, From: from, To: to, GeneratedAt: time.Now()}
+	out := &attachment{c: c, format: format, filename: header.Filename(format)}
+
+	// The writer needs the account's currency, so it starts once the
+	// account has been found.
+	var w export.Writer
+	start := func(account *domain.Account) error {
+		header.Currency = account.Currency
+		var err error
+		if w, err = export.NewWriter(format, out, header); err != nil {
+			return common.NewInternalError(domain.ErrMsgUnexpectedError, err)
+		}
+		return nil
+	}
+	write := func(tx *domain.Transaction) error { return w.Write(tx) }
+
+	// The server's write timeout is sized for regular responses; an export
+	// may keep writing until its own deadline. Writers that cannot move the
+	// deadline, such as test recorders, have no timeout to begin with.
+	ctx := c.Request.Context()
+	deadline, _ := ctx.Deadline()
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(deadline)
+
+	err = h.service.ExportTransactions(ctx, accountID, from, to, start, write)
+	if err != nil && !out.started {
+		c.Error(err)
+		return
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		// The status line is gone; all that is left is to drop the connection
+		// so the client sees a failed download rather than a short file that
+		// looks complete.
+		common.FromContext(ctx).Error("transaction export interrupted",
+			common.Int64("account_id", accountID),
+			common.Err(err),
+		)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// GetBalances godoc
+// @Summary      Obter saldos por moeda
+// @Description  Retorna o saldo da conta na sua moeda e, para cada moeda em que houve transações, o total no valor original e o total contabilizado na moeda da conta
+// @Tags         Accounts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        accountId path int64 true "ID da conta"
+// @Success      200 {object} domain.AccountBalances "Saldos da conta"
+// @Failure      400 {object} BadRequestError "ID inválido"
+// @Failure      404 {object} NotFoundError "Conta não encontrada"
+// @Failure      500 {object} InternalServerError "Erro interno do servidor"
+// @Failure      504 {object} TimeoutError "Tempo limite da requisição excedido"
+// @Router       /v1/accounts/{accountId}/balances [get]
+func (h *TransactionHandler) GetBalances(c *gin.Context) {
+	accountID, ok := pathID(c, "accountId", domain.ErrMsgAccountIDInvalid)
+	if !ok {
+		return
+	}
+
+	balances, err := h.service.GetBalances(c.Request.Context(), accountID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, balances)
+}
+
+// attachment sends the download headers with its first write, so errors
+// raised before any row is produced still get a regular error response.
+type attachment struct {
+	c        *gin.Context
+	format   export.Format
+	filename string
+	started  bool
+}
+
+func (a *attachment) Write(p []byte) (int, error) {
+	if !a.started {
+		a.started = true
+		a.c.Header("Content-Type", a.format.ContentType())
+		a.c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.filename}))
+		a.c.Status(http.StatusOK)
+	}
+	return a.c.Writer.Write(p)
+}
+
+// queryPeriod reads the from and to query parameters. Both accept RFC 3339
+// timestamps or plain dates; a plain to date includes that whole day.
+func queryPeriod(c *gin.Context) (from, to time.Time, ok bool) {
+	from, ok = queryTime(c, "from", false)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	to, ok = queryTime(c, "to", true)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		c.Error(common.NewValidationError(domain.ErrMsgPeriodRangeInvalid, common.ErrInvalidPeriod).
+			WithField("from", domain.ErrMsgPeriodRangeInvalid))
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
+
+func queryTime(c *gin.Context, param string, endOfDay bool) (time.Time, bool) {
+	value := c.Query(param)
+	if value == "" {
+		return time.Time{}, true
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		c.Error(common.NewValidationError(domain.ErrMsgPeriodDateInvalid, common.ErrInvalidPeriod).
+			WithField(param, domain.ErrMsgPeriodDateInvalid))
+		return time.Time{}, false
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, true
+}
@@ -5,23 +5,28 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/evythrossell/account-management-api/internal/adapter/http/handler"
+	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
 	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type MockTransactionService struct {
 	mock.Mock
 }
 
-func (m *MockTransactionService) CreateTransaction(ctx context.Context, accID int64, opType int16, amount float64) (*domain.Transaction, error) {
-	args := m.Called(ctx, accID, opType, amount)
+func (m *MockTransactionService) CreateTransaction(ctx context.Context, accID int64, opType int16, amount float64, currency domain.Currency) (*domain.Transaction, error) {
+	args := m.Called(ctx, accID, opType, amount, currency)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -36,6 +41,49 @@ func (m *MockTransactionService) GetByTransactionID(ctx context.Context, id int6
 	return args.Get(0).(*domain.Transaction), args.Error(1)
 }
 
+// ExportTransactions starts the export of a BRL account and feeds fn the
+// transactions given to Return, then fails with the returned error. An
+// error without transactions is returned before the export starts, as when
+// the account is not found.
+func (m *MockTransactionService) ExportTransactions(
+	ctx context.Context,
+	accountID int64,
+	from, to time.Time,
+	start func(*domain.Account) error,
+	fn func(*domain.Transaction) error,
+) error {
+	args := m.Called(ctx, accountID, from, to)
+	rows, _ := args.Get(0).([]*domain.Transaction)
+	if rows == nil && args.Error(1) != nil {
+		return args.Error(1)
+	}
+	if err := start(&domain.Account{ID: accountID, Currency: "BRL"}); err != nil {
+		return err
+	}
+	for _, tx := range rows {
+		if err := fn(tx); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockTransactionService) GetBalances(ctx context.Context, accountID int64) (*domain.AccountBalances, error) {
+	args := m.Called(ctx, accountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AccountBalances), args.Error(1)
+}
+
+func exportRouter(svc *MockTransactionService) *gin.Engine {
+	h := handler.NewTransactionHandler(svc)
+	r := gin.New()
+	r.Use(middleware.Error(), middleware.Recovery())
+	r.GET("/accounts/:accountId/transactions/export", h.ExportTransactions)
+	return r
+}
+
 func TestTransactionHandler(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -46,7 +94,7 @@ func TestTransactionHandler(t *testing.T) {
 		r.POST("/transactions", h.CreateTransaction)
 
 		tx := &domain.Transaction{ID: 100}
-		svc.On("CreateTransaction", mock.Anything, int64(1), int16(4), 50.0).Return(tx, nil)
+		svc.On("CreateTransaction", mock.Anything, int64(1), int16(4), 50.0, domain.Currency("")).Return(tx, nil)
 
 		body := map[string]interface{}{"account_id": 1, "operation_type_id": 4, "amount": 50.0}
 		jsonBody, _ := json.Marshal(body)
@@ -59,9 +107,31 @@ func TestTransactionHandler(t *testing.T) {
 		svc.AssertExpectations(t)
 	})
 
+	t.Run("CreateTransaction - Foreign Currency", func(t *testing.T) {
+		svc := new(MockTransactionService)
+		h := handler.NewTransactionHandler(svc)
+		r := gin.New()
+		r.POST("/transactions", h.CreateTransaction)
+
+		tx := &domain.Transaction{ID: 100, Amount: 100, Currency: "BRL",
+			Conversion: &domain.Conversion{Currency: "USD", Amount: 20, Rate: 5}}
+		svc.On("CreateTransaction", mock.Anything, int64(1), int16(4), 20.0, domain.Currency("usd")).Return(tx, nil)
+
+		req := httptest.NewRequest("POST", "/transactions",
+			bytes.NewBufferString(`{"account_id": 1, "operation_type_id": 4, "amount": 20, "currency": "usd"}`))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"currency":"BRL"`)
+		assert.Contains(t, w.Body.String(), `"original_currency":"USD","original_amount":20,"rate":5`)
+	})
+
 	t.Run("CreateTransaction - Invalid Body", func(t *testing.T) {
 		h := handler.NewTransactionHandler(nil)
 		r := gin.New()
+		r.Use(middleware.Error())
 		r.POST("/transactions", h.CreateTransaction)
 
 		req := httptest.NewRequest("POST", "/transactions", bytes.NewBufferString("{invalid}"))
@@ -70,6 +140,33 @@ func TestTransactionHandler(t *testing.T) {
 		r.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), common.CodeInvalidBody)
+	})
+
+	t.Run("CreateTransaction - Field Errors As Problem Details", func(t *testing.T) {
+		h := handler.NewTransactionHandler(nil)
+		r := gin.New()
+		r.Use(middleware.Error())
+		r.POST("/transactions", h.CreateTransaction)
+
+		req := httptest.NewRequest("POST", "/transactions", bytes.NewBufferString(`{"account_id": "one", "amount": 10}`))
+		req.Header.Set("Accept", "application/problem+json")
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), `"errors":[{"field":"account_id","reason":"must be an integer"}]`)
+
+		req = httptest.NewRequest("POST", "/transactions", bytes.NewBufferString(`{"account_id": 1}`))
+		req.Header.Set("Accept", "application/problem+json")
+		w = httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Contains(t, w.Body.String(), `{"field":"operation_type_id","reason":"is required"}`)
+		assert.Contains(t, w.Body.String(), `{"field":"amount","reason":"is required"}`)
 	})
 
 	t.Run("CreateTransaction - Service Error", func(t *testing.T) {
@@ -78,7 +175,7 @@ func TestTransactionHandler(t *testing.T) {
 		r := gin.New()
 		r.POST("/transactions", h.CreateTransaction)
 
-		svc.On("CreateTransaction", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("error"))
+		svc.On("CreateTransaction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("error"))
 
 		body := map[string]interface{}{"account_id": 1, "operation_type_id": 4, "amount": 50.0}
 		jsonBody, _ := json.Marshal(body)
@@ -110,6 +207,7 @@ func TestTransactionHandler(t *testing.T) {
 	t.Run("GetTransaction - Invalid ID", func(t *testing.T) {
 		h := handler.NewTransactionHandler(nil)
 		r := gin.New()
+		r.Use(middleware.Error())
 		r.GET("/transactions/:transactionId", h.GetTransaction)
 
 		req := httptest.NewRequest("GET", "/transactions/abc", nil)
@@ -118,6 +216,11 @@ func TestTransactionHandler(t *testing.T) {
 		r.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{
+			"code": "INVALID_ID",
+			"message": "the transaction ID must be a valid integer",
+			"errors": [{"field": "transactionId", "reason": "the transaction ID must be a valid integer"}]
+		}`, w.Body.String())
 	})
 
 	t.Run("GetTransaction - Service Error", func(t *testing.T) {
@@ -135,4 +238,126 @@ func TestTransactionHandler(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
+
+	t.Run("ExportTransactions - CSV", func(t *testing.T) {
+		svc := new(MockTransactionService)
+		from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		svc.On("ExportTransactions", mock.Anything, int64(1), from, from.AddDate(0, 1, 0)).Return([]*domain.Transaction{
+			{ID: 10, AccountID: 1, OperationTypeID: domain.Purchase, Amount: -50.5, EventDate: from, Currency: "BRL"},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		exportRouter(svc).ServeHTTP(w, httptest.NewRequest("GET", "/accounts/1/transactions/export?from=2026-03-01&to=2026-03-31", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=account-1-transactions-20260301-20260331.csv`, w.Header().Get("Content-Disposition"))
+		assert.Contains(t, w.Body.String(), "10,1,2026-03-01T00:00:00Z,1,purchase,-50.50,BRL,,,\n")
+	})
+
+	t.Run("ExportTransactions - OFX Without Rows", func(t *testing.T) {
+		svc := new(MockTransactionService)
+		svc.On("ExportTransactions", mock.Anything, int64(1), time.Time{}, time.Time{}).Return(nil, nil)
+
+		w := httptest.NewRecorder()
+		exportRouter(svc).ServeHTTP(w, httptest.NewRequest("GET", "/accounts/1/transactions/export?format=ofx", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-ofx", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "<CURDEF>BRL</CURDEF>")
+		assert.Contains(t, w.Body.String(), "<BALAMT>0.00</BALAMT>")
+	})
+
+	t.Run("ExportTransactions - Failure Midway Aborts The Download", func(t *testing.T) {
+		svc := new(MockTransactionService)
+		rows := make([]*domain.Transaction, 500)
+		for i := range rows {
+			rows[i] = &domain.Transaction{ID: int64(i + 1), AccountID: 1, OperationTypeID: domain.Payment, Amount: 10, Currency: "BRL"}
+		}
+		svc.On("ExportTransactions", mock.Anything, int64(1), time.Time{}, time.Time{}).Return(rows, errors.New("connection reset"))
+		srv := httptest.NewServer(exportRouter(svc))
+		defer srv.Close()
+
+		resp, err := http.Get(srv.URL + "/accounts/1/transactions/export?format=jsonl")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		_, err = io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("ExportTransactions - Invalid Format", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		exportRouter(new(MockTransactionService)).ServeHTTP(w, httptest.NewRequest("GET", "/accounts/1/transactions/export?format=xlsx", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), string(common.CodeValidation))
+		assert.Contains(t, w.Body.String(), `{"field":"format","reason":"format must be one of csv, ofx, jsonl"}`)
+	})
+
+	t.Run("ExportTransactions - Invalid Period", func(t *testing.T) {
+		for _, query := range []string{"from=yesterday", "from=2026-03-10&to=2026-03-01"} {
+			w := httptest.NewRecorder()
+			exportRouter(new(MockTransactionService)).ServeHTTP(w, httptest.NewRequest("GET", "/accounts/1/transactions/export?"+query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+			assert.Contains(t, w.Body.String(), `"field":"from"`, query)
+		}
+	})
+
+	t.Run("ExportTransactions - Account Not Found", func(t *testing.T) {
+		svc := new(MockTransactionService)
+		svc.On("ExportTransactions", mock.Anything, int64(9), mock.Anything, mock.Anything).
+			Return(nil, common.NewNotFoundError(domain.ErrMsgAccountNotFound, common.ErrAccountNotFound))
+
+		w := httptest.NewRecorder()
+		exportRouter(svc).ServeHTTP(w, httptest.NewRequest("GET", "/accounts/9/transactions/export", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, w.Header().Get("Content-Disposition"))
+	})
+	t.Run("GetBalances - Success", func(t *testing.T) {
+		svc := new(MockTransactionService)
+		h := handler.NewTransactionHandler(svc)
+		r := gin.New()
+		r.Use(middleware.Error())
+		r.GET("/accounts/:accountId/balances", h.GetBalances)
+
+		svc.On("GetBalances", mock.Anything, int64(1)).Return(&domain.AccountBalances{
+			AccountID: 1, Currency: "BRL", Balance: -150,
+			Currencies: []domain.CurrencyBalance{
+				{Currency: "BRL", Amount: -50, Booked: -50},
+				{Currency: "USD", Amount: -20, Booked: -100},
+			},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/accounts/1/balances", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{
+			"account_id": 1, "currency": "BRL", "balance": -150,
+			"currencies": [
+				{"currency": "BRL", "amount": -50, "booked": -50},
+				{"currency": "USD", "amount": -20, "booked": -100}
+			]
+		}`, w.Body.String())
+	})
+
+	t.Run("GetBalances - Account Not Found", func(t *testing.T) {
+		svc := new(MockTransactionService)
+		h := handler.NewTransactionHandler(svc)
+		r := gin.New()
+		r.Use(middleware.Error())
+		r.GET("/accounts/:accountId/balances", h.GetBalances)
+
+		svc.On("GetBalances", mock.Anything, int64(9)).
+			Return(nil, common.NewNotFoundError(domain.ErrMsgAccountNotFound, common.ErrAccountNotFound))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/accounts/9/balances", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
 }
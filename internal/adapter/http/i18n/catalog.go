@@ -0,0 +1,159 @@
+package i18n
+
+import (
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+const unexpected = domain.ErrMsgUnexpectedError
+
+// titles holds, per locale, the short description of each error code.
+var titles = map[string]map[common.Code]string{
+	English: {
+		common.CodeValidation:      "Validation failed",
+		common.CodeInvalidBody:     "Invalid request body",
+		common.CodeInvalidID:       "Invalid identifier",
+		common.CodeNotFound:        "Resource not found",
+		common.CodeConflict:        "Resource conflict",
+		common.CodeUnauthorized:    "Authentication required",
+		common.CodeRateLimited:     "Too many requests",
+		common.CodePayloadTooLarge: "Request body too large",
+		common.CodeTimeout:         "Request timed out",
+		common.CodeInternal:        "Internal server error",
+	},
+	Portuguese: {
+		common.CodeValidation:      "Falha na validação",
+		common.CodeInvalidBody:     "Corpo da requisição inválido",
+		common.CodeInvalidID:       "Identificador inválido",
+		common.CodeNotFound:        "Recurso não encontrado",
+		common.CodeConflict:        "Conflito de recurso",
+		common.CodeUnauthorized:    "Autenticação necessária",
+		common.CodeRateLimited:     "Muitas requisições",
+		common.CodePayloadTooLarge: "Corpo da requisição muito grande",
+		common.CodeTimeout:         "Tempo limite da requisição excedido",
+		common.CodeInternal:        "Erro interno do servidor",
+	},
+}
+
+// catalog holds, per locale, the text of each message key and field reason.
+// Placeholders in braces are filled from the error's parameters.
+var catalog = map[string]map[string]string{
+	English: {
+		domain.ErrMsgDocumentInvalid:         "document must be between 11 and 14 digits",
+		domain.ErrMsgAccountExists:           "account with this document already exists",
+		domain.ErrMsgAccountNotFound:         "account not found",
+		domain.ErrMsgAccountIDInvalid:        "the account ID must be a valid integer",
+		domain.ErrMsgTransactionNotFound:     "transaction not found",
+		domain.ErrMsgTransactionIDInvalid:    "the transaction ID must be a valid integer",
+		domain.ErrMsgAccountIDDoesNotExist:   "account id does not exist",
+		domain.ErrMsgOperationTypeInvalid:    "invalid operation type",
+		domain.ErrMsgAmountInvalid:           "amount must be greater than zero",
+		domain.ErrMsgDatabaseError:           "database error",
+		domain.ErrMsgSaveAccountFailed:       "failed to save account",
+		domain.ErrMsgCreateTransactionFailed: "failed to create transaction",
+		domain.ErrMsgMissingToken:            "missing or malformed bearer token",
+		domain.ErrMsgInvalidToken:            "invalid or expired token",
+		domain.ErrMsgRateLimited:             "too many requests, retry later",
+		domain.ErrMsgBodyTooLarge:            "request body too large",
+		domain.ErrMsgRequestTimeout:          "the request took too long to complete",
+		domain.ErrMsgBillingDayInvalid:       "billing day must be between {min} and {max}",
+		domain.ErrMsgStatementNotFound:       "statement not found",
+		domain.ErrMsgStatementIDInvalid:      "the statement ID must be a valid integer",
+		domain.ErrMsgExportFormatInvalid:     "format must be one of {formats}",
+		domain.ErrMsgPeriodDateInvalid:       "dates must be YYYY-MM-DD or RFC 3339 timestamps",
+		domain.ErrMsgPeriodRangeInvalid:      "from must be before to",
+		domain.ErrMsgImportModeInvalid:       "mode must be one of {modes}",
+		domain.ErrMsgImportFormatInvalid:     "format must be one of {formats}",
+		domain.ErrMsgImportFileInvalid:       "the file header must name the account_id, operation_type_id and amount columns",
+		domain.ErrMsgImportFileEmpty:         "the file has no transactions",
+		domain.ErrMsgImportJobNotFound:       "import job not found",
+		domain.ErrMsgImportJobIDInvalid:      "the import job ID must be a valid integer",
+		domain.ErrMsgImportLineMalformed:     "the line is malformed or has a field of the wrong type",
+		domain.ErrMsgImportBatchRejected:     "not imported because other lines were rejected",
+		domain.ErrMsgImportInterrupted:       "the import was interrupted before it finished",
+		domain.ErrMsgAccountBatchInvalid:     "a batch must have between 1 and {max} document numbers",
+		domain.ErrMsgDocumentRepeated:        "the document number appears earlier in the batch",
+		domain.ErrMsgInvalidAPIKey:           "the API key is invalid or has been revoked",
+		domain.ErrMsgJournalEntryNotFound:    "journal entry not found",
+		domain.ErrMsgCurrencyInvalid:         "currency must be a three-letter ISO 4217 code",
+		domain.ErrMsgCurrencyUnsupported:     "currency {currency} is not supported",
+		domain.ErrMsgAPIKeyNotFound:          "API key not found",
+		domain.ErrMsgAPIKeyNameInvalid:       "the API key needs a name",
+		domain.ErrMsgInvalidBodyRequest:      "invalid request body or missing required fields",
+		domain.ErrMsgUnexpectedError:         "an unexpected error occurred",
+
+		domain.ReasonRequired:    "is required",
+		domain.ReasonMin:         "must be at least {param}",
+		domain.ReasonMax:         "must be at most {param}",
+		domain.ReasonGreaterThan: "must be greater than {param}",
+		domain.ReasonLessThan:    "must be less than {param}",
+		domain.ReasonOneOf:       "must be one of {param}",
+		domain.ReasonRule:        "failed the {rule} rule",
+		domain.ReasonInteger:     "must be an integer",
+		domain.ReasonNumber:      "must be a number",
+		domain.ReasonBoolean:     "must be a boolean",
+		domain.ReasonString:      "must be a string",
+		domain.ReasonArray:       "must be an array",
+		domain.ReasonObject:      "must be an object",
+		domain.ReasonSyntax:      "body is not valid JSON",
+	},
+	Portuguese: {
+		domain.ErrMsgDocumentInvalid:         "o documento deve ter entre 11 e 14 dígitos",
+		domain.ErrMsgAccountExists:           "já existe uma conta com este documento",
+		domain.ErrMsgAccountNotFound:         "conta não encontrada",
+		domain.ErrMsgAccountIDInvalid:        "o ID da conta deve ser um número inteiro válido",
+		domain.ErrMsgTransactionNotFound:     "transação não encontrada",
+		domain.ErrMsgTransactionIDInvalid:    "o ID da transação deve ser um número inteiro válido",
+		domain.ErrMsgAccountIDDoesNotExist:   "o ID da conta não existe",
+		domain.ErrMsgOperationTypeInvalid:    "tipo de operação inválido",
+		domain.ErrMsgAmountInvalid:           "o valor deve ser maior que zero",
+		domain.ErrMsgDatabaseError:           "erro no banco de dados",
+		domain.ErrMsgSaveAccountFailed:       "falha ao salvar a conta",
+		domain.ErrMsgCreateTransactionFailed: "falha ao criar a transação",
+		domain.ErrMsgMissingToken:            "token bearer ausente ou malformado",
+		domain.ErrMsgInvalidToken:            "token inválido ou expirado",
+		domain.ErrMsgRateLimited:             "muitas requisições, tente novamente mais tarde",
+		domain.ErrMsgBodyTooLarge:            "corpo da requisição muito grande",
+		domain.ErrMsgRequestTimeout:          "a requisição demorou demais para ser concluída",
+		domain.ErrMsgBillingDayInvalid:       "o dia de fechamento da fatura deve estar entre {min} e {max}",
+		domain.ErrMsgStatementNotFound:       "fatura não encontrada",
+		domain.ErrMsgStatementIDInvalid:      "o ID da fatura deve ser um número inteiro válido",
+		domain.ErrMsgExportFormatInvalid:     "o formato deve ser um de {formats}",
+		domain.ErrMsgPeriodDateInvalid:       "as datas devem estar no formato AAAA-MM-DD ou RFC 3339",
+		domain.ErrMsgPeriodRangeInvalid:      "from deve ser anterior a to",
+		domain.ErrMsgImportModeInvalid:       "o modo deve ser um de {modes}",
+		domain.ErrMsgImportFormatInvalid:     "o formato deve ser um de {formats}",
+		domain.ErrMsgImportFileInvalid:       "o cabeçalho do arquivo deve conter as colunas account_id, operation_type_id e amount",
+		domain.ErrMsgImportFileEmpty:         "o arquivo não tem transações",
+		domain.ErrMsgImportJobNotFound:       "importação não encontrada",
+		domain.ErrMsgImportJobIDInvalid:      "o ID da importação deve ser um número inteiro válido",
+		domain.ErrMsgImportLineMalformed:     "a linha está malformada ou tem um campo do tipo errado",
+		domain.ErrMsgImportBatchRejected:     "não importada porque outras linhas foram rejeitadas",
+		domain.ErrMsgImportInterrupted:       "a importação foi interrompida antes de terminar",
+		domain.ErrMsgAccountBatchInvalid:     "um lote deve ter entre 1 e {max} documentos",
+		domain.ErrMsgDocumentRepeated:        "o documento aparece antes no mesmo lote",
+		domain.ErrMsgInvalidAPIKey:           "a chave de API é inválida ou foi revogada",
+		domain.ErrMsgJournalEntryNotFound:    "lançamento contábil não encontrado",
+		domain.ErrMsgCurrencyInvalid:         "a moeda deve ser um código ISO 4217 de três letras",
+		domain.ErrMsgCurrencyUnsupported:     "a moeda {currency} não é suportada",
+		domain.ErrMsgAPIKeyNotFound:          "chave de API não encontrada",
+		domain.ErrMsgAPIKeyNameInvalid:       "a chave de API precisa de um nome",
+		domain.ErrMsgInvalidBodyRequest:      "corpo da requisição inválido ou campos obrigatórios ausentes",
+		domain.ErrMsgUnexpectedError:         "ocorreu um erro inesperado",
+
+		domain.ReasonRequired:    "é obrigatório",
+		domain.ReasonMin:         "deve ser no mínimo {param}",
+		domain.ReasonMax:         "deve ser no máximo {param}",
+		domain.ReasonGreaterThan: "deve ser maior que {param}",
+		domain.ReasonLessThan:    "deve ser menor que {param}",
+		domain.ReasonOneOf:       "deve ser um dos valores {param}",
+		domain.ReasonRule:        "não atende à regra {rule}",
+		domain.ReasonInteger:     "deve ser um número inteiro",
+		domain.ReasonNumber:      "deve ser um número",
+		domain.ReasonBoolean:     "deve ser um booleano",
+		domain.ReasonString:      "deve ser um texto",
+		domain.ReasonArray:       "deve ser uma lista",
+		domain.ReasonObject:      "deve ser um objeto",
+		domain.ReasonSyntax:      "o corpo não é um JSON válido",
+	},
+}
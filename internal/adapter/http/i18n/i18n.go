@@ -0,0 +1,81 @@
+// Package i18n renders error messages in the client's language.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+
+	common "github.com/evythrossell/account-management-api/pkg"
+	"golang.org/x/text/language"
+)
+
+// Supported locales. English is the fallback for anything missing from
+// another locale.
+const (
+	English    = "en"
+	Portuguese = "pt-BR"
+)
+
+var (
+	locales = []string{English, Portuguese}
+	matcher = language.NewMatcher([]language.Tag{language.English, language.BrazilianPortuguese})
+)
+
+// Negotiate picks the supported locale that best matches an Accept-Language
+// header, defaulting to English.
+func Negotiate(acceptLanguage string) string {
+	_, index := language.MatchStrings(matcher, acceptLanguage)
+	return locales[index]
+}
+
+// Title returns the short description of an error code.
+func Title(locale string, code common.Code) string {
+	if text, ok := titles[locale][code]; ok {
+		return text
+	}
+	if text, ok := titles[English][code]; ok {
+		return text
+	}
+	text, _ := lookup(locale, unexpected)
+	return text
+}
+
+// Message renders the message for key, falling back to the code's title when
+// the catalog has no such key.
+func Message(locale string, code common.Code, key string, params map[string]any) string {
+	if text, ok := lookup(locale, key); ok {
+		return render(text, params)
+	}
+	return Title(locale, code)
+}
+
+// Reason renders a field-level reason. Reasons missing from the catalog are
+// returned as given.
+func Reason(locale, key string, params map[string]any) string {
+	if text, ok := lookup(locale, key); ok {
+		return render(text, params)
+	}
+	return render(key, params)
+}
+
+func lookup(locale, key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+	if text, ok := catalog[locale][key]; ok {
+		return text, true
+	}
+	text, ok := catalog[English][key]
+	return text, ok
+}
+
+func render(text string, params map[string]any) string {
+	if len(params) == 0 {
+		return text
+	}
+	pairs := make([]string, 0, 2*len(params))
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
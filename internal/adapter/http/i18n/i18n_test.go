@@ -0,0 +1,95 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/http/i18n"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	cases := map[string]string{
+		"":                           i18n.English,
+		"pt-BR":                      i18n.Portuguese,
+		"pt":                         i18n.Portuguese,
+		"pt-PT,pt;q=0.9":             i18n.Portuguese,
+		"en-US,en;q=0.9":             i18n.English,
+		"fr-FR":                      i18n.English,
+		"fr-FR,pt-BR;q=0.8,en;q=0.5": i18n.Portuguese,
+		"en;q=0.9,pt-BR":             i18n.Portuguese,
+		"not a language tag":         i18n.English,
+	}
+	for header, want := range cases {
+		assert.Equal(t, want, i18n.Negotiate(header), header)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	t.Run("should render the message in the requested locale", func(t *testing.T) {
+		assert.Equal(t, "conta não encontrada", i18n.Message(i18n.Portuguese, common.CodeNotFound, domain.ErrMsgAccountNotFound, nil))
+		assert.Equal(t, "account not found", i18n.Message(i18n.English, common.CodeNotFound, domain.ErrMsgAccountNotFound, nil))
+	})
+
+	t.Run("should fall back to English for unknown locales", func(t *testing.T) {
+		assert.Equal(t, "account not found", i18n.Message("de", common.CodeNotFound, domain.ErrMsgAccountNotFound, nil))
+	})
+
+	t.Run("should fall back to the code title for unknown keys", func(t *testing.T) {
+		assert.Equal(t, "Recurso não encontrado", i18n.Message(i18n.Portuguese, common.CodeNotFound, "no_such_key", nil))
+		assert.Equal(t, "Validation failed", i18n.Message(i18n.English, common.CodeValidation, "", nil))
+	})
+
+	t.Run("should fall back to a generic message for unknown codes", func(t *testing.T) {
+		assert.Equal(t, "an unexpected error occurred", i18n.Message(i18n.English, "NO_SUCH_CODE", "", nil))
+	})
+
+	t.Run("should fill placeholders in field reasons", func(t *testing.T) {
+		params := map[string]any{"param": 3}
+		assert.Equal(t, "must be at least 3", i18n.Reason(i18n.English, domain.ReasonMin, params))
+		assert.Equal(t, "deve ser no mínimo 3", i18n.Reason(i18n.Portuguese, domain.ReasonMin, params))
+		assert.Equal(t, "free text", i18n.Reason(i18n.Portuguese, "free text", nil))
+	})
+}
+
+func TestCatalog(t *testing.T) {
+	keys := []string{
+		domain.ErrMsgDocumentInvalid, domain.ErrMsgAccountExists, domain.ErrMsgAccountNotFound,
+		domain.ErrMsgAccountIDInvalid, domain.ErrMsgTransactionNotFound, domain.ErrMsgTransactionIDInvalid,
+		domain.ErrMsgAccountIDDoesNotExist, domain.ErrMsgOperationTypeInvalid, domain.ErrMsgAmountInvalid,
+		domain.ErrMsgDatabaseError, domain.ErrMsgSaveAccountFailed, domain.ErrMsgCreateTransactionFailed,
+		domain.ErrMsgMissingToken, domain.ErrMsgInvalidToken,
+		domain.ErrMsgRateLimited, domain.ErrMsgBodyTooLarge, domain.ErrMsgRequestTimeout,
+		domain.ErrMsgBillingDayInvalid, domain.ErrMsgStatementNotFound, domain.ErrMsgStatementIDInvalid,
+		domain.ErrMsgExportFormatInvalid, domain.ErrMsgPeriodDateInvalid, domain.ErrMsgPeriodRangeInvalid,
+		domain.ErrMsgImportModeInvalid, domain.ErrMsgImportFormatInvalid, domain.ErrMsgImportFileInvalid,
+		domain.ErrMsgImportFileEmpty, domain.ErrMsgImportJobNotFound, domain.ErrMsgImportJobIDInvalid,
+		domain.ErrMsgImportLineMalformed, domain.ErrMsgImportBatchRejected, domain.ErrMsgImportInterrupted,
+		domain.ErrMsgAccountBatchInvalid, domain.ErrMsgDocumentRepeated, domain.ErrMsgInvalidAPIKey,
+		domain.ErrMsgAPIKeyNotFound, domain.ErrMsgAPIKeyNameInvalid, domain.ErrMsgJournalEntryNotFound,
+		domain.ErrMsgCurrencyInvalid, domain.ErrMsgCurrencyUnsupported,
+		domain.ErrMsgInvalidBodyRequest, domain.ErrMsgUnexpectedError,
+		domain.ReasonRequired, domain.ReasonMin, domain.ReasonMax, domain.ReasonGreaterThan,
+		domain.ReasonLessThan, domain.ReasonOneOf, domain.ReasonRule, domain.ReasonInteger,
+		domain.ReasonNumber, domain.ReasonBoolean, domain.ReasonString, domain.ReasonArray,
+		domain.ReasonObject, domain.ReasonSyntax,
+	}
+
+	t.Run("should title every code in every locale", func(t *testing.T) {
+		generic := i18n.Title(i18n.English, "NO_SUCH_CODE")
+		for _, info := range common.Codes() {
+			code := info.Code
+			assert.NotEqual(t, generic, i18n.Title(i18n.English, code), code)
+			assert.NotEqual(t, i18n.Title(i18n.English, code), i18n.Title(i18n.Portuguese, code), code)
+		}
+	})
+
+	t.Run("should translate every key", func(t *testing.T) {
+		for _, key := range keys {
+			en := i18n.Reason(i18n.English, key, nil)
+			assert.NotEqual(t, key, en, "missing English text for %s", key)
+			assert.NotEqual(t, en, i18n.Reason(i18n.Portuguese, key, nil), "missing Portuguese text for %s", key)
+		}
+	})
+}
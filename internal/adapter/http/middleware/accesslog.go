@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type AccessLogConfig struct {
+	// SkipPaths are path prefixes that are never logged, such as probes.
+	SkipPaths []string
+	// SampledRoutes are hot route templates (e.g. "/v1/accounts/:accountId")
+	// whose 2xx responses are logged at SuccessSampleRate. Other statuses are
+	// always logged.
+	SampledRoutes     []string
+	SuccessSampleRate float64
+}
+
+func DefaultAccessLogConfig() AccessLogConfig {
+	return AccessLogConfig{
+		SkipPaths:         []string{"/health", "/metrics", "/swagger"},
+		SuccessSampleRate: 1,
+	}
+}
+
+// AccessLog writes one line per request through the request logger, so each
+// line carries the request ID. It must run after RequestID. A handler that
+// drops the connection with http.ErrAbortHandler is logged as a 500, since
+// the client never got a complete response.
+func AccessLog(cfg AccessLogConfig) gin.HandlerFunc {
+	sampled := make(map[string]bool, len(cfg.SampledRoutes))
+	for _, route := range cfg.SampledRoutes {
+		sampled[route] = true
+	}
+
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		for _, prefix := range cfg.SkipPaths {
+			if strings.HasPrefix(path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		start := time.Now()
+		defer func() {
+			status := c.Writer.Status()
+			rec := recover()
+			if rec != nil {
+				status = http.StatusInternalServerError
+			}
+			logRequest(c, sampled, cfg.SuccessSampleRate, status, time.Since(start))
+			if rec != nil {
+				panic(rec)
+			}
+		}()
+		c.Next()
+	}
+}
+
+// logRequest writes the line for a finished request, unless it is a success
+// on a sampled route that the sample leaves out.
+func logRequest(c *gin.Context, sampled map[string]bool, sampleRate float64, status int, latency time.Duration) {
+	path := c.Request.URL.Path
+	route := c.FullPath()
+	if status < http.StatusMultipleChoices && sampled[route] && rand.Float64() >= sampleRate {
+		return
+	}
+
+	fields := []common.Field{
+		common.String("method", c.Request.Method),
+		common.String("route", route),
+		common.Int("status", status),
+		common.Duration("latency", latency),
+		common.Int("bytes", max(c.Writer.Size(), 0)),
+		common.String("client_ip", c.ClientIP()),
+	}
+	if p, ok := domain.PrincipalFromContext(c.Request.Context()); ok {
+		fields = append(fields, common.String("subject", p.Subject))
+	}
+	if route == "" {
+		fields = append(fields, common.String("path", path))
+	}
+
+	log := common.FromContext(c.Request.Context())
+	switch {
+	case status >= http.StatusInternalServerError:
+		log.Error("http request", fields...)
+	case status >= http.StatusBadRequest:
+		log.Warn("http request", fields...)
+	default:
+		log.Info("http request", fields...)
+	}
+}
@@ -0,0 +1,119 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAccessLogRouter(log *recordingLogger, cfg middleware.AccessLogConfig) *gin.Engine {
+	r := gin.New()
+	r.Use(middleware.RequestID(log))
+	r.Use(func(c *gin.Context) {
+		if subject := c.GetHeader("X-Test-Subject"); subject != "" {
+			c.Request = c.Request.WithContext(domain.ContextWithPrincipal(c.Request.Context(), &domain.Principal{Subject: subject}))
+		}
+	})
+	r.Use(middleware.AccessLog(cfg))
+	r.Use(middleware.Error())
+	r.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/v1/accounts/:accountId", func(c *gin.Context) { c.String(http.StatusOK, "hello") })
+	r.GET("/v1/fail", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+	r.GET("/v1/abort", func(c *gin.Context) {
+		c.String(http.StatusOK, "partial")
+		panic(http.ErrAbortHandler)
+	})
+	return r
+}
+
+func TestAccessLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should log request details with route template", func(t *testing.T) {
+		log := &recordingLogger{}
+		r := newAccessLogRouter(log, middleware.DefaultAccessLogConfig())
+
+		req := httptest.NewRequest("GET", "/v1/accounts/42", nil)
+		req.Header.Set(middleware.RequestIDHeader, "req-1")
+		req.Header.Set("X-Test-Subject", "client-a")
+		r.ServeHTTP(httptest.NewRecorder(), req)
+
+		require.Len(t, log.lines, 1)
+		assert.Equal(t, "info", log.lines[0].level)
+		assert.Equal(t, "http request", log.lines[0].msg)
+		assert.Equal(t, "GET", log.field(0, "method"))
+		assert.Equal(t, "/v1/accounts/:accountId", log.field(0, "route"))
+		assert.Equal(t, http.StatusOK, log.field(0, "status"))
+		assert.Equal(t, 5, log.field(0, "bytes"))
+		assert.Equal(t, "client-a", log.field(0, "subject"))
+		assert.Equal(t, "req-1", log.field(0, "request_id"))
+		assert.IsType(t, time.Duration(0), log.field(0, "latency"))
+	})
+
+	t.Run("should skip excluded paths", func(t *testing.T) {
+		log := &recordingLogger{}
+		r := newAccessLogRouter(log, middleware.DefaultAccessLogConfig())
+
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/health", nil))
+
+		assert.Empty(t, log.lines)
+	})
+
+	t.Run("should log server errors at error level", func(t *testing.T) {
+		log := &recordingLogger{}
+		r := newAccessLogRouter(log, middleware.DefaultAccessLogConfig())
+
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/v1/fail", nil))
+
+		require.Len(t, log.lines, 1)
+		assert.Equal(t, "error", log.lines[0].level)
+	})
+
+	t.Run("should log dropped connections as server errors", func(t *testing.T) {
+		log := &recordingLogger{}
+		r := newAccessLogRouter(log, middleware.DefaultAccessLogConfig())
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/v1/abort", nil))
+		})
+
+		require.Len(t, log.lines, 1)
+		assert.Equal(t, "error", log.lines[0].level)
+		assert.Equal(t, http.StatusInternalServerError, log.field(0, "status"))
+		assert.Equal(t, 7, log.field(0, "bytes"))
+	})
+
+	t.Run("should log unmatched routes with their path", func(t *testing.T) {
+		log := &recordingLogger{}
+		r := newAccessLogRouter(log, middleware.DefaultAccessLogConfig())
+
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/nope", nil))
+
+		require.Len(t, log.lines, 1)
+		assert.Equal(t, "warn", log.lines[0].level)
+		assert.Equal(t, "/nope", log.field(0, "path"))
+	})
+
+	t.Run("should sample successful requests on hot routes only", func(t *testing.T) {
+		log := &recordingLogger{}
+		r := newAccessLogRouter(log, middleware.AccessLogConfig{
+			SampledRoutes:     []string{"/v1/accounts/:accountId"},
+			SuccessSampleRate: 0,
+		})
+
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/v1/accounts/42", nil))
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/v1/fail", nil))
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/health", nil))
+
+		require.Len(t, log.lines, 2)
+		assert.Equal(t, "/v1/fail", log.field(0, "route"))
+		assert.Equal(t, "/health", log.field(1, "route"))
+	})
+}
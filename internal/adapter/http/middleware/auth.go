@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+const bearerPrefix = "bearer "
+
+// Auth validates the bearer token of each request and stores the resulting
+// principal in the request context, where services can read it through
+// domain.PrincipalFromContext. Requests already authenticated by a client
+// certificate skip the token check.
+func Auth(verifier port.TokenVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := domain.PrincipalFromContext(c.Request.Context()); ok {
+			c.Next()
+			return
+		}
+
+		header := c.GetHeader("Authorization")
+		if len(header) <= len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+			c.Header("WWW-Authenticate", `Bearer`)
+			c.Error(common.NewUnauthorizedError(domain.ErrMsgMissingToken, common.ErrMissingToken))
+			c.Abort()
+			return
+		}
+
+		token := strings.TrimSpace(header[len(bearerPrefix):])
+		principal, err := verifier.Verify(c.Request.Context(), token)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			c.Error(common.NewUnauthorizedError(domain.ErrMsgInvalidToken, err))
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(domain.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// APIKeyHeader carries the API keys that APIKey checks.
+const APIKeyHeader = "X-API-Key"
+
+// APIKey authenticates requests that carry an API key. Requests without one
+// pass through unchanged so bearer authentication can still apply, while a
+// key that fails verification is rejected rather than ignored.
+func APIKey(verifier port.TokenVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret := c.GetHeader(APIKeyHeader)
+		if secret == "" {
+			c.Next()
+			return
+		}
+		if _, ok := domain.PrincipalFromContext(c.Request.Context()); ok {
+			c.Next()
+			return
+		}
+
+		principal, err := verifier.Verify(c.Request.Context(), secret)
+		if err != nil {
+			var de *common.DomainError
+			if !errors.As(err, &de) {
+				err = common.NewUnauthorizedError(domain.ErrMsgInvalidAPIKey, err)
+			}
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(domain.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// ClientCert authenticates requests whose verified TLS client certificate
+// maps to a known identity. Other requests pass through unchanged so bearer
+// authentication can still apply.
+func ClientCert(mapper port.ClientCertMapper) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if state := c.Request.TLS; state != nil && len(state.VerifiedChains) > 0 && len(state.VerifiedChains[0]) > 0 {
+			if principal, ok := mapper.Principal(state.VerifiedChains[0][0]); ok {
+				c.Request = c.Request.WithContext(domain.ContextWithPrincipal(c.Request.Context(), principal))
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,247 @@
+package middleware_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTokenVerifier struct {
+	mock.Mock
+}
+
+func (m *MockTokenVerifier) Verify(ctx context.Context, token string) (*domain.Principal, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Principal), args.Error(1)
+}
+
+func newAuthRouter(verifier *MockTokenVerifier) *gin.Engine {
+	r := gin.New()
+	r.Use(middleware.Error())
+	r.Use(middleware.Auth(verifier))
+	r.GET("/protected", func(c *gin.Context) {
+		p, ok := domain.PrincipalFromContext(c.Request.Context())
+		if !ok {
+			c.Status(http.StatusTeapot)
+			return
+		}
+		c.JSON(http.StatusOK, p)
+	})
+	return r
+}
+
+func TestAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should store principal in request context", func(t *testing.T) {
+		verifier := new(MockTokenVerifier)
+		verifier.On("Verify", mock.Anything, "good-token").
+			Return(&domain.Principal{Subject: "client-1", Tenant: "acme"}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Bearer good-token")
+		newAuthRouter(verifier).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "client-1")
+		verifier.AssertExpectations(t)
+	})
+
+	t.Run("should accept case-insensitive scheme", func(t *testing.T) {
+		verifier := new(MockTokenVerifier)
+		verifier.On("Verify", mock.Anything, "good-token").Return(&domain.Principal{Subject: "client-1"}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "bearer good-token")
+		newAuthRouter(verifier).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should return 401 when header is missing", func(t *testing.T) {
+		verifier := new(MockTokenVerifier)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/protected", nil)
+		newAuthRouter(verifier).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+		assert.Contains(t, w.Body.String(), common.CodeUnauthorized)
+		verifier.AssertNotCalled(t, "Verify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return 401 for non bearer scheme", func(t *testing.T) {
+		verifier := new(MockTokenVerifier)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+		newAuthRouter(verifier).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("should return 401 when token is invalid", func(t *testing.T) {
+		verifier := new(MockTokenVerifier)
+		verifier.On("Verify", mock.Anything, "bad-token").Return(nil, errors.New("signature mismatch"))
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Bearer bad-token")
+		newAuthRouter(verifier).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), "invalid_token")
+		assert.Contains(t, w.Body.String(), "invalid or expired token")
+		assert.NotContains(t, w.Body.String(), "signature mismatch")
+	})
+}
+
+type staticCertMapper map[string]*domain.Principal
+
+func (m staticCertMapper) Principal(cert *x509.Certificate) (*domain.Principal, bool) {
+	p, ok := m[cert.Subject.CommonName]
+	return p, ok
+}
+
+func TestClientCert(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mapper := staticCertMapper{"billing": {Subject: "svc-billing"}}
+	withCert := func(req *http.Request, cn string) *http.Request {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+		req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+		return req
+	}
+	newRouter := func(verifier *MockTokenVerifier) *gin.Engine {
+		r := gin.New()
+		r.Use(middleware.Error())
+		r.Use(middleware.ClientCert(mapper))
+		r.Use(middleware.Auth(verifier))
+		r.GET("/protected", func(c *gin.Context) {
+			p, _ := domain.PrincipalFromContext(c.Request.Context())
+			c.JSON(http.StatusOK, p)
+		})
+		return r
+	}
+
+	t.Run("should authenticate mapped certificates without a token", func(t *testing.T) {
+		verifier := new(MockTokenVerifier)
+
+		w := httptest.NewRecorder()
+		newRouter(verifier).ServeHTTP(w, withCert(httptest.NewRequest("GET", "/protected", nil), "billing"))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "svc-billing")
+		verifier.AssertNotCalled(t, "Verify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should fall back to bearer tokens for unmapped certificates", func(t *testing.T) {
+		verifier := new(MockTokenVerifier)
+		verifier.On("Verify", mock.Anything, "good-token").Return(&domain.Principal{Subject: "client-1"}, nil)
+
+		w := httptest.NewRecorder()
+		req := withCert(httptest.NewRequest("GET", "/protected", nil), "stranger")
+		req.Header.Set("Authorization", "Bearer good-token")
+		newRouter(verifier).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "client-1")
+	})
+
+	t.Run("should return 401 for unmapped certificates without a token", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(new(MockTokenVerifier)).ServeHTTP(w, withCert(httptest.NewRequest("GET", "/protected", nil), "stranger"))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(keys, tokens *MockTokenVerifier) *gin.Engine {
+		r := gin.New()
+		r.Use(middleware.Error())
+		r.Use(middleware.APIKey(keys))
+		r.Use(middleware.Auth(tokens))
+		r.GET("/protected", func(c *gin.Context) {
+			p, _ := domain.PrincipalFromContext(c.Request.Context())
+			c.JSON(http.StatusOK, p)
+		})
+		return r
+	}
+
+	t.Run("should authenticate valid keys without a token", func(t *testing.T) {
+		keys, tokens := new(MockTokenVerifier), new(MockTokenVerifier)
+		keys.On("Verify", mock.Anything, "amk_good").Return(&domain.Principal{Subject: "apikey:billing"}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/protected", nil)
+		req.Header.Set(middleware.APIKeyHeader, "amk_good")
+		newRouter(keys, tokens).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "apikey:billing")
+		tokens.AssertNotCalled(t, "Verify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should fall back to bearer tokens without a key", func(t *testing.T) {
+		keys, tokens := new(MockTokenVerifier), new(MockTokenVerifier)
+		tokens.On("Verify", mock.Anything, "good-token").Return(&domain.Principal{Subject: "client-1"}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Bearer good-token")
+		newRouter(keys, tokens).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		keys.AssertNotCalled(t, "Verify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return 401 for invalid keys even with a token", func(t *testing.T) {
+		keys, tokens := new(MockTokenVerifier), new(MockTokenVerifier)
+		keys.On("Verify", mock.Anything, "amk_bad").Return(nil, common.ErrInvalidAPIKey)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/protected", nil)
+		req.Header.Set(middleware.APIKeyHeader, "amk_bad")
+		req.Header.Set("Authorization", "Bearer good-token")
+		newRouter(keys, tokens).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "revoked")
+		tokens.AssertNotCalled(t, "Verify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not hide lookup failures as 401", func(t *testing.T) {
+		keys := new(MockTokenVerifier)
+		keys.On("Verify", mock.Anything, "amk_good").
+			Return(nil, common.NewInternalError(domain.ErrMsgDatabaseError, errors.New("connection reset")))
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/protected", nil)
+		req.Header.Set(middleware.APIKeyHeader, "amk_good")
+		newRouter(keys, new(MockTokenVerifier)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/evythrossell/account-management-api/internal/adapter/http/i18n"
 	"github.com/evythrossell/account-management-api/internal/core/domain"
 	common "github.com/evythrossell/account-management-api/pkg"
 	"github.com/gin-gonic/gin"
@@ -15,48 +16,85 @@ func Error() gin.HandlerFunc {
 
 		if len(c.Errors) > 0 {
 			err := c.Errors.Last().Err
+			abortWithError(c, err, domainError(err))
+		}
+	}
+}
+
+// domainError classifies err, mapping the storage sentinels to their codes
+// and anything unrecognised to an internal error.
+func domainError(err error) *common.DomainError {
+	var de *common.DomainError
+	switch {
+	case errors.As(err, &de):
+		return de
+	case errors.Is(err, common.ErrAccountNotFound):
+		return common.NewNotFoundError(domain.ErrMsgAccountNotFound, err)
+	case errors.Is(err, common.ErrTransactionNotFound):
+		return common.NewNotFoundError(domain.ErrMsgTransactionNotFound, err)
+	case errors.Is(err, common.ErrInvalidAmount):
+		return common.NewValidationError(domain.ErrMsgAmountInvalid, err)
+	case errors.Is(err, common.ErrInvalidOperation):
+		return common.NewValidationError(domain.ErrMsgOperationTypeInvalid, err)
+	default:
+		return common.NewInternalError(domain.ErrMsgUnexpectedError, err)
+	}
+}
+
+// abortWithError writes the public error body in the negotiated language, as
+// problem details when the client accepts them. The status comes from the
+// code registry. Server errors are logged with the underlying cause, which is
+// never sent to the client.
+func abortWithError(c *gin.Context, err error, de *common.DomainError) {
+	ctx := c.Request.Context()
+	status, code := de.HTTPStatusCode(), de.Code
+	locale := i18n.Negotiate(c.GetHeader("Accept-Language"))
+	message := i18n.Message(locale, code, de.Key, de.Params)
+	fields := localizeFields(locale, de.Fields, de.Params)
+
+	if status >= http.StatusInternalServerError {
+		common.FromContext(ctx).Error("request failed",
+			common.String("code", string(code)),
+			common.String("path", c.FullPath()),
+			common.Err(err),
+		)
+	}
 
-			var de *common.DomainError
-			if errors.As(err, &de) {
-				c.AbortWithStatusJSON(de.HTTPStatusCode(), gin.H{
-					"code":    de.Code,
-					"message": de.PublicMessage(),
-				})
-				return
-			}
-			if errors.Is(err, common.ErrAccountNotFound) {
-				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{
-					"code":    domain.ErrCodeNotFound,
-					"message": domain.ErrMsgAccountNotFound,
-				})
-				return
-			}
-			if errors.Is(err, common.ErrTransactionNotFound) {
-				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{
-					"code":    domain.ErrCodeNotFound,
-					"message": domain.ErrMsgTransactionNotFound,
-				})
-				return
-			}
-			if errors.Is(err, common.ErrInvalidAmount) {
-				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-					"code":    domain.ErrCodeValidation,
-					"message": domain.ErrMsgAmountInvalid,
-				})
-				return
-			}
-			if errors.Is(err, common.ErrInvalidOperation) {
-				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-					"code":    domain.ErrCodeValidation,
-					"message": domain.ErrMsgOperationTypeInvalid,
-				})
-				return
-			}
-
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-				"code":    domain.ErrCodeInternalError,
-				"message": domain.ErrMsgUnexpectedError,
-			})
+	c.Writer.Header().Add("Vary", "Accept, Accept-Language")
+	c.Header("Content-Language", locale)
+	if wantsProblem(c) {
+		c.Header("Content-Type", ProblemContentType)
+		c.AbortWithStatusJSON(status, newProblem(c, status, code, i18n.Title(locale, code), message, fields))
+		return
+	}
+
+	body := gin.H{
+		"code":    code,
+		"message": message,
+	}
+	if id := common.RequestIDFromContext(ctx); id != "" {
+		body["request_id"] = id
+	}
+	if len(fields) > 0 {
+		body["errors"] = fields
+	}
+
+	c.AbortWithStatusJSON(status, body)
+}
+
+// localizeFields renders each field reason; a field without parameters of its
+// own reuses the error's, as when the reason repeats the message key.
+func localizeFields(locale string, fields []common.FieldError, params map[string]any) []common.FieldError {
+	if len(fields) == 0 {
+		return nil
+	}
+	localized := make([]common.FieldError, len(fields))
+	for i, field := range fields {
+		fieldParams := field.Params
+		if fieldParams == nil {
+			fieldParams = params
 		}
+		localized[i] = common.FieldError{Field: field.Field, Reason: i18n.Reason(locale, field.Reason, fieldParams)}
 	}
+	return localized
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
 	common "github.com/evythrossell/account-management-api/pkg"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -19,7 +20,7 @@ func TestError(t *testing.T) {
 		r := gin.New()
 		r.Use(middleware.Error())
 
-		domainErr := common.NewNotFoundError("account not found", nil)
+		domainErr := common.NewNotFoundError(domain.ErrMsgAccountNotFound, nil)
 
 		r.GET("/not-found", func(c *gin.Context) {
 			c.Error(domainErr)
@@ -38,7 +39,7 @@ func TestError(t *testing.T) {
 		r := gin.New()
 		r.Use(middleware.Error())
 
-		domainErr := common.NewValidationError("invalid input", nil)
+		domainErr := common.NewValidationError(domain.ErrMsgDocumentInvalid, nil)
 
 		r.GET("/validation-error", func(c *gin.Context) {
 			c.Error(domainErr)
@@ -65,7 +66,7 @@ func TestError(t *testing.T) {
 		r.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
-		assert.Contains(t, w.Body.String(), "INTERNAL_SERVER_ERROR")
+		assert.Contains(t, w.Body.String(), "INTERNAL_ERROR")
 		assert.Contains(t, w.Body.String(), "an unexpected error occurred")
 	})
 
@@ -152,4 +153,101 @@ func TestError(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "VALIDATION_ERROR")
 		assert.Contains(t, w.Body.String(), "invalid operation type")
 	})
+
+	t.Run("should render problem details when accepted", func(t *testing.T) {
+		r := gin.New()
+		r.Use(middleware.RequestID(common.NewNoOpLogger()))
+		r.Use(middleware.Error())
+		r.POST("/v1/accounts", func(c *gin.Context) {
+			c.Error(common.NewValidationError(domain.ErrMsgDocumentInvalid, nil).
+				WithField("document_number", domain.ErrMsgDocumentInvalid))
+		})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/v1/accounts", nil)
+		req.Header.Set("Accept", "application/problem+json, application/json;q=0.5")
+		req.Header.Set("X-Request-ID", "req-1")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, middleware.ProblemContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, "Accept, Accept-Language", w.Header().Get("Vary"))
+		assert.JSONEq(t, `{
+			"type": "https://github.com/evythrossell/account-management-api/blob/main/ERRORS.md#validation_error",
+			"title": "Validation failed",
+			"status": 400,
+			"detail": "document must be between 11 and 14 digits",
+			"instance": "/v1/accounts",
+			"code": "VALIDATION_ERROR",
+			"request_id": "req-1",
+			"errors": [{"field": "document_number", "reason": "document must be between 11 and 14 digits"}]
+		}`, w.Body.String())
+	})
+
+	t.Run("should keep the legacy body for other clients", func(t *testing.T) {
+		r := gin.New()
+		r.Use(middleware.Error())
+		r.GET("/v1/accounts/x", func(c *gin.Context) {
+			c.Error(common.NewValidationError(domain.ErrMsgAccountIDInvalid, nil).
+				WithField("accountId", domain.ErrMsgAccountIDInvalid))
+		})
+
+		for _, accept := range []string{"", "*/*", "application/json"} {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/v1/accounts/x", nil)
+			if accept != "" {
+				req.Header.Set("Accept", accept)
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"), accept)
+			assert.JSONEq(t, `{
+				"code": "VALIDATION_ERROR",
+				"message": "the account ID must be a valid integer",
+				"errors": [{"field": "accountId", "reason": "the account ID must be a valid integer"}]
+			}`, w.Body.String(), accept)
+		}
+	})
+
+	t.Run("should answer in the negotiated language", func(t *testing.T) {
+		r := gin.New()
+		r.Use(middleware.Error())
+		r.POST("/v1/transactions", func(c *gin.Context) {
+			c.Error(common.NewValidationError(domain.ErrMsgAmountInvalid, nil).
+				WithField("amount", domain.ErrMsgAmountInvalid))
+		})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/v1/transactions", nil)
+		req.Header.Set("Accept", "application/problem+json")
+		req.Header.Set("Accept-Language", "pt-BR,pt;q=0.9,en;q=0.8")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, "pt-BR", w.Header().Get("Content-Language"))
+		assert.JSONEq(t, `{
+			"type": "https://github.com/evythrossell/account-management-api/blob/main/ERRORS.md#validation_error",
+			"title": "Falha na validação",
+			"status": 400,
+			"detail": "o valor deve ser maior que zero",
+			"instance": "/v1/transactions",
+			"code": "VALIDATION_ERROR",
+			"errors": [{"field": "amount", "reason": "o valor deve ser maior que zero"}]
+		}`, w.Body.String())
+	})
+
+	t.Run("should fall back to English for unsupported languages", func(t *testing.T) {
+		r := gin.New()
+		r.Use(middleware.Error())
+		r.GET("/v1/accounts/1", func(c *gin.Context) {
+			c.Error(common.NewNotFoundError(domain.ErrMsgAccountNotFound, nil))
+		})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/v1/accounts/1", nil)
+		req.Header.Set("Accept-Language", "fr-FR")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, "en", w.Header().Get("Content-Language"))
+		assert.JSONEq(t, `{"code": "NOT_FOUND_ERROR", "message": "account not found"}`, w.Body.String())
+	})
 }
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimit rejects requests whose declared body exceeds max bytes with 413.
+// Bodies of unknown length are capped while being read; handlers report the
+// overflow through IsBodyTooLarge.
+func BodyLimit(max int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > max {
+			c.Error(common.NewPayloadTooLargeError(domain.ErrMsgBodyTooLarge, nil))
+			c.Abort()
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, max)
+		c.Next()
+	}
+}
+
+func IsBodyTooLarge(err error) bool {
+	var maxErr *http.MaxBytesError
+	return errors.As(err, &maxErr)
+}
+
+// Timeout bounds the request context, which services and repositories pass
+// down to their queries. When the deadline expires before a response is
+// written the request is answered with 504, replacing whatever error the
+// cancelled work produced.
+func Timeout(d time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			c.Error(common.NewTimeoutError(domain.ErrMsgRequestTimeout, ctx.Err()))
+			c.Abort()
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBodyLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(middleware.Error())
+	r.Use(middleware.BodyLimit(8))
+	r.POST("/upload", func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			assert.True(t, middleware.IsBodyTooLarge(err))
+			c.Status(http.StatusTeapot)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	t.Run("should accept bodies within the limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("POST", "/upload", strings.NewReader("12345678")))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("should reject declared oversized bodies with 413", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("POST", "/upload", strings.NewReader("123456789")))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.JSONEq(t, `{"code":"PAYLOAD_TOO_LARGE","message":"request body too large"}`, w.Body.String())
+	})
+
+	t.Run("should cap bodies of unknown length", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/upload", strings.NewReader("123456789"))
+		req.ContentLength = -1
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusTeapot, w.Code)
+	})
+}
+
+func TestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(h gin.HandlerFunc) *gin.Engine {
+		r := gin.New()
+		r.Use(middleware.Error())
+		r.Use(middleware.Timeout(20 * time.Millisecond))
+		r.GET("/work", h)
+		return r
+	}
+
+	t.Run("should pass a deadline to the handler", func(t *testing.T) {
+		var deadline time.Time
+		w := httptest.NewRecorder()
+		newRouter(func(c *gin.Context) {
+			deadline, _ = c.Request.Context().Deadline()
+			c.Status(http.StatusOK)
+		}).ServeHTTP(w, httptest.NewRequest("GET", "/work", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.WithinDuration(t, time.Now().Add(20*time.Millisecond), deadline, 20*time.Millisecond)
+	})
+
+	t.Run("should replace errors from cancelled work with 504", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(func(c *gin.Context) {
+			<-c.Request.Context().Done()
+			c.Error(c.Request.Context().Err())
+		}).ServeHTTP(w, httptest.NewRequest("GET", "/work", nil))
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.Contains(t, w.Body.String(), "the request took too long to complete")
+	})
+
+	t.Run("should keep responses written before the deadline", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(func(c *gin.Context) {
+			c.Status(http.StatusAccepted)
+			c.Writer.WriteHeaderNow()
+			<-c.Request.Context().Done()
+		}).ServeHTTP(w, httptest.NewRequest("GET", "/work", nil))
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+	})
+
+	t.Run("should not report cancellation by the client as a timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		w := httptest.NewRecorder()
+		newRouter(func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		}).ServeHTTP(w, httptest.NewRequest("GET", "/work", nil).WithContext(ctx))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"strings"
+
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+
+	// ProblemTypeBase prefixes every problem type. Each type resolves to the
+	// matching entry of the error catalog.
+	ProblemTypeBase = "https://github.com/evythrossell/account-management-api/blob/main/ERRORS.md#"
+)
+
+// Problem is an RFC 9457 problem details body. Code, RequestID and Errors
+// are extension members shared with the legacy body.
+type Problem struct {
+	Type      string              `json:"type"`
+	Title     string              `json:"title"`
+	Status    int                 `json:"status"`
+	Detail    string              `json:"detail,omitempty"`
+	Instance  string              `json:"instance,omitempty"`
+	Code      common.Code         `json:"code"`
+	RequestID string              `json:"request_id,omitempty"`
+	Errors    []common.FieldError `json:"errors,omitempty"`
+}
+
+func newProblem(c *gin.Context, status int, code common.Code, title, detail string, fields []common.FieldError) Problem {
+	return Problem{
+		Type:      ProblemTypeBase + strings.ToLower(string(code)),
+		Title:     title,
+		Status:    status,
+		Detail:    detail,
+		Instance:  c.Request.URL.Path,
+		Code:      code,
+		RequestID: common.RequestIDFromContext(c.Request.Context()),
+		Errors:    fields,
+	}
+}
+
+// wantsProblem reports whether the client asked for problem details. Clients
+// that do not mention application/problem+json keep the legacy body.
+func wantsProblem(c *gin.Context) bool {
+	return c.NegotiateFormat(gin.MIMEJSON, ProblemContentType) == ProblemContentType
+}
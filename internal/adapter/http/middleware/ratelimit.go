@@ -0,0 +1,252 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+const maxRateLimitBodyPeek = 64 << 10
+
+// RateLimit is a token bucket refilled at Rate tokens per second and holding
+// at most Burst tokens.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+func (l RateLimit) Enabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+type RateLimitDecision struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+// RateLimitStore keeps the bucket state. The in-memory store is enough for a
+// single replica; a shared store (e.g. Redis) can be plugged in behind this
+// interface when the API is scaled out.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit RateLimit) (RateLimitDecision, error)
+	// Refund puts back a token Take removed from the bucket.
+	Refund(ctx context.Context, key string, limit RateLimit) error
+}
+
+// RateLimitKeyFunc derives the bucket key for a request. Returning false
+// skips the policy for that request.
+type RateLimitKeyFunc func(c *gin.Context) (string, bool)
+
+type RateLimitPolicy struct {
+	Name  string
+	Limit RateLimit
+	Key   RateLimitKeyFunc
+}
+
+// RateLimiter enforces every policy on the request. When any bucket is empty
+// the request is rejected with 429 and Retry-After; the RateLimit-* headers
+// always describe the most constrained bucket. A rejected request gives back
+// the tokens it took from the other buckets, so it costs nothing. Store
+// failures fail open.
+func RateLimiter(store RateLimitStore, policies ...RateLimitPolicy) gin.HandlerFunc {
+	type take struct {
+		key   string
+		limit RateLimit
+	}
+
+	return func(c *gin.Context) {
+		var (
+			tightest *RateLimitDecision
+			taken    []take
+		)
+
+		for _, policy := range policies {
+			if !policy.Limit.Enabled() {
+				continue
+			}
+			key, ok := policy.Key(c)
+			if !ok {
+				continue
+			}
+
+			key = policy.Name + ":" + key
+			decision, err := store.Take(c.Request.Context(), key, policy.Limit)
+			if err != nil {
+				continue
+			}
+			if decision.Allowed {
+				taken = append(taken, take{key: key, limit: policy.Limit})
+			}
+
+			if tightest == nil || !decision.Allowed || (tightest.Allowed && decision.Remaining < tightest.Remaining) {
+				d := decision
+				tightest = &d
+			}
+			if !decision.Allowed {
+				break
+			}
+		}
+
+		if tightest == nil {
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(tightest.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(tightest.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(tightest.ResetAfter)))
+
+		if !tightest.Allowed {
+			for _, t := range taken {
+				_ = store.Refund(c.Request.Context(), t.key, t.limit)
+			}
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(tightest.RetryAfter)))
+			c.Error(common.NewRateLimitError(domain.ErrMsgRateLimited, nil))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ClientKey identifies the API client by the authenticated subject, falling
+// back to the client IP for anonymous requests.
+func ClientKey(c *gin.Context) (string, bool) {
+	if p, ok := domain.PrincipalFromContext(c.Request.Context()); ok {
+		return "client:" + p.Subject, true
+	}
+	return "ip:" + c.ClientIP(), true
+}
+
+// AccountWriteKey keys write requests by the target account, read from the
+// accountId path parameter or the account_id field of the JSON body. Reads
+// and requests without an account are skipped.
+func AccountWriteKey(c *gin.Context) (string, bool) {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return "", false
+	}
+
+	if id := c.Param("accountId"); id != "" {
+		return "account:" + id, true
+	}
+
+	if c.Request.Body == nil {
+		return "", false
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxRateLimitBodyPeek))
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+	if err != nil {
+		return "", false
+	}
+
+	var payload struct {
+		AccountID json.Number `json:"account_id"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.AccountID == "" {
+		return "", false
+	}
+	return "account:" + payload.AccountID.String(), true
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	fullAt time.Time
+}
+
+const sweepEvery = 1024
+
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+	ops     int
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, limit RateLimit) (RateLimitDecision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	burst := float64(limit.Burst)
+
+	s.ops++
+	if s.ops%sweepEvery == 0 {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: burst, last: now}
+		s.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed*limit.Rate)
+		b.last = now
+	}
+
+	decision := RateLimitDecision{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = secondsToDuration((1 - b.tokens) / limit.Rate)
+	}
+
+	decision.Remaining = int(math.Floor(b.tokens))
+	decision.ResetAfter = secondsToDuration((burst - b.tokens) / limit.Rate)
+	b.fullAt = now.Add(decision.ResetAfter)
+	return decision, nil
+}
+
+func (s *MemoryRateLimitStore) Refund(_ context.Context, key string, limit RateLimit) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if b, ok := s.buckets[key]; ok {
+		b.tokens = math.Min(float64(limit.Burst), b.tokens+1)
+	}
+	return nil
+}
+
+// sweep drops buckets that have refilled completely; recreating them later
+// yields the same state.
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if now.After(b.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,218 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(ctx context.Context, key string, limit middleware.RateLimit) (middleware.RateLimitDecision, error) {
+	return middleware.RateLimitDecision{}, errors.New("store unavailable")
+}
+
+func (failingRateLimitStore) Refund(ctx context.Context, key string, limit middleware.RateLimit) error {
+	return errors.New("store unavailable")
+}
+
+func newRateLimitedRouter(store middleware.RateLimitStore, policies ...middleware.RateLimitPolicy) *gin.Engine {
+	r := gin.New()
+	r.Use(middleware.Error())
+	r.Use(middleware.RateLimiter(store, policies...))
+	r.GET("/limited", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.POST("/limited", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusCreated, string(body))
+	})
+	return r
+}
+
+func doRequest(r http.Handler, method, body, remoteAddr string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, "/limited", bytes.NewBufferString(body))
+	req.RemoteAddr = remoteAddr
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimiter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	clientPolicy := middleware.RateLimitPolicy{
+		Name:  "test",
+		Limit: middleware.RateLimit{Rate: 1, Burst: 2},
+		Key:   middleware.ClientKey,
+	}
+
+	t.Run("should allow requests within burst and set headers", func(t *testing.T) {
+		r := newRateLimitedRouter(middleware.NewMemoryRateLimitStore(), clientPolicy)
+
+		w := doRequest(r, "GET", "", "10.0.0.1:1234")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "1", w.Header().Get("RateLimit-Reset"))
+		assert.Empty(t, w.Header().Get("Retry-After"))
+	})
+
+	t.Run("should reject with 429 once bucket is empty", func(t *testing.T) {
+		r := newRateLimitedRouter(middleware.NewMemoryRateLimitStore(), clientPolicy)
+
+		doRequest(r, "GET", "", "10.0.0.1:1234")
+		doRequest(r, "GET", "", "10.0.0.1:1234")
+		w := doRequest(r, "GET", "", "10.0.0.1:1234")
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+		assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+		assert.Contains(t, w.Body.String(), common.CodeRateLimited)
+	})
+
+	t.Run("should keep separate buckets per client IP", func(t *testing.T) {
+		r := newRateLimitedRouter(middleware.NewMemoryRateLimitStore(), clientPolicy)
+
+		doRequest(r, "GET", "", "10.0.0.1:1234")
+		doRequest(r, "GET", "", "10.0.0.1:1234")
+		w := doRequest(r, "GET", "", "10.0.0.2:1234")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should key by authenticated subject", func(t *testing.T) {
+		r := gin.New()
+		r.Use(middleware.Error())
+		r.Use(func(c *gin.Context) {
+			subject := c.GetHeader("X-Test-Subject")
+			c.Request = c.Request.WithContext(domain.ContextWithPrincipal(c.Request.Context(), &domain.Principal{Subject: subject}))
+		})
+		r.Use(middleware.RateLimiter(middleware.NewMemoryRateLimitStore(), middleware.RateLimitPolicy{
+			Name:  "test",
+			Limit: middleware.RateLimit{Rate: 1, Burst: 1},
+			Key:   middleware.ClientKey,
+		}))
+		r.GET("/limited", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		send := func(subject string) int {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/limited", nil)
+			req.Header.Set("X-Test-Subject", subject)
+			r.ServeHTTP(w, req)
+			return w.Code
+		}
+
+		assert.Equal(t, http.StatusOK, send("client-a"))
+		assert.Equal(t, http.StatusTooManyRequests, send("client-a"))
+		assert.Equal(t, http.StatusOK, send("client-b"))
+	})
+
+	t.Run("should refill tokens over time", func(t *testing.T) {
+		r := newRateLimitedRouter(middleware.NewMemoryRateLimitStore(), middleware.RateLimitPolicy{
+			Name:  "test",
+			Limit: middleware.RateLimit{Rate: 100, Burst: 1},
+			Key:   middleware.ClientKey,
+		})
+
+		assert.Equal(t, http.StatusOK, doRequest(r, "GET", "", "10.0.0.1:1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, doRequest(r, "GET", "", "10.0.0.1:1").Code)
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(t, http.StatusOK, doRequest(r, "GET", "", "10.0.0.1:1").Code)
+	})
+
+	t.Run("should limit writes per account and preserve body", func(t *testing.T) {
+		r := newRateLimitedRouter(middleware.NewMemoryRateLimitStore(), middleware.RateLimitPolicy{
+			Name:  "account-writes",
+			Limit: middleware.RateLimit{Rate: 1, Burst: 1},
+			Key:   middleware.AccountWriteKey,
+		})
+
+		body := `{"account_id": 1, "operation_type_id": 4, "amount": 10}`
+		w := doRequest(r, "POST", body, "10.0.0.1:1")
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, body, w.Body.String())
+
+		w = doRequest(r, "POST", body, "10.0.0.2:1")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+		w = doRequest(r, "POST", `{"account_id": 2}`, "10.0.0.1:1")
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		w = doRequest(r, "GET", "", "10.0.0.1:1")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	})
+
+	t.Run("should not spend the client's tokens on requests another policy rejects", func(t *testing.T) {
+		r := newRateLimitedRouter(middleware.NewMemoryRateLimitStore(), clientPolicy, middleware.RateLimitPolicy{
+			Name:  "account-writes",
+			Limit: middleware.RateLimit{Rate: 0.001, Burst: 1},
+			Key:   middleware.AccountWriteKey,
+		})
+
+		body := `{"account_id": 1}`
+		assert.Equal(t, http.StatusCreated, doRequest(r, "POST", body, "10.0.0.1:1").Code)
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, http.StatusTooManyRequests, doRequest(r, "POST", body, "10.0.0.1:1").Code)
+		}
+
+		w := doRequest(r, "POST", `{"account_id": 2}`, "10.0.0.1:1")
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	})
+
+	t.Run("should skip disabled policies", func(t *testing.T) {
+		r := newRateLimitedRouter(middleware.NewMemoryRateLimitStore(), middleware.RateLimitPolicy{
+			Name: "disabled",
+			Key:  middleware.ClientKey,
+		})
+
+		for i := 0; i < 5; i++ {
+			assert.Equal(t, http.StatusOK, doRequest(r, "GET", "", "10.0.0.1:1").Code)
+		}
+	})
+
+	t.Run("should fail open when store errors", func(t *testing.T) {
+		r := newRateLimitedRouter(failingRateLimitStore{}, clientPolicy)
+
+		w := doRequest(r, "GET", "", "10.0.0.1:1")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestMemoryRateLimitStore(t *testing.T) {
+	store := middleware.NewMemoryRateLimitStore()
+	limit := middleware.RateLimit{Rate: 10, Burst: 3}
+	ctx := context.Background()
+
+	for i := 2; i >= 0; i-- {
+		d, err := store.Take(ctx, "k", limit)
+		require.NoError(t, err)
+		assert.True(t, d.Allowed)
+		assert.Equal(t, i, d.Remaining)
+	}
+
+	d, err := store.Take(ctx, "k", limit)
+	require.NoError(t, err)
+	assert.False(t, d.Allowed)
+	assert.Greater(t, d.RetryAfter, time.Duration(0))
+	assert.LessOrEqual(t, d.RetryAfter, 100*time.Millisecond)
+
+	require.NoError(t, store.Refund(ctx, "k", limit))
+	d, err = store.Take(ctx, "k", limit)
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery turns a panic in a handler into the standard 500 error body. It
+// must run after Error so the error it records is rendered.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			common.FromContext(c.Request.Context()).Error("panic recovered",
+				common.String("panic", fmt.Sprint(rec)),
+				common.String("path", c.Request.URL.Path),
+				common.String("stack", string(debug.Stack())),
+			)
+
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			c.Error(common.NewInternalError(domain.ErrMsgUnexpectedError, fmt.Errorf("panic: %v", rec)))
+			c.Abort()
+		}()
+
+		c.Next()
+	}
+}
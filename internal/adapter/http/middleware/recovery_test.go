@@ -0,0 +1,59 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecovery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(log *recordingLogger) *gin.Engine {
+		r := gin.New()
+		r.Use(middleware.RequestID(log))
+		r.Use(middleware.Error())
+		r.Use(middleware.Recovery())
+		r.GET("/panic", func(c *gin.Context) { panic("boom") })
+		r.GET("/ok", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+		return r
+	}
+
+	t.Run("should return standard error body and log stack", func(t *testing.T) {
+		log := &recordingLogger{}
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/panic", nil)
+		req.Header.Set(middleware.RequestIDHeader, "req-1")
+
+		assert.NotPanics(t, func() { newRouter(log).ServeHTTP(w, req) })
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "an unexpected error occurred", body["message"])
+		assert.Equal(t, "req-1", body["request_id"])
+		assert.NotContains(t, w.Body.String(), "boom")
+
+		require.NotEmpty(t, log.lines)
+		assert.Equal(t, "panic recovered", log.lines[0].msg)
+		assert.Equal(t, "boom", log.field(0, "panic"))
+		assert.True(t, strings.Contains(log.field(0, "stack").(string), "goroutine"))
+	})
+
+	t.Run("should pass through when handler does not panic", func(t *testing.T) {
+		log := &recordingLogger{}
+		w := httptest.NewRecorder()
+
+		newRouter(log).ServeHTTP(w, httptest.NewRequest("GET", "/ok", nil))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, log.lines)
+	})
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	RequestIDHeader    = "X-Request-ID"
+	maxRequestIDLength = 128
+)
+
+// RequestID accepts the caller's X-Request-ID (or generates one), echoes it
+// in the response and stores it in the request context together with a
+// logger that tags every line with it.
+func RequestID(base common.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		ctx := common.WithRequestID(c.Request.Context(), id)
+		ctx = common.WithLogger(ctx, base)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// validRequestID rejects IDs that could corrupt log lines or headers.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		ch := id[i]
+		isAlnum := ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z'
+		if !isAlnum && ch != '-' && ch != '_' && ch != '.' && ch != ':' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
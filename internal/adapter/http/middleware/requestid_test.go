@@ -0,0 +1,124 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedLine struct {
+	level  string
+	msg    string
+	fields []common.Field
+}
+
+type recordingLogger struct {
+	root   *recordingLogger
+	fields []common.Field
+	lines  []recordedLine
+}
+
+func (r *recordingLogger) record(level, msg string, fields []common.Field) {
+	root := r
+	if r.root != nil {
+		root = r.root
+	}
+	all := append(append([]common.Field{}, r.fields...), fields...)
+	root.lines = append(root.lines, recordedLine{level: level, msg: msg, fields: all})
+}
+
+func (r *recordingLogger) With(fields ...common.Field) common.Logger {
+	root := r
+	if r.root != nil {
+		root = r.root
+	}
+	return &recordingLogger{root: root, fields: append(append([]common.Field{}, r.fields...), fields...)}
+}
+
+func (r *recordingLogger) Debug(msg string, fields ...common.Field) { r.record("debug", msg, fields) }
+func (r *recordingLogger) Info(msg string, fields ...common.Field)  { r.record("info", msg, fields) }
+func (r *recordingLogger) Warn(msg string, fields ...common.Field)  { r.record("warn", msg, fields) }
+func (r *recordingLogger) Error(msg string, fields ...common.Field) { r.record("error", msg, fields) }
+func (r *recordingLogger) Fatal(msg string, fields ...common.Field) { r.record("fatal", msg, fields) }
+
+func (r *recordingLogger) field(line int, key string) interface{} {
+	for _, f := range r.lines[line].fields {
+		if f.Key == key {
+			return f.Value
+		}
+	}
+	return nil
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(log common.Logger) *gin.Engine {
+		r := gin.New()
+		r.Use(middleware.RequestID(log))
+		r.Use(middleware.Error())
+		r.GET("/ok", func(c *gin.Context) {
+			common.FromContext(c.Request.Context()).Info("handled")
+			c.String(http.StatusOK, common.RequestIDFromContext(c.Request.Context()))
+		})
+		r.GET("/fail", func(c *gin.Context) {
+			c.Error(common.NewInternalError("failed to save account", assert.AnError))
+		})
+		return r
+	}
+
+	t.Run("should propagate incoming request ID", func(t *testing.T) {
+		log := &recordingLogger{}
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/ok", nil)
+		req.Header.Set(middleware.RequestIDHeader, "abc-123")
+		newRouter(log).ServeHTTP(w, req)
+
+		assert.Equal(t, "abc-123", w.Header().Get(middleware.RequestIDHeader))
+		assert.Equal(t, "abc-123", w.Body.String())
+		assert.Equal(t, "abc-123", log.field(0, "request_id"))
+	})
+
+	t.Run("should generate request ID when missing", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(&recordingLogger{}).ServeHTTP(w, httptest.NewRequest("GET", "/ok", nil))
+
+		id := w.Header().Get(middleware.RequestIDHeader)
+		assert.Regexp(t, uuidPattern, id)
+		assert.Equal(t, id, w.Body.String())
+	})
+
+	t.Run("should replace unsafe request IDs", func(t *testing.T) {
+		for _, bad := range []string{"bad id\nINJECTED", strings.Repeat("a", 200), "<script>"} {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/ok", nil)
+			req.Header.Set(middleware.RequestIDHeader, bad)
+			newRouter(&recordingLogger{}).ServeHTTP(w, req)
+
+			assert.Regexp(t, uuidPattern, w.Header().Get(middleware.RequestIDHeader))
+		}
+	})
+
+	t.Run("should include request ID in error body and log", func(t *testing.T) {
+		log := &recordingLogger{}
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/fail", nil)
+		req.Header.Set(middleware.RequestIDHeader, "req-500")
+		newRouter(log).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), `"request_id":"req-500"`)
+		assert.Len(t, log.lines, 1)
+		assert.Equal(t, "request failed", log.lines[0].msg)
+		assert.Equal(t, "req-500", log.field(0, "request_id"))
+	})
+}
@@ -0,0 +1,238 @@
+package tlsconfig_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/http/tlsconfig"
+	logger "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type certificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func (c certificate) keyPEM(t *testing.T) []byte {
+	t.Helper()
+	der, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func (c certificate) tls(t *testing.T) tls.Certificate {
+	t.Helper()
+	pair, err := tls.X509KeyPair(c.pem, c.keyPEM(t))
+	require.NoError(t, err)
+	return pair
+}
+
+// issue creates a certificate signed by parent, or self-signed when parent
+// is nil.
+func issue(t *testing.T, cn string, parent *certificate, usage x509.ExtKeyUsage) certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn, Organization: []string{"Test"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return certificate{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+type fixture struct {
+	ca       certificate
+	certFile string
+	keyFile  string
+	caFile   string
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	dir := t.TempDir()
+	f := &fixture{
+		ca:       issue(t, "test-ca", nil, x509.ExtKeyUsageAny),
+		certFile: filepath.Join(dir, "server.crt"),
+		keyFile:  filepath.Join(dir, "server.key"),
+		caFile:   filepath.Join(dir, "ca.crt"),
+	}
+	require.NoError(t, os.WriteFile(f.caFile, f.ca.pem, 0o600))
+	f.writeServerCert(t, "server-1")
+	return f
+}
+
+func (f *fixture) writeServerCert(t *testing.T, cn string) {
+	t.Helper()
+	server := issue(t, cn, &f.ca, x509.ExtKeyUsageServerAuth)
+	require.NoError(t, os.WriteFile(f.certFile, server.pem, 0o600))
+	require.NoError(t, os.WriteFile(f.keyFile, server.keyPEM(t), 0o600))
+}
+
+// serve runs an HTTPS server that echoes the verified client common name.
+func serve(t *testing.T, cfg *tls.Config) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := &http.Server{
+		TLSConfig: cfg,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(r.TLS.VerifiedChains) > 0 {
+				w.Header().Set("X-Client", r.TLS.VerifiedChains[0][0].Subject.CommonName)
+			}
+			w.WriteHeader(http.StatusOK)
+		}),
+	}
+	go func() { _ = srv.ServeTLS(listener, "", "") }()
+	t.Cleanup(func() { _ = srv.Close() })
+	return "https://" + listener.Addr().String()
+}
+
+func (f *fixture) client(certs ...tls.Certificate) *http.Client {
+	roots := x509.NewCertPool()
+	roots.AddCert(f.ca.cert)
+	return &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{RootCAs: roots, Certificates: certs},
+			ForceAttemptHTTP2: true,
+			DisableKeepAlives: true,
+		},
+	}
+}
+
+func get(t *testing.T, client *http.Client, url string) (*http.Response, error) {
+	t.Helper()
+	resp, err := client.Get(url)
+	if err == nil {
+		resp.Body.Close()
+	}
+	return resp, err
+}
+
+func TestReloader(t *testing.T) {
+	t.Run("should serve HTTP/2 over TLS", func(t *testing.T) {
+		f := newFixture(t)
+		r, err := tlsconfig.NewReloader(tlsconfig.Config{CertFile: f.certFile, KeyFile: f.keyFile}, logger.NewNoOpLogger())
+		require.NoError(t, err)
+
+		resp, err := get(t, f.client(), serve(t, r.TLSConfig()))
+
+		require.NoError(t, err)
+		assert.Equal(t, "HTTP/2.0", resp.Proto)
+		assert.Equal(t, "server-1", resp.TLS.PeerCertificates[0].Subject.CommonName)
+	})
+
+	t.Run("should require a client certificate signed by the CA", func(t *testing.T) {
+		f := newFixture(t)
+		r, err := tlsconfig.NewReloader(tlsconfig.Config{
+			CertFile:     f.certFile,
+			KeyFile:      f.keyFile,
+			ClientCAFile: f.caFile,
+			ClientAuth:   tlsconfig.ClientAuthRequire,
+		}, logger.NewNoOpLogger())
+		require.NoError(t, err)
+		url := serve(t, r.TLSConfig())
+
+		_, err = get(t, f.client(), url)
+		assert.Error(t, err)
+
+		stranger := issue(t, "stranger", nil, x509.ExtKeyUsageClientAuth)
+		_, err = get(t, f.client(stranger.tls(t)), url)
+		assert.Error(t, err)
+
+		billing := issue(t, "billing", &f.ca, x509.ExtKeyUsageClientAuth)
+		resp, err := get(t, f.client(billing.tls(t)), url)
+		require.NoError(t, err)
+		assert.Equal(t, "billing", resp.Header.Get("X-Client"))
+	})
+
+	t.Run("should pick up a rotated certificate", func(t *testing.T) {
+		f := newFixture(t)
+		r, err := tlsconfig.NewReloader(tlsconfig.Config{
+			CertFile:       f.certFile,
+			KeyFile:        f.keyFile,
+			ReloadInterval: 10 * time.Millisecond,
+		}, logger.NewNoOpLogger())
+		require.NoError(t, err)
+		url := serve(t, r.TLSConfig())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go r.Run(ctx)
+
+		f.writeServerCert(t, "server-2")
+		future := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(f.certFile, future, future))
+		require.NoError(t, os.Chtimes(f.keyFile, future, future))
+
+		assert.Eventually(t, func() bool {
+			resp, err := get(t, f.client(), url)
+			return err == nil && resp.TLS.PeerCertificates[0].Subject.CommonName == "server-2"
+		}, 2*time.Second, 20*time.Millisecond)
+	})
+
+	t.Run("should keep the previous certificate when reload fails", func(t *testing.T) {
+		f := newFixture(t)
+		r, err := tlsconfig.NewReloader(tlsconfig.Config{CertFile: f.certFile, KeyFile: f.keyFile}, logger.NewNoOpLogger())
+		require.NoError(t, err)
+		url := serve(t, r.TLSConfig())
+
+		require.NoError(t, os.WriteFile(f.certFile, []byte("not a certificate"), 0o600))
+
+		assert.Error(t, r.Reload())
+		resp, err := get(t, f.client(), url)
+		require.NoError(t, err)
+		assert.Equal(t, "server-1", resp.TLS.PeerCertificates[0].Subject.CommonName)
+	})
+
+	t.Run("should reject invalid configuration", func(t *testing.T) {
+		f := newFixture(t)
+
+		_, err := tlsconfig.NewReloader(tlsconfig.Config{CertFile: f.certFile, KeyFile: f.keyFile, ClientAuth: tlsconfig.ClientAuthRequire}, nil)
+		assert.Error(t, err)
+
+		_, err = tlsconfig.NewReloader(tlsconfig.Config{CertFile: f.certFile, KeyFile: f.keyFile, ClientAuth: "sometimes"}, nil)
+		assert.Error(t, err)
+
+		_, err = tlsconfig.NewReloader(tlsconfig.Config{CertFile: f.certFile, KeyFile: f.caFile + ".missing"}, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,72 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+var requiredColumns = []string{"account_id", "operation_type_id", "amount"}
+
+// decodeCSV locates columns by the header's names, so partners may order
+// them freely and add columns of their own. event_date and currency are
+// optional.
+func decodeCSV(r io.Reader) ([]domain.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", common.ErrInvalidImportFile, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+	for _, name := range requiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", common.ErrInvalidImportFile, name)
+		}
+	}
+	value := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	var rows []domain.ImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, malformed(parseErr.StartLine))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", common.ErrInvalidImportFile, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, fields{
+			accountID:       value(record, "account_id"),
+			operationTypeID: value(record, "operation_type_id"),
+			amount:          value(record, "amount"),
+			eventDate:       value(record, "event_date"),
+			currency:        value(record, "currency"),
+		}.row(line))
+	}
+}
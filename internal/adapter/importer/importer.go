@@ -0,0 +1,114 @@
+// Package importer reads batch files of transactions. Lines that cannot be
+// parsed are returned with a Problem instead of failing the whole file, so
+// they show up in the import report next to the lines that could.
+package importer
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+type Format string
+
+const (
+	CSV       Format = "csv"
+	JSONLines Format = "jsonl"
+)
+
+var contentTypes = map[string]Format{
+	"text/csv":             CSV,
+	"application/x-ndjson": JSONLines,
+	"application/jsonl":    JSONLines,
+}
+
+// Formats lists the supported formats, comma separated.
+func Formats() string {
+	return strings.Join([]string{string(CSV), string(JSONLines)}, ", ")
+}
+
+// Detect resolves the format named by the caller or, failing that, the one
+// implied by the request's content type. CSV is the default.
+func Detect(name, contentType string) (Format, error) {
+	switch f := Format(strings.ToLower(name)); f {
+	case CSV, JSONLines:
+		return f, nil
+	case "":
+	default:
+		return "", fmt.Errorf("%w: %q", common.ErrInvalidImportFile, name)
+	}
+
+	if media, _, err := mime.ParseMediaType(contentType); err == nil {
+		if f, ok := contentTypes[media]; ok {
+			return f, nil
+		}
+	}
+	return CSV, nil
+}
+
+// Decode reads every row of r. It fails only when the file as a whole is
+// unusable, such as a CSV header missing a required column.
+func Decode(f Format, r io.Reader) ([]domain.ImportRow, error) {
+	switch f {
+	case CSV:
+		return decodeCSV(r)
+	case JSONLines:
+		return decodeJSONLines(r)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", common.ErrInvalidImportFile, f)
+	}
+}
+
+// fields holds one line's raw values before they are parsed.
+type fields struct {
+	accountID       string
+	operationTypeID string
+	amount          string
+	eventDate       string
+	currency        string
+}
+
+func (f fields) row(line int) domain.ImportRow {
+	row := domain.ImportRow{Line: line}
+
+	accountID, err := strconv.ParseInt(strings.TrimSpace(f.accountID), 10, 64)
+	if err != nil {
+		return malformed(line)
+	}
+	operationTypeID, err := strconv.ParseInt(strings.TrimSpace(f.operationTypeID), 10, 16)
+	if err != nil {
+		return malformed(line)
+	}
+	amount, err := strconv.ParseFloat(strings.TrimSpace(f.amount), 64)
+	if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return malformed(line)
+	}
+	row.AccountID, row.OperationTypeID, row.Amount = accountID, int16(operationTypeID), amount
+	// The currency is checked against the rates when the line is imported.
+	row.Currency = domain.Currency(strings.TrimSpace(f.currency))
+
+	if date := strings.TrimSpace(f.eventDate); date != "" {
+		if row.EventDate, err = parseDate(date); err != nil {
+			return malformed(line)
+		}
+	}
+	return row
+}
+
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+func malformed(line int) domain.ImportRow {
+	return domain.ImportRow{Line: line, Problem: domain.ErrMsgImportLineMalformed}
+}
@@ -0,0 +1,96 @@
+package importer_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/importer"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		contentType string
+		want        importer.Format
+	}{
+		{"Success - Explicit format wins", "jsonl", "text/csv", importer.JSONLines},
+		{"Success - From content type", "", "application/x-ndjson; charset=utf-8", importer.JSONLines},
+		{"Success - Defaults to CSV", "", "application/octet-stream", importer.CSV},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := importer.Detect(tt.format, tt.contentType)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, f)
+		})
+	}
+
+	t.Run("Error - Unsupported format", func(t *testing.T) {
+		_, err := importer.Detect("xlsx", "")
+		assert.ErrorIs(t, err, common.ErrInvalidImportFile)
+	})
+}
+
+func TestDecodeCSV(t *testing.T) {
+	t.Run("Success - Columns in any order", func(t *testing.T) {
+		file := "\ufeffamount,Account_ID,operation_type_id,event_date,note,currency\n" +
+			"50.25,1,1,2026-03-01,lunch, usd\n" +
+			"\n" +
+			"100,2,4,,,\n"
+
+		rows, err := importer.Decode(importer.CSV, strings.NewReader(file))
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.ImportRow{
+			{Line: 2, AccountID: 1, OperationTypeID: 1, Amount: 50.25, EventDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Currency: "usd"},
+			{Line: 4, AccountID: 2, OperationTypeID: 4, Amount: 100},
+		}, rows)
+	})
+
+	t.Run("Success - Malformed lines are reported", func(t *testing.T) {
+		file := "account_id,operation_type_id,amount\n" +
+			"x,1,10\n" +
+			"1,1\n" +
+			"1,1,NaN\n" +
+			"1,1,\"10\n"
+
+		rows, err := importer.Decode(importer.CSV, strings.NewReader(file))
+
+		require.NoError(t, err)
+		require.Len(t, rows, 4)
+		for _, row := range rows {
+			assert.Equal(t, domain.ErrMsgImportLineMalformed, row.Problem, row.Line)
+		}
+		assert.Equal(t, 2, rows[0].Line)
+	})
+
+	t.Run("Error - Missing required column", func(t *testing.T) {
+		_, err := importer.Decode(importer.CSV, strings.NewReader("account_id,amount\n1,10\n"))
+		assert.ErrorIs(t, err, common.ErrInvalidImportFile)
+	})
+}
+
+func TestDecodeJSONLines(t *testing.T) {
+	file := `{"account_id": 1, "operation_type_id": 1, "amount": 50.25, "currency": "EUR"}` + "\n" +
+		"\n" +
+		`{"account_id": "2", "operation_type_id": 4, "amount": "100", "event_date": "2026-03-01T10:00:00Z"}` + "\n" +
+		`{"account_id": 1, "amount": 5}` + "\n" +
+		`not json` + "\n"
+
+	rows, err := importer.Decode(importer.JSONLines, strings.NewReader(file))
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ImportRow{
+		{Line: 1, AccountID: 1, OperationTypeID: 1, Amount: 50.25, Currency: "EUR"},
+		{Line: 3, AccountID: 2, OperationTypeID: 4, Amount: 100, EventDate: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{Line: 4, Problem: domain.ErrMsgImportLineMalformed},
+		{Line: 5, Problem: domain.ErrMsgImportLineMalformed},
+	}, rows)
+}
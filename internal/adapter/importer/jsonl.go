@@ -0,0 +1,55 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+// maxJSONLine is far above any real transaction line; longer lines mean the
+// file is not JSON Lines at all.
+const maxJSONLine = 64 * 1024
+
+// jsonLine takes numbers as JSON numbers or strings, like the CSV columns.
+type jsonLine struct {
+	AccountID       json.Number `json:"account_id"`
+	OperationTypeID json.Number `json:"operation_type_id"`
+	Amount          json.Number `json:"amount"`
+	EventDate       string      `json:"event_date"`
+	Currency        string      `json:"currency"`
+}
+
+func decodeJSONLines(r io.Reader) ([]domain.ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxJSONLine)
+
+	var rows []domain.ImportRow
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var l jsonLine
+		if err := json.Unmarshal(text, &l); err != nil {
+			rows = append(rows, malformed(line))
+			continue
+		}
+		rows = append(rows, fields{
+			accountID:       l.AccountID.String(),
+			operationTypeID: l.OperationTypeID.String(),
+			amount:          l.Amount.String(),
+			eventDate:       l.EventDate,
+			currency:        l.Currency,
+		}.row(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", common.ErrInvalidImportFile, err)
+	}
+	return rows, nil
+}
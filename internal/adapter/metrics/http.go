@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	unmatchedRoute = "unmatched"
+	otherMethod    = "other"
+)
+
+// standardMethods are the methods kept as label values; net/http accepts
+// any token as a method, so the rest share otherMethod.
+var standardMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+}
+
+// Middleware records RED metrics per route template. Unmatched paths and
+// non-standard methods each share a single label value to keep cardinality
+// bounded. It must run before Error and
+// Recovery, which write the status of failed requests. A handler that drops
+// the connection with http.ErrAbortHandler is counted as a 500, since the
+// client never got a complete response.
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		defer func() {
+			status := c.Writer.Status()
+			rec := recover()
+			if rec != nil {
+				status = http.StatusInternalServerError
+			}
+			m.observeRequest(c, status, time.Since(start))
+			if rec != nil {
+				panic(rec)
+			}
+		}()
+		c.Next()
+	}
+}
+
+func (m *Metrics) observeRequest(c *gin.Context, status int, latency time.Duration) {
+	route := c.FullPath()
+	if route == "" {
+		route = unmatchedRoute
+	}
+	method := c.Request.Method
+	if !standardMethods[method] {
+		method = otherMethod
+	}
+
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(latency.Seconds())
+
+	switch {
+	case status >= http.StatusInternalServerError:
+		m.httpErrors.WithLabelValues(method, route, "5xx").Inc()
+	case status >= http.StatusBadRequest:
+		m.httpErrors.WithLabelValues(method, route, "4xx").Inc()
+	}
+}
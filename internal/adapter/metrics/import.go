@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+)
+
+type importService struct {
+	next    port.ImportService
+	metrics *Metrics
+}
+
+// NewImportService counts the transactions that imports create, whether
+// right away or in a background job, like NewTransactionService counts the
+// ones created one at a time.
+func NewImportService(next port.ImportService, m *Metrics) port.ImportService {
+	return &importService{next: next, metrics: m}
+}
+
+func (s *importService) Import(ctx context.Context, mode domain.ImportMode, rows []domain.ImportRow) (*domain.ImportReport, error) {
+	report, err := s.next.Import(ctx, mode, rows)
+	if err != nil {
+		return report, err
+	}
+	s.countReport(report)
+	return report, nil
+}
+
+func (s *importService) Submit(ctx context.Context, mode domain.ImportMode, rows []domain.ImportRow) (*domain.ImportJob, error) {
+	return s.next.Submit(ctx, mode, rows)
+}
+
+func (s *importService) GetJob(ctx context.Context, jobID int64) (*domain.ImportJob, error) {
+	return s.next.GetJob(ctx, jobID)
+}
+
+// RunPending counts the transactions of every job that ran, including
+// interrupted jobs, whose report still lists the lines saved before they
+// stopped.
+func (s *importService) RunPending(ctx context.Context) ([]*domain.ImportJob, error) {
+	jobs, err := s.next.RunPending(ctx)
+	for _, job := range jobs {
+		s.countReport(job.Report)
+	}
+	return jobs, err
+}
+
+func (s *importService) countReport(report *domain.ImportReport) {
+	if report == nil {
+		return
+	}
+	for _, tx := range report.Transactions {
+		s.metrics.countCreated(tx)
+	}
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics owns a private registry so several instances (e.g. in tests) never
+// collide on the global default registry.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	httpErrors   *prometheus.CounterVec
+
+	repositoryDuration *prometheus.HistogramVec
+
+	transactionsCreated *prometheus.CounterVec
+	amountPosted        *prometheus.CounterVec
+
+	balanceDiscrepancies prometheus.Counter
+	balancesRepaired     prometheus.Counter
+	reconciliationRuns   *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests served, by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency, by method and route template.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		httpErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_request_errors_total",
+			Help: "HTTP requests answered with a 4xx or 5xx status, by method, route template and status class.",
+		}, []string{"method", "route", "class"}),
+		repositoryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "repository_query_duration_seconds",
+			Help:    "Repository call latency, by repository, method and outcome.",
+			Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"repository", "method", "outcome"}),
+		transactionsCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "transactions_created_total",
+			Help: "Transactions created, by operation type.",
+		}, []string{"operation_type"}),
+		amountPosted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "transactions_amount_posted_total",
+			Help: "Absolute amount posted by created transactions, by operation type and booked currency.",
+		}, []string{"operation_type", "currency"}),
+		balanceDiscrepancies: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "balance_discrepancies_total",
+			Help: "Stored balances found to differ from the sum of their transactions.",
+		}),
+		balancesRepaired: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "balance_repairs_total",
+			Help: "Drifted balances corrected by reconciliation.",
+		}),
+		reconciliationRuns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "balance_reconciliation_runs_total",
+			Help: "Balance reconciliation runs, by outcome.",
+		}, []string{"outcome"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.httpErrors,
+		m.repositoryDuration,
+		m.transactionsCreated,
+		m.amountPosted,
+		m.balanceDiscrepancies,
+		m.balancesRepaired,
+		m.reconciliationRuns,
+	)
+
+	return m
+}
+
+// RegisterDB exports the connection pool statistics of db as go_sql_*
+// metrics labelled with dbName.
+func (m *Metrics) RegisterDB(db *sql.DB, dbName string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, dbName))
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
@@ -0,0 +1,361 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/evythrossell/account-management-api/internal/adapter/http/middleware"
+	"github.com/evythrossell/account-management-api/internal/adapter/metrics"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockAccountRepository struct{ mock.Mock }
+
+func (m *MockAccountRepository) Save(ctx context.Context, acc *domain.Account, owner string) (*domain.Account, error) {
+	args := m.Called(ctx, acc, owner)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) SaveBatch(ctx context.Context, accounts []*domain.Account, owner string) ([]bool, error) {
+	args := m.Called(ctx, accounts, owner)
+	created, _ := args.Get(0).([]bool)
+	return created, args.Error(1)
+}
+
+func (m *MockAccountRepository) FindByDocument(ctx context.Context, doc string) (*domain.Account, error) {
+	args := m.Called(ctx, doc)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) FindByAccountID(ctx context.Context, id int64) (*domain.Account, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Account), args.Error(1)
+}
+
+type MockTransactionService struct{ mock.Mock }
+
+func (m *MockTransactionService) CreateTransaction(ctx context.Context, accountID int64, op int16, amount float64, currency domain.Currency) (*domain.Transaction, error) {
+	args := m.Called(ctx, accountID, op, amount, currency)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionService) GetByTransactionID(ctx context.Context, id int64) (*domain.Transaction, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionService) ExportTransactions(
+	ctx context.Context,
+	accountID int64,
+	from, to time.Time,
+	start func(*domain.Account) error,
+	fn func(*domain.Transaction) error,
+) error {
+	return m.Called(ctx, accountID, from, to).Error(0)
+}
+
+func (m *MockTransactionService) GetBalances(ctx context.Context, accountID int64) (*domain.AccountBalances, error) {
+	args := m.Called(ctx, accountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AccountBalances), args.Error(1)
+}
+
+type MockTransactionRepository struct {
+	port.TransactionRepository
+	mock.Mock
+}
+
+func (m *MockTransactionRepository) SaveBatch(ctx context.Context, transactions []*domain.Transaction) error {
+	return m.Called(ctx, transactions).Error(0)
+}
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+	srv := httptest.NewServer(m.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m := metrics.New()
+	r := gin.New()
+	r.Use(m.Middleware())
+	r.GET("/v1/accounts/:accountId", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/v1/fail", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+
+	for _, path := range []string{"/v1/accounts/1", "/v1/accounts/2", "/v1/fail", "/nope"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `http_requests_total{method="GET",route="/v1/accounts/:accountId",status="200"} 2`)
+	assert.Contains(t, out, `http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, out, `http_request_duration_seconds_count{method="GET",route="/v1/accounts/:accountId"} 2`)
+	assert.Contains(t, out, `http_request_errors_total{class="5xx",method="GET",route="/v1/fail"} 1`)
+	assert.Contains(t, out, `http_request_errors_total{class="4xx",method="GET",route="unmatched"} 1`)
+	assert.Contains(t, out, "go_goroutines")
+
+}
+
+func TestMiddlewareCustomMethods(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m := metrics.New()
+	r := gin.New()
+	r.Use(m.Middleware())
+	r.GET("/v1/accounts/:accountId", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, method := range []string{"FOO", "BAR", "PROPFIND"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/nope", nil))
+	}
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("FOO", "/v1/accounts/1", nil))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("DELETE", "/nope", nil))
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `http_requests_total{method="other",route="unmatched",status="404"} 4`)
+	assert.Contains(t, out, `http_request_duration_seconds_count{method="other",route="unmatched"} 4`)
+	assert.Contains(t, out, `http_request_errors_total{class="4xx",method="other",route="unmatched"} 4`)
+	assert.Contains(t, out, `http_requests_total{method="DELETE",route="unmatched",status="404"} 1`)
+	assert.NotContains(t, out, `method="FOO"`)
+	assert.NotContains(t, out, `method="PROPFIND"`)
+}
+
+func TestMiddlewareRenderedErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m := metrics.New()
+	r := gin.New()
+	r.Use(m.Middleware(), middleware.Error(), middleware.Recovery())
+	r.GET("/v1/accounts/:accountId", func(c *gin.Context) {
+		c.Error(common.NewNotFoundError(domain.ErrMsgAccountNotFound, common.ErrAccountNotFound))
+	})
+	r.GET("/v1/panic", func(c *gin.Context) { panic("boom") })
+	r.GET("/v1/abort", func(c *gin.Context) {
+		c.String(http.StatusOK, "partial")
+		panic(http.ErrAbortHandler)
+	})
+
+	for _, path := range []string{"/v1/accounts/1", "/v1/panic"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		require.NotEqual(t, http.StatusOK, w.Code)
+	}
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/v1/abort", nil))
+	})
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `http_requests_total{method="GET",route="/v1/accounts/:accountId",status="404"} 1`)
+	assert.Contains(t, out, `http_request_errors_total{class="4xx",method="GET",route="/v1/accounts/:accountId"} 1`)
+	assert.Contains(t, out, `http_requests_total{method="GET",route="/v1/panic",status="500"} 1`)
+	assert.Contains(t, out, `http_request_errors_total{class="5xx",method="GET",route="/v1/panic"} 1`)
+	assert.Contains(t, out, `http_requests_total{method="GET",route="/v1/abort",status="500"} 1`)
+	assert.Contains(t, out, `http_request_errors_total{class="5xx",method="GET",route="/v1/abort"} 1`)
+}
+
+func TestRegisterDB(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	m := metrics.New()
+	m.RegisterDB(db, "accounts")
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `go_sql_open_connections{db_name="accounts"}`)
+	assert.Contains(t, out, `go_sql_max_open_connections{db_name="accounts"}`)
+	assert.Contains(t, out, `go_sql_wait_count_total{db_name="accounts"}`)
+}
+
+func TestAccountRepository(t *testing.T) {
+	m := metrics.New()
+	repo := new(MockAccountRepository)
+	repo.On("FindByAccountID", mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil)
+	repo.On("FindByAccountID", mock.Anything, int64(2)).Return(nil, errors.New("db down"))
+
+	instrumented := metrics.NewAccountRepository(repo, m)
+
+	acc, err := instrumented.FindByAccountID(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), acc.ID)
+	_, err = instrumented.FindByAccountID(context.Background(), 2)
+	assert.EqualError(t, err, "db down")
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `repository_query_duration_seconds_count{method="FindByAccountID",outcome="success",repository="account"} 1`)
+	assert.Contains(t, out, `repository_query_duration_seconds_count{method="FindByAccountID",outcome="error",repository="account"} 1`)
+}
+
+func TestTransactionRepositorySaveBatch(t *testing.T) {
+	m := metrics.New()
+	repo := new(MockTransactionRepository)
+	saved := []*domain.Transaction{
+		{OperationTypeID: domain.Purchase, Amount: -50, Currency: "BRL"},
+		{OperationTypeID: domain.Payment, Amount: 20.5, Currency: "BRL"},
+	}
+	failed := []*domain.Transaction{{OperationTypeID: domain.Purchase, Amount: -10}}
+	repo.On("SaveBatch", mock.Anything, saved).Return(nil)
+	repo.On("SaveBatch", mock.Anything, failed).Return(errors.New("db down"))
+
+	instrumented := metrics.NewTransactionRepository(repo, m)
+	assert.NoError(t, instrumented.SaveBatch(context.Background(), saved))
+	assert.Error(t, instrumented.SaveBatch(context.Background(), failed))
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `repository_query_duration_seconds_count{method="SaveBatch",outcome="success",repository="transaction"} 1`)
+	assert.Contains(t, out, `repository_query_duration_seconds_count{method="SaveBatch",outcome="error",repository="transaction"} 1`)
+	assert.NotContains(t, out, `transactions_created_total{`)
+}
+
+type MockImportService struct {
+	port.ImportService
+	mock.Mock
+}
+
+func (m *MockImportService) Import(ctx context.Context, mode domain.ImportMode, rows []domain.ImportRow) (*domain.ImportReport, error) {
+	args := m.Called(ctx, mode, rows)
+	report, _ := args.Get(0).(*domain.ImportReport)
+	return report, args.Error(1)
+}
+
+func (m *MockImportService) RunPending(ctx context.Context) ([]*domain.ImportJob, error) {
+	args := m.Called(ctx)
+	jobs, _ := args.Get(0).([]*domain.ImportJob)
+	return jobs, args.Error(1)
+}
+
+func TestImportService(t *testing.T) {
+	m := metrics.New()
+	ctx := context.Background()
+	svc := new(MockImportService)
+	svc.On("Import", ctx, domain.ImportBestEffort, mock.Anything).Return(&domain.ImportReport{Created: 2, Transactions: []*domain.Transaction{
+		{OperationTypeID: domain.Purchase, Amount: -50, Currency: "BRL"},
+		{OperationTypeID: domain.Payment, Amount: 20.5, Currency: "USD"},
+	}}, nil).Once()
+	svc.On("Import", ctx, domain.ImportAllOrNothing, mock.Anything).Return(nil, errors.New("db down")).Once()
+	svc.On("RunPending", ctx).Return([]*domain.ImportJob{
+		{ID: 1, Status: domain.ImportSucceeded, Report: &domain.ImportReport{Created: 1, Transactions: []*domain.Transaction{
+			{OperationTypeID: domain.Purchase, Amount: -30, Currency: "BRL"},
+		}}},
+		{ID: 2, Status: domain.ImportFailed},
+	}, nil).Once()
+
+	instrumented := metrics.NewImportService(svc, m)
+
+	_, err := instrumented.Import(ctx, domain.ImportBestEffort, nil)
+	assert.NoError(t, err)
+	_, err = instrumented.Import(ctx, domain.ImportAllOrNothing, nil)
+	assert.Error(t, err)
+	jobs, err := instrumented.RunPending(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `transactions_created_total{operation_type="purchase"} 2`)
+	assert.Contains(t, out, `transactions_created_total{operation_type="payment"} 1`)
+	assert.Contains(t, out, `transactions_amount_posted_total{currency="BRL",operation_type="purchase"} 80`)
+	assert.Contains(t, out, `transactions_amount_posted_total{currency="USD",operation_type="payment"} 20.5`)
+	svc.AssertExpectations(t)
+}
+
+func TestTransactionService(t *testing.T) {
+	m := metrics.New()
+	svc := new(MockTransactionService)
+	svc.On("CreateTransaction", mock.Anything, int64(1), int16(1), 50.0, domain.Currency("")).
+		Return(&domain.Transaction{ID: 1, OperationTypeID: domain.Purchase, Amount: -50, Currency: "BRL"}, nil)
+	svc.On("CreateTransaction", mock.Anything, int64(2), int16(4), 20.5, domain.Currency("")).
+		Return(&domain.Transaction{ID: 2, OperationTypeID: domain.Payment, Amount: 20.5, Currency: "USD"}, nil)
+	svc.On("CreateTransaction", mock.Anything, int64(1), int16(4), -1.0, domain.Currency("")).
+		Return(nil, errors.New("invalid amount"))
+
+	instrumented := metrics.NewTransactionService(svc, m)
+	ctx := context.Background()
+
+	_, err := instrumented.CreateTransaction(ctx, 1, 1, 50, "")
+	assert.NoError(t, err)
+	_, err = instrumented.CreateTransaction(ctx, 1, 1, 50, "")
+	assert.NoError(t, err)
+	_, err = instrumented.CreateTransaction(ctx, 2, 4, 20.5, "")
+	assert.NoError(t, err)
+	_, err = instrumented.CreateTransaction(ctx, 1, 4, -1, "")
+	assert.Error(t, err)
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `transactions_created_total{operation_type="purchase"} 2`)
+	assert.Contains(t, out, `transactions_created_total{operation_type="payment"} 1`)
+	assert.Contains(t, out, `transactions_amount_posted_total{currency="BRL",operation_type="purchase"} 100`)
+	assert.Contains(t, out, `transactions_amount_posted_total{currency="USD",operation_type="payment"} 20.5`)
+	assert.False(t, strings.Contains(out, `operation_type="unknown"`))
+}
+
+type reconcileFunc func(ctx context.Context, repair bool) (*domain.ReconciliationReport, error)
+
+func (f reconcileFunc) Reconcile(ctx context.Context, repair bool) (*domain.ReconciliationReport, error) {
+	return f(ctx, repair)
+}
+
+func TestReconciliationService(t *testing.T) {
+	m := metrics.New()
+	ctx := context.Background()
+
+	report := &domain.ReconciliationReport{Repaired: 1}
+	report.Add(domain.BalanceCheck{AccountID: 1, Stored: 10})
+	report.Add(domain.BalanceCheck{AccountID: 2, Stored: 20})
+	ok := metrics.NewReconciliationService(reconcileFunc(func(context.Context, bool) (*domain.ReconciliationReport, error) {
+		return report, nil
+	}), m)
+	failing := metrics.NewReconciliationService(reconcileFunc(func(context.Context, bool) (*domain.ReconciliationReport, error) {
+		return nil, errors.New("connection reset")
+	}), m)
+
+	_, err := ok.Reconcile(ctx, true)
+	assert.NoError(t, err)
+	_, err = failing.Reconcile(ctx, true)
+	assert.Error(t, err)
+
+	out := scrape(t, m)
+	assert.Contains(t, out, "balance_discrepancies_total 2")
+	assert.Contains(t, out, "balance_repairs_total 1")
+	assert.Contains(t, out, `balance_reconciliation_runs_total{outcome="success"} 1`)
+	assert.Contains(t, out, `balance_reconciliation_runs_total{outcome="error"} 1`)
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+)
+
+type reconciliationService struct {
+	next    port.ReconciliationService
+	metrics *Metrics
+}
+
+// NewReconciliationService counts runs and the discrepancies they found and
+// repaired.
+func NewReconciliationService(next port.ReconciliationService, m *Metrics) port.ReconciliationService {
+	return &reconciliationService{next: next, metrics: m}
+}
+
+func (s *reconciliationService) Reconcile(ctx context.Context, repair bool) (*domain.ReconciliationReport, error) {
+	report, err := s.next.Reconcile(ctx, repair)
+	if err != nil {
+		s.metrics.reconciliationRuns.WithLabelValues("error").Inc()
+		return report, err
+	}
+
+	s.metrics.reconciliationRuns.WithLabelValues("success").Inc()
+	s.metrics.balanceDiscrepancies.Add(float64(len(report.Discrepancies)))
+	s.metrics.balancesRepaired.Add(float64(report.Repaired))
+	return report, nil
+}
@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+)
+
+func (m *Metrics) observeQuery(repository, method string, start time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	m.repositoryDuration.WithLabelValues(repository, method, outcome).Observe(time.Since(start).Seconds())
+}
+
+type accountRepository struct {
+	next    port.AccountRepository
+	metrics *Metrics
+}
+
+func NewAccountRepository(next port.AccountRepository, m *Metrics) port.AccountRepository {
+	return &accountRepository{next: next, metrics: m}
+}
+
+func (r *accountRepository) Save(ctx context.Context, account *domain.Account, owner string) (*domain.Account, error) {
+	start := time.Now()
+	acc, err := r.next.Save(ctx, account, owner)
+	r.metrics.observeQuery("account", "Save", start, err)
+	return acc, err
+}
+
+func (r *accountRepository) SaveBatch(ctx context.Context, accounts []*domain.Account, owner string) ([]bool, error) {
+	start := time.Now()
+	created, err := r.next.SaveBatch(ctx, accounts, owner)
+	r.metrics.observeQuery("account", "SaveBatch", start, err)
+	return created, err
+}
+
+func (r *accountRepository) FindByDocument(ctx context.Context, documentNumber string) (*domain.Account, error) {
+	start := time.Now()
+	acc, err := r.next.FindByDocument(ctx, documentNumber)
+	r.metrics.observeQuery("account", "FindByDocument", start, err)
+	return acc, err
+}
+
+func (r *accountRepository) FindByAccountID(ctx context.Context, accountID int64) (*domain.Account, error) {
+	start := time.Now()
+	acc, err := r.next.FindByAccountID(ctx, accountID)
+	r.metrics.observeQuery("account", "FindByAccountID", start, err)
+	return acc, err
+}
+
+type transactionRepository struct {
+	next    port.TransactionRepository
+	metrics *Metrics
+}
+
+func NewTransactionRepository(next port.TransactionRepository, m *Metrics) port.TransactionRepository {
+	return &transactionRepository{next: next, metrics: m}
+}
+
+func (r *transactionRepository) Save(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, error) {
+	start := time.Now()
+	tx, err := r.next.Save(ctx, transaction)
+	r.metrics.observeQuery("transaction", "Save", start, err)
+	return tx, err
+}
+
+func (r *transactionRepository) SaveBatch(ctx context.Context, transactions []*domain.Transaction) error {
+	start := time.Now()
+	err := r.next.SaveBatch(ctx, transactions)
+	r.metrics.observeQuery("transaction", "SaveBatch", start, err)
+	return err
+}
+
+func (r *transactionRepository) FindByTransactionID(ctx context.Context, transactionID int64) (*domain.Transaction, error) {
+	start := time.Now()
+	tx, err := r.next.FindByTransactionID(ctx, transactionID)
+	r.metrics.observeQuery("transaction", "FindByTransactionID", start, err)
+	return tx, err
+}
+
+func (r *transactionRepository) SumByCurrency(ctx context.Context, accountID int64) ([]domain.CurrencyBalance, error) {
+	start := time.Now()
+	balances, err := r.next.SumByCurrency(ctx, accountID)
+	r.metrics.observeQuery("transaction", "SumByCurrency", start, err)
+	return balances, err
+}
+
+func (r *transactionRepository) StreamByAccount(
+	ctx context.Context,
+	accountID int64,
+	from, to time.Time,
+	fn func(*domain.Transaction) error,
+) error {
+	start := time.Now()
+	err := r.next.StreamByAccount(ctx, accountID, from, to, fn)
+	r.metrics.observeQuery("transaction", "StreamByAccount", start, err)
+	return err
+}
+
+type operationRepository struct {
+	next    port.OperationRepository
+	metrics *Metrics
+}
+
+func NewOperationRepository(next port.OperationRepository, m *Metrics) port.OperationRepository {
+	return &operationRepository{next: next, metrics: m}
+}
+
+func (r *operationRepository) Exists(ctx context.Context, operationType int16) (bool, error) {
+	start := time.Now()
+	ok, err := r.next.Exists(ctx, operationType)
+	r.metrics.observeQuery("operation", "Exists", start, err)
+	return ok, err
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+)
+
+var operationTypeLabels = map[domain.OperationType]string{
+	domain.Purchase:            "purchase",
+	domain.InstallmentPurchase: "installment_purchase",
+	domain.Withdrawal:          "withdrawal",
+	domain.Payment:             "payment",
+}
+
+func operationTypeLabel(op domain.OperationType) string {
+	if label, ok := operationTypeLabels[op]; ok {
+		return label
+	}
+	return "unknown"
+}
+
+type transactionService struct {
+	next    port.TransactionService
+	metrics *Metrics
+}
+
+// NewTransactionService counts created transactions and the amount they
+// posted, by operation type.
+func NewTransactionService(next port.TransactionService, m *Metrics) port.TransactionService {
+	return &transactionService{next: next, metrics: m}
+}
+
+func (s *transactionService) CreateTransaction(ctx context.Context, accountID int64, operationType int16, amount float64, currency domain.Currency) (*domain.Transaction, error) {
+	tx, err := s.next.CreateTransaction(ctx, accountID, operationType, amount, currency)
+	if err != nil {
+		return tx, err
+	}
+
+	s.metrics.countCreated(tx)
+	return tx, nil
+}
+
+func (s *transactionService) GetByTransactionID(ctx context.Context, transactionID int64) (*domain.Transaction, error) {
+	return s.next.GetByTransactionID(ctx, transactionID)
+}
+
+func (s *transactionService) ExportTransactions(
+	ctx context.Context,
+	accountID int64,
+	from, to time.Time,
+	start func(*domain.Account) error,
+	fn func(*domain.Transaction) error,
+) error {
+	return s.next.ExportTransactions(ctx, accountID, from, to, start, fn)
+}
+
+func (s *transactionService) GetBalances(ctx context.Context, accountID int64) (*domain.AccountBalances, error) {
+	return s.next.GetBalances(ctx, accountID)
+}
+
+// countCreated adds tx to the created transactions and the amount posted.
+func (m *Metrics) countCreated(tx *domain.Transaction) {
+	label := operationTypeLabel(tx.OperationTypeID)
+	m.transactionsCreated.WithLabelValues(label).Inc()
+	m.amountPosted.WithLabelValues(label, string(tx.Currency)).Add(math.Abs(tx.Amount))
+}
@@ -19,11 +19,18 @@ func NewPostgresAccountRepository(db *sql.DB) *PostgresAccountRepository {
 	return &PostgresAccountRepository{db: db}
 }
 
-func (p *PostgresAccountRepository) Save(ctx context.Context, account *domain.Account) (*domain.Account, error) {
-	stmt := `INSERT INTO accounts (document_number) VALUES ($1) RETURNING account_id`
+func (p *PostgresAccountRepository) Save(ctx context.Context, account *domain.Account, owner string) (*domain.Account, error) {
+	stmt := `WITH inserted AS (
+				INSERT INTO accounts (document_number, currency) VALUES ($1, $2) RETURNING account_id
+			),
+			owned AS (
+				INSERT INTO account_owners (subject, account_id)
+				SELECT $3::text, account_id FROM inserted WHERE $3::text <> ''
+			)
+			SELECT account_id FROM inserted`
 
 	var accountId int64
-	err := p.db.QueryRowContext(ctx, stmt, account.DocumentNumber).Scan(&accountId)
+	err := p.db.QueryRowContext(ctx, stmt, account.DocumentNumber, account.Currency, owner).Scan(&accountId)
 
 	if err != nil {
 		var pgErr *pq.Error
@@ -38,11 +45,95 @@ func (p *PostgresAccountRepository) Save(ctx context.Context, account *domain.Ac
 	return account, nil
 }
 
+// SaveBatch inserts with ON CONFLICT DO NOTHING and reads the existing IDs
+// in the same statement. Its snapshot cannot see accounts committed by a
+// concurrent insert, so any still missing are looked up afterwards.
+func (p *PostgresAccountRepository) SaveBatch(ctx context.Context, accounts []*domain.Account, owner string) ([]bool, error) {
+	created := make([]bool, len(accounts))
+	if len(accounts) == 0 {
+		return created, nil
+	}
+
+	index := make(map[string]int, len(accounts))
+	documents := make([]string, len(accounts))
+	currencies := make([]string, len(accounts))
+	for i, acc := range accounts {
+		index[acc.DocumentNumber] = i
+		documents[i] = acc.DocumentNumber
+		currencies[i] = string(acc.Currency)
+	}
+
+	stmt := `WITH input AS (SELECT * FROM unnest($1::text[], $2::text[]) AS i (document_number, currency)),
+			inserted AS (
+				INSERT INTO accounts (document_number, currency) SELECT document_number, currency FROM input
+				ON CONFLICT (document_number) DO NOTHING
+				RETURNING account_id, document_number
+			),
+			owned AS (
+				INSERT INTO account_owners (subject, account_id)
+				SELECT $3::text, account_id FROM inserted WHERE $3::text <> ''
+			)
+			SELECT account_id, document_number, true FROM inserted
+			UNION ALL
+			SELECT a.account_id, a.document_number, false FROM accounts a JOIN input USING (document_number)`
+	found, err := p.scanBatch(ctx, accounts, index, created, stmt, pq.Array(documents), pq.Array(currencies), owner)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: save accounts: %w", err)
+	}
+	if found == len(accounts) {
+		return created, nil
+	}
+
+	var missing []string
+	for _, acc := range accounts {
+		if acc.ID == 0 {
+			missing = append(missing, acc.DocumentNumber)
+		}
+	}
+	stmt = `SELECT account_id, document_number, false FROM accounts WHERE document_number = ANY($1)`
+	if _, err := p.scanBatch(ctx, accounts, index, created, stmt, pq.Array(missing)); err != nil {
+		return nil, fmt.Errorf("infrastructure error: save accounts: %w", err)
+	}
+	for _, acc := range accounts {
+		if acc.ID == 0 {
+			return nil, fmt.Errorf("infrastructure error: save accounts: no account for a document in the batch")
+		}
+	}
+	return created, nil
+}
+
+// scanBatch reads (account_id, document_number, created) rows into the
+// matching accounts and returns how many it read.
+func (p *PostgresAccountRepository) scanBatch(ctx context.Context, accounts []*domain.Account, index map[string]int, created []bool, query string, args ...any) (int, error) {
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		var (
+			id       int64
+			document string
+			inserted bool
+		)
+		if err := rows.Scan(&id, &document, &inserted); err != nil {
+			return n, err
+		}
+		if i, ok := index[document]; ok {
+			accounts[i].ID, created[i] = id, inserted
+			n++
+		}
+	}
+	return n, rows.Err()
+}
+
 func (p *PostgresAccountRepository) FindByDocument(ctx context.Context, documentNumber string) (*domain.Account, error) {
-	stmt := `SELECT account_id, document_number FROM accounts WHERE document_number = $1`
+	stmt := `SELECT account_id, document_number, currency FROM accounts WHERE document_number = $1`
 
 	var acc domain.Account
-	err := p.db.QueryRowContext(ctx, stmt, documentNumber).Scan(&acc.ID, &acc.DocumentNumber)
+	err := p.db.QueryRowContext(ctx, stmt, documentNumber).Scan(&acc.ID, &acc.DocumentNumber, &acc.Currency)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -55,10 +146,10 @@ func (p *PostgresAccountRepository) FindByDocument(ctx context.Context, document
 }
 
 func (p *PostgresAccountRepository) FindByAccountID(ctx context.Context, accountID int64) (*domain.Account, error) {
-	stmt := `SELECT account_id, document_number FROM accounts WHERE account_id = $1`
+	stmt := `SELECT account_id, document_number, currency FROM accounts WHERE account_id = $1`
 	var acc domain.Account
 
-	err := p.db.QueryRowContext(ctx, stmt, accountID).Scan(&acc.ID, &acc.DocumentNumber)
+	err := p.db.QueryRowContext(ctx, stmt, accountID).Scan(&acc.ID, &acc.DocumentNumber, &acc.Currency)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -25,34 +25,45 @@ func TestPostgresAccountRepository(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("Save - Success", func(t *testing.T) {
-		acc := &domain.Account{DocumentNumber: "123"}
-		mock.ExpectQuery("INSERT INTO accounts").
-			WithArgs(acc.DocumentNumber).
+		acc := &domain.Account{DocumentNumber: "123", Currency: "BRL"}
+		mock.ExpectQuery("INSERT INTO accounts (.+) INSERT INTO account_owners").
+			WithArgs(acc.DocumentNumber, acc.Currency, "client-1").
 			WillReturnRows(sqlmock.NewRows([]string{"account_id"}).AddRow(1))
 
-		result, err := repo.Save(ctx, acc)
+		result, err := repo.Save(ctx, acc, "client-1")
 		assert.NoError(t, err)
 		assert.Equal(t, int64(1), result.ID)
 	})
 
+	t.Run("Save - Without Owner", func(t *testing.T) {
+		acc := &domain.Account{DocumentNumber: "123", Currency: "BRL"}
+		mock.ExpectQuery("INSERT INTO accounts").
+			WithArgs(acc.DocumentNumber, acc.Currency, "").
+			WillReturnRows(sqlmock.NewRows([]string{"account_id"}).AddRow(2))
+
+		result, err := repo.Save(ctx, acc, "")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), result.ID)
+	})
+
 	t.Run("Save - Duplicate Document", func(t *testing.T) {
-		acc := &domain.Account{DocumentNumber: "123"}
+		acc := &domain.Account{DocumentNumber: "123", Currency: "BRL"}
 		mock.ExpectQuery("INSERT INTO accounts").
-			WithArgs(acc.DocumentNumber).
+			WithArgs(acc.DocumentNumber, acc.Currency, "client-1").
 			WillReturnError(&pq.Error{Code: "23505"})
 
-		result, err := repo.Save(ctx, acc)
+		result, err := repo.Save(ctx, acc, "client-1")
 		assert.ErrorIs(t, err, common.ErrAccountAlreadyExists)
 		assert.Nil(t, result)
 	})
 
 	t.Run("Save - Generic Error", func(t *testing.T) {
-		acc := &domain.Account{DocumentNumber: "123"}
+		acc := &domain.Account{DocumentNumber: "123", Currency: "BRL"}
 		mock.ExpectQuery("INSERT INTO accounts").
-			WithArgs(acc.DocumentNumber).
+			WithArgs(acc.DocumentNumber, acc.Currency, "client-1").
 			WillReturnError(errors.New("db error"))
 
-		_, err := repo.Save(ctx, acc)
+		_, err := repo.Save(ctx, acc, "client-1")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "db error")
 	})
@@ -60,11 +71,12 @@ func TestPostgresAccountRepository(t *testing.T) {
 	t.Run("FindByDocument - Success", func(t *testing.T) {
 		mock.ExpectQuery("SELECT (.+) FROM accounts").
 			WithArgs("123").
-			WillReturnRows(sqlmock.NewRows([]string{"account_id", "document_number"}).AddRow(1, "123"))
+			WillReturnRows(sqlmock.NewRows([]string{"account_id", "document_number", "currency"}).AddRow(1, "123", "USD"))
 
 		result, err := repo.FindByDocument(ctx, "123")
 		assert.NoError(t, err)
 		assert.Equal(t, int64(1), result.ID)
+		assert.Equal(t, domain.Currency("USD"), result.Currency)
 	})
 
 	t.Run("FindByDocument - Not Found", func(t *testing.T) {
@@ -90,11 +102,12 @@ func TestPostgresAccountRepository(t *testing.T) {
 	t.Run("FindByAccountID - Success", func(t *testing.T) {
 		mock.ExpectQuery("SELECT (.+) FROM accounts").
 			WithArgs(int64(1)).
-			WillReturnRows(sqlmock.NewRows([]string{"account_id", "document_number"}).AddRow(1, "123"))
+			WillReturnRows(sqlmock.NewRows([]string{"account_id", "document_number", "currency"}).AddRow(1, "123", "USD"))
 
 		result, err := repo.FindByAccountID(ctx, 1)
 		assert.NoError(t, err)
 		assert.Equal(t, int64(1), result.ID)
+		assert.Equal(t, domain.Currency("USD"), result.Currency)
 	})
 
 	t.Run("FindByAccountID - Not Found", func(t *testing.T) {
@@ -116,4 +129,45 @@ func TestPostgresAccountRepository(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "db error")
 	})
+
+	t.Run("SaveBatch - New And Existing", func(t *testing.T) {
+		accounts := []*domain.Account{{DocumentNumber: "111", Currency: "BRL"}, {DocumentNumber: "222", Currency: "BRL"}}
+		mock.ExpectQuery("ON CONFLICT \\(document_number\\) DO NOTHING").
+			WithArgs(pq.Array([]string{"111", "222"}), pq.Array([]string{"BRL", "BRL"}), "client-1").
+			WillReturnRows(sqlmock.NewRows([]string{"account_id", "document_number", "bool"}).
+				AddRow(5, "222", true).
+				AddRow(1, "111", false))
+
+		created, err := repo.SaveBatch(ctx, accounts, "client-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []bool{false, true}, created)
+		assert.Equal(t, int64(1), accounts[0].ID)
+		assert.Equal(t, int64(5), accounts[1].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SaveBatch - Looks Up Concurrent Inserts", func(t *testing.T) {
+		accounts := []*domain.Account{{DocumentNumber: "111"}, {DocumentNumber: "222"}}
+		mock.ExpectQuery("ON CONFLICT (.+) INSERT INTO account_owners").
+			WillReturnRows(sqlmock.NewRows([]string{"account_id", "document_number", "bool"}).AddRow(5, "111", true))
+		mock.ExpectQuery("WHERE document_number = ANY\\(\\$1\\)").
+			WithArgs(pq.Array([]string{"222"})).
+			WillReturnRows(sqlmock.NewRows([]string{"account_id", "document_number", "bool"}).AddRow(6, "222", false))
+
+		created, err := repo.SaveBatch(ctx, accounts, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []bool{true, false}, created)
+		assert.Equal(t, int64(6), accounts[1].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SaveBatch - Generic Error", func(t *testing.T) {
+		mock.ExpectQuery("ON CONFLICT").WillReturnError(errors.New("connection lost"))
+
+		_, err := repo.SaveBatch(ctx, []*domain.Account{{DocumentNumber: "111"}}, "")
+
+		assert.ErrorContains(t, err, "infrastructure error")
+	})
 }
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/lib/pq"
+)
+
+type PostgresAPIKeyRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAPIKeyRepository(db *sql.DB) *PostgresAPIKeyRepository {
+	return &PostgresAPIKeyRepository{db: db}
+}
+
+const apiKeyColumns = `key_id, name, prefix, subject, roles, scopes, created_at, revoked_at`
+
+func (p *PostgresAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey, hash string) error {
+	stmt := `INSERT INTO api_keys (name, prefix, key_hash, subject, roles, scopes)
+			VALUES ($1, $2, $3, $4, $5, $6) RETURNING key_id, created_at`
+	err := p.db.QueryRowContext(ctx, stmt, key.Name, key.Prefix, hash, key.Subject, pq.Array(key.Roles), pq.Array(key.Scopes)).
+		Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("infrastructure error: failed to save api key: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresAPIKeyRepository) FindByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+
+	key, err := scanAPIKey(p.db.QueryRowContext(ctx, query, hash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("infrastructure error: failed to find api key: %w", err)
+	}
+	return key, nil
+}
+
+func (p *PostgresAPIKeyRepository) List(ctx context.Context) ([]*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY key_id`
+
+	rows, err := p.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]*domain.APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("infrastructure error: failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (p *PostgresAPIKeyRepository) Revoke(ctx context.Context, keyID int64) error {
+	stmt := `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, now()) WHERE key_id = $1`
+
+	result, err := p.db.ExecContext(ctx, stmt, keyID)
+	if err != nil {
+		return fmt.Errorf("infrastructure error: failed to revoke api key: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("infrastructure error: failed to revoke api key: %w", err)
+	}
+	if affected == 0 {
+		return common.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func scanAPIKey(row interface{ Scan(...any) error }) (*domain.APIKey, error) {
+	var (
+		key     domain.APIKey
+		revoked sql.NullTime
+	)
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.Subject,
+		(*pq.StringArray)(&key.Roles), (*pq.StringArray)(&key.Scopes), &key.CreatedAt, &revoked)
+	if err != nil {
+		return nil, err
+	}
+	if revoked.Valid {
+		key.RevokedAt = &revoked.Time
+	}
+	return &key, nil
+}
@@ -0,0 +1,94 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	postgres "github.com/evythrossell/account-management-api/internal/adapter/storage/postgres"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var apiKeyRow = []string{"key_id", "name", "prefix", "subject", "roles", "scopes", "created_at", "revoked_at"}
+
+func TestPostgresAPIKeyRepository(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := postgres.NewPostgresAPIKeyRepository(db)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Create - Success", func(t *testing.T) {
+		key := &domain.APIKey{Name: "billing", Prefix: "amk_0123abcd", Subject: "apikey:billing", Roles: []string{"admin"}}
+		mock.ExpectQuery("INSERT INTO api_keys").
+			WithArgs("billing", "amk_0123abcd", "hash", "apikey:billing", pq.Array([]string{"admin"}), pq.Array([]string(nil))).
+			WillReturnRows(sqlmock.NewRows([]string{"key_id", "created_at"}).AddRow(3, now))
+
+		err := repo.Create(ctx, key, "hash")
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), key.ID)
+		assert.Equal(t, now, key.CreatedAt)
+	})
+
+	t.Run("FindByHash - Success", func(t *testing.T) {
+		mock.ExpectQuery("FROM api_keys WHERE key_hash = \\$1").
+			WithArgs("hash").
+			WillReturnRows(sqlmock.NewRows(apiKeyRow).
+				AddRow(3, "billing", "amk_0123abcd", "apikey:billing", "{admin}", "{accounts:read}", now, nil))
+
+		key, err := repo.FindByHash(ctx, "hash")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admin"}, key.Roles)
+		assert.Equal(t, []string{"accounts:read"}, key.Scopes)
+		assert.False(t, key.Revoked())
+	})
+
+	t.Run("FindByHash - Not Found", func(t *testing.T) {
+		mock.ExpectQuery("FROM api_keys").WithArgs("other").WillReturnRows(sqlmock.NewRows(apiKeyRow))
+
+		_, err := repo.FindByHash(ctx, "other")
+
+		assert.ErrorIs(t, err, common.ErrAPIKeyNotFound)
+	})
+
+	t.Run("List - Success", func(t *testing.T) {
+		mock.ExpectQuery("FROM api_keys ORDER BY key_id").
+			WillReturnRows(sqlmock.NewRows(apiKeyRow).
+				AddRow(3, "billing", "amk_0123abcd", "apikey:billing", "{}", "{}", now, now))
+
+		keys, err := repo.List(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.True(t, keys[0].Revoked())
+	})
+
+	t.Run("Revoke - Not Found", func(t *testing.T) {
+		mock.ExpectExec("UPDATE api_keys SET revoked_at").WithArgs(int64(9)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.ErrorIs(t, repo.Revoke(ctx, 9), common.ErrAPIKeyNotFound)
+	})
+
+	t.Run("Revoke - Database Error", func(t *testing.T) {
+		mock.ExpectExec("UPDATE api_keys").WithArgs(int64(3)).WillReturnError(errors.New("connection reset"))
+
+		err := repo.Revoke(ctx, 3)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "infrastructure error")
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
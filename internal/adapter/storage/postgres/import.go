@@ -0,0 +1,150 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+type PostgresImportJobRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresImportJobRepository(db *sql.DB) *PostgresImportJobRepository {
+	return &PostgresImportJobRepository{db: db}
+}
+
+const importJobColumns = `job_id, status, mode, line_count, submitted_at, started_at, finished_at, error, report, principal`
+
+func (p *PostgresImportJobRepository) Create(ctx context.Context, job *domain.ImportJob, rows []domain.ImportRow) (*domain.ImportJob, error) {
+	lines, err := json.Marshal(rows)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to encode import rows: %w", err)
+	}
+	principal, err := jsonOrNull(job.Principal)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to encode import principal: %w", err)
+	}
+
+	stmt := `INSERT INTO import_jobs (status, mode, line_count, principal, lines)
+			VALUES ($1, $2, $3, $4, $5) RETURNING job_id, submitted_at`
+	err = p.db.QueryRowContext(ctx, stmt, job.Status, job.Mode, job.Lines, principal, string(lines)).
+		Scan(&job.ID, &job.SubmittedAt)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to save import job: %w", err)
+	}
+	return job, nil
+}
+
+func (p *PostgresImportJobRepository) FindByID(ctx context.Context, jobID int64) (*domain.ImportJob, error) {
+	query := `SELECT ` + importJobColumns + ` FROM import_jobs WHERE job_id = $1`
+
+	job, err := scanImportJob(p.db.QueryRowContext(ctx, query, jobID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.ErrImportJobNotFound
+		}
+		return nil, fmt.Errorf("infrastructure error: failed to find import job: %w", err)
+	}
+	return job, nil
+}
+
+func (p *PostgresImportJobRepository) Claim(ctx context.Context, staleBefore time.Time) (*domain.ImportJob, []domain.ImportRow, error) {
+	stmt := `UPDATE import_jobs SET status = $1, error = $2, finished_at = now(), lines = NULL
+			WHERE status = $3 AND started_at < $4`
+	_, err := p.db.ExecContext(ctx, stmt, domain.ImportFailed, domain.ErrMsgImportInterrupted, domain.ImportRunning, staleBefore)
+	if err != nil {
+		return nil, nil, fmt.Errorf("infrastructure error: failed to expire import jobs: %w", err)
+	}
+
+	stmt = `UPDATE import_jobs SET status = $1, started_at = now()
+			WHERE job_id = (SELECT job_id FROM import_jobs WHERE status = $2 ORDER BY job_id LIMIT 1 FOR UPDATE SKIP LOCKED)
+			RETURNING ` + importJobColumns + `, lines`
+
+	var lines []byte
+	job, err := scanImportJob(p.db.QueryRowContext(ctx, stmt, domain.ImportRunning, domain.ImportPending), &lines)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("infrastructure error: failed to claim import job: %w", err)
+	}
+
+	var rows []domain.ImportRow
+	if err := json.Unmarshal(lines, &rows); err != nil {
+		return nil, nil, fmt.Errorf("infrastructure error: failed to decode import rows: %w", err)
+	}
+	return job, rows, nil
+}
+
+// Finish only updates running jobs, so a job already expired by Claim keeps
+// its failure.
+func (p *PostgresImportJobRepository) Finish(ctx context.Context, job *domain.ImportJob) error {
+	report, err := jsonOrNull(job.Report)
+	if err != nil {
+		return fmt.Errorf("infrastructure error: failed to encode import report: %w", err)
+	}
+
+	stmt := `UPDATE import_jobs SET status = $1, error = $2, report = $3, finished_at = now(), lines = NULL
+			WHERE job_id = $4 AND status = $5`
+	_, err = p.db.ExecContext(ctx, stmt, job.Status, sql.NullString{String: job.Error, Valid: job.Error != ""}, report, job.ID, domain.ImportRunning)
+	if err != nil {
+		return fmt.Errorf("infrastructure error: failed to finish import job: %w", err)
+	}
+	return nil
+}
+
+// scanImportJob reads importJobColumns followed by any extra destinations.
+func scanImportJob(row *sql.Row, extra ...any) (*domain.ImportJob, error) {
+	var (
+		job               domain.ImportJob
+		started, finished sql.NullTime
+		jobErr            sql.NullString
+		report, principal []byte
+	)
+	dest := append([]any{
+		&job.ID, &job.Status, &job.Mode, &job.Lines, &job.SubmittedAt,
+		&started, &finished, &jobErr, &report, &principal,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	if started.Valid {
+		job.StartedAt = &started.Time
+	}
+	if finished.Valid {
+		job.FinishedAt = &finished.Time
+	}
+	job.Error = jobErr.String
+	if report != nil {
+		if err := json.Unmarshal(report, &job.Report); err != nil {
+			return nil, err
+		}
+	}
+	if principal != nil {
+		if err := json.Unmarshal(principal, &job.Principal); err != nil {
+			return nil, err
+		}
+	}
+	return &job, nil
+}
+
+// jsonOrNull encodes v for a JSONB column, or NULL for a nil pointer. The
+// text form matters: lib/pq would send a []byte as bytea.
+func jsonOrNull[T any](v *T) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,126 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	postgres "github.com/evythrossell/account-management-api/internal/adapter/storage/postgres"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var importJobRow = []string{
+	"job_id", "status", "mode", "line_count", "submitted_at", "started_at", "finished_at", "error", "report", "principal",
+}
+
+func TestPostgresImportJobRepository(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := postgres.NewPostgresImportJobRepository(db)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	rows := []domain.ImportRow{{Line: 2, AccountID: 1, OperationTypeID: 1, Amount: 10}}
+
+	t.Run("Create - Success", func(t *testing.T) {
+		mock.ExpectQuery("INSERT INTO import_jobs").
+			WithArgs(domain.ImportPending, domain.ImportBestEffort, 1, `{"sub":"client-1"}`,
+				`[{"line":2,"account_id":1,"operation_type_id":1,"amount":10}]`).
+			WillReturnRows(sqlmock.NewRows([]string{"job_id", "submitted_at"}).AddRow(7, now))
+
+		job, err := repo.Create(ctx, &domain.ImportJob{
+			Status:    domain.ImportPending,
+			Mode:      domain.ImportBestEffort,
+			Lines:     1,
+			Principal: &domain.Principal{Subject: "client-1"},
+		}, rows)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), job.ID)
+		assert.Equal(t, now, job.SubmittedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("FindByID - Not Found", func(t *testing.T) {
+		mock.ExpectQuery("FROM import_jobs WHERE job_id = \\$1").
+			WithArgs(int64(9)).
+			WillReturnRows(sqlmock.NewRows(importJobRow))
+
+		_, err := repo.FindByID(ctx, 9)
+
+		assert.ErrorIs(t, err, common.ErrImportJobNotFound)
+	})
+
+	t.Run("FindByID - Success", func(t *testing.T) {
+		mock.ExpectQuery("FROM import_jobs WHERE job_id = \\$1").
+			WithArgs(int64(7)).
+			WillReturnRows(sqlmock.NewRows(importJobRow).AddRow(
+				7, "succeeded", "best_effort", 1, now, now, now, nil,
+				[]byte(`{"total":1,"created":1,"rejected":0,"skipped":0,"lines":[{"line":2,"status":"created","transaction_id":100}]}`),
+				[]byte(`{"sub":"client-1"}`),
+			))
+
+		job, err := repo.FindByID(ctx, 7)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ImportSucceeded, job.Status)
+		assert.Equal(t, now, *job.FinishedAt)
+		assert.Equal(t, int64(100), job.Report.Lines[0].TransactionID)
+		assert.Equal(t, "client-1", job.Principal.Subject)
+		assert.Empty(t, job.Error)
+	})
+
+	t.Run("Claim - None Pending", func(t *testing.T) {
+		mock.ExpectExec("UPDATE import_jobs SET status = \\$1, error = \\$2").
+			WithArgs(domain.ImportFailed, domain.ErrMsgImportInterrupted, domain.ImportRunning, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("FOR UPDATE SKIP LOCKED").
+			WithArgs(domain.ImportRunning, domain.ImportPending).
+			WillReturnRows(sqlmock.NewRows(append(importJobRow, "lines")))
+
+		job, claimed, err := repo.Claim(ctx, now)
+
+		assert.NoError(t, err)
+		assert.Nil(t, job)
+		assert.Nil(t, claimed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Claim - Success", func(t *testing.T) {
+		mock.ExpectExec("UPDATE import_jobs SET status = \\$1, error = \\$2").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("FOR UPDATE SKIP LOCKED").
+			WillReturnRows(sqlmock.NewRows(append(importJobRow, "lines")).AddRow(
+				7, "running", "all_or_nothing", 1, now, now, nil, nil, nil, nil,
+				[]byte(`[{"line":2,"account_id":1,"operation_type_id":1,"amount":10}]`),
+			))
+
+		job, claimed, err := repo.Claim(ctx, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ImportRunning, job.Status)
+		assert.Nil(t, job.Principal)
+		assert.Nil(t, job.FinishedAt)
+		assert.Equal(t, rows, claimed)
+	})
+
+	t.Run("Finish - Success", func(t *testing.T) {
+		mock.ExpectExec("UPDATE import_jobs SET status = \\$1, error = \\$2, report = \\$3").
+			WithArgs(domain.ImportFailed, domain.ErrMsgDatabaseError, `{"total":1,"created":0,"rejected":0,"skipped":1,"lines":[{"line":2,"status":"skipped"}]}`,
+				int64(7), domain.ImportRunning).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		report := domain.NewImportReport(rows)
+		err := repo.Finish(ctx, &domain.ImportJob{ID: 7, Status: domain.ImportFailed, Error: domain.ErrMsgDatabaseError, Report: report})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
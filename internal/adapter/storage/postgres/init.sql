@@ -21,4 +21,230 @@ CREATE TABLE IF NOT EXISTS transactions (
     operation_type_id SMALLINT NOT NULL REFERENCES operations_types(operation_type_id),
     amount NUMERIC(12,2) NOT NULL,
     event_date TIMESTAMP WITH TIME ZONE NOT NULL
-)
+);
+
+CREATE TABLE IF NOT EXISTS account_owners (
+    subject TEXT NOT NULL,
+    account_id INTEGER NOT NULL REFERENCES accounts(account_id),
+    PRIMARY KEY (subject, account_id)
+);
+
+CREATE TABLE IF NOT EXISTS billing_cycles (
+    account_id INTEGER PRIMARY KEY REFERENCES accounts(account_id),
+    billing_day SMALLINT NOT NULL CHECK (billing_day BETWEEN 1 AND 28)
+);
+
+CREATE TABLE IF NOT EXISTS statements (
+    statement_id SERIAL PRIMARY KEY,
+    account_id INTEGER NOT NULL REFERENCES accounts(account_id),
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    due_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    opening_balance NUMERIC(12,2) NOT NULL,
+    debits NUMERIC(12,2) NOT NULL,
+    credits NUMERIC(12,2) NOT NULL,
+    closing_balance NUMERIC(12,2) NOT NULL,
+    minimum_payment NUMERIC(12,2) NOT NULL,
+    amount_paid NUMERIC(12,2) NOT NULL DEFAULT 0,
+    closed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    UNIQUE (account_id, period_end)
+);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS statement_id INTEGER REFERENCES statements(statement_id);
+
+CREATE INDEX IF NOT EXISTS transactions_unbilled_idx ON transactions (account_id, event_date)
+    WHERE statement_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS transactions_account_event_idx ON transactions (account_id, event_date, transaction_id);
+
+-- statement_payments records which statements each payment settled.
+CREATE TABLE IF NOT EXISTS statement_payments (
+    statement_id INTEGER NOT NULL REFERENCES statements(statement_id),
+    transaction_id INTEGER NOT NULL REFERENCES transactions(transaction_id),
+    amount NUMERIC(12,2) NOT NULL CHECK (amount > 0),
+    PRIMARY KEY (statement_id, transaction_id)
+);
+
+-- import_jobs queues batch imports; lines holds the parsed rows until the
+-- job finishes.
+CREATE TABLE IF NOT EXISTS import_jobs (
+    job_id SERIAL PRIMARY KEY,
+    status TEXT NOT NULL,
+    mode TEXT NOT NULL,
+    line_count INTEGER NOT NULL,
+    principal JSONB,
+    lines JSONB,
+    report JSONB,
+    error TEXT,
+    submitted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS import_jobs_status_idx ON import_jobs (status, job_id)
+    WHERE status IN ('pending', 'running');
+
+-- api_keys authenticates service clients; only a hash of each key is kept.
+CREATE TABLE IF NOT EXISTS api_keys (
+    key_id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    subject TEXT NOT NULL,
+    roles TEXT[] NOT NULL DEFAULT '{}',
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+-- accounts.balance is kept equal to the sum of the account's transactions by
+-- the trigger below, within the same database transaction as each insert.
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS balance NUMERIC(14,2) NOT NULL DEFAULT 0;
+
+CREATE OR REPLACE FUNCTION apply_transaction_to_balance() RETURNS trigger AS $$
+BEGIN
+    UPDATE accounts SET balance = balance + NEW.amount WHERE account_id = NEW.account_id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER transactions_balance AFTER INSERT ON transactions
+    FOR EACH ROW EXECUTE FUNCTION apply_transaction_to_balance();
+
+-- reconciliation_reports keeps the outcome of every balance reconciliation.
+CREATE TABLE IF NOT EXISTS reconciliation_reports (
+    report_id SERIAL PRIMARY KEY,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    repair BOOLEAN NOT NULL,
+    accounts_scanned INTEGER NOT NULL,
+    discrepancy_count INTEGER NOT NULL,
+    repaired_count INTEGER NOT NULL,
+    discrepancies JSONB NOT NULL
+);
+
+-- The ledger is the audit trail behind transactions: every transaction is
+-- booked by one journal entry whose postings balance, and transactions stays
+-- as the customer-side projection, written in the same database transaction
+-- as its entry. Entries can never be changed or removed.
+CREATE TABLE IF NOT EXISTS journal_entries (
+    transaction_id INTEGER PRIMARY KEY REFERENCES transactions(transaction_id),
+    posted_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS ledger_postings (
+    posting_id BIGSERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL REFERENCES journal_entries(transaction_id),
+    ledger_account TEXT NOT NULL,
+    account_id INTEGER REFERENCES accounts(account_id),
+    direction TEXT NOT NULL CHECK (direction IN ('debit', 'credit')),
+    amount NUMERIC(14,2) NOT NULL CHECK (amount > 0),
+    CHECK ((ledger_account = 'customer') = (account_id IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS ledger_postings_transaction_idx ON ledger_postings (transaction_id);
+
+CREATE OR REPLACE FUNCTION reject_ledger_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger entries are immutable' USING ERRCODE = 'restrict_violation';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER journal_entries_immutable BEFORE UPDATE OR DELETE ON journal_entries
+    FOR EACH ROW EXECUTE FUNCTION reject_ledger_change();
+CREATE OR REPLACE TRIGGER journal_entries_no_truncate BEFORE TRUNCATE ON journal_entries
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_ledger_change();
+CREATE OR REPLACE TRIGGER ledger_postings_immutable BEFORE UPDATE OR DELETE ON ledger_postings
+    FOR EACH ROW EXECUTE FUNCTION reject_ledger_change();
+CREATE OR REPLACE TRIGGER ledger_postings_no_truncate BEFORE TRUNCATE ON ledger_postings
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_ledger_change();
+
+-- Debits must equal credits for every entry by the time its database
+-- transaction commits.
+CREATE OR REPLACE FUNCTION check_entry_balanced() RETURNS trigger AS $$
+BEGIN
+    IF (SELECT SUM(CASE direction WHEN 'debit' THEN amount ELSE -amount END)
+        FROM ledger_postings WHERE transaction_id = NEW.transaction_id) <> 0 THEN
+        RAISE EXCEPTION 'journal entry % is unbalanced', NEW.transaction_id USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Constraint triggers do not support OR REPLACE.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'ledger_postings_balanced') THEN
+        CREATE CONSTRAINT TRIGGER ledger_postings_balanced AFTER INSERT ON ledger_postings
+            DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION check_entry_balanced();
+    END IF;
+END;
+$$;
+
+-- Every account holds a single currency, and transactions and journal
+-- entries are booked in it. Rows from before currencies existed are BRL.
+-- A transaction made in another currency keeps the original amount and
+-- the rate it was converted at.
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'BRL';
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'BRL';
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS original_currency CHAR(3);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS original_amount NUMERIC(14,2);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS fx_rate NUMERIC(20,10);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS fx_rate_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE journal_entries ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'BRL';
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'transactions_conversion_complete') THEN
+        ALTER TABLE transactions ADD CONSTRAINT transactions_conversion_complete CHECK (
+            (original_currency IS NULL) = (original_amount IS NULL) AND
+            (original_currency IS NULL) = (fx_rate IS NULL) AND
+            (original_currency IS NULL) = (fx_rate_at IS NULL));
+    END IF;
+END;
+$$;
+
+-- Bump SchemaVersion in schema.go together with every schema change.
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- Balances start from the existing transactions. This runs only once, so a
+-- later migrate cannot hide drift that reconciliation should report.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM schema_migrations WHERE version = 6) THEN
+        UPDATE accounts a SET balance = COALESCE(
+            (SELECT SUM(t.amount) FROM transactions t WHERE t.account_id = a.account_id), 0);
+    END IF;
+END;
+$$;
+
+-- Transactions made before the ledger existed are booked once, with the
+-- posting rules of domain.OperationType at the time of migration 7.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM schema_migrations WHERE version = 7) THEN
+        INSERT INTO journal_entries (transaction_id, posted_at)
+        SELECT transaction_id, event_date FROM transactions
+        ON CONFLICT (transaction_id) DO NOTHING;
+
+        INSERT INTO ledger_postings (transaction_id, ledger_account, account_id, direction, amount)
+        SELECT t.transaction_id, r.ledger_account,
+               CASE WHEN r.ledger_account = 'customer' THEN t.account_id END,
+               r.direction, ABS(t.amount)
+        FROM transactions t
+        JOIN (VALUES
+            (1, 'customer', 'debit'), (1, 'merchant_settlement', 'credit'),
+            (2, 'customer', 'debit'), (2, 'merchant_settlement', 'credit'),
+            (3, 'customer', 'debit'), (3, 'cash', 'credit'),
+            (4, 'cash', 'debit'), (4, 'customer', 'credit')
+        ) AS r (operation_type_id, ledger_account, direction) ON r.operation_type_id = t.operation_type_id
+        WHERE NOT EXISTS (SELECT 1 FROM ledger_postings p WHERE p.transaction_id = t.transaction_id);
+    END IF;
+END;
+$$;
+
+INSERT INTO schema_migrations (version) VALUES (1), (2), (3), (4), (5), (6), (7), (8)
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+type PostgresLedgerRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresLedgerRepository(db *sql.DB) *PostgresLedgerRepository {
+	return &PostgresLedgerRepository{db: db}
+}
+
+func (p *PostgresLedgerRepository) FindEntry(ctx context.Context, transactionID int64) (*domain.JournalEntry, error) {
+	query := `SELECT e.posted_at, e.currency, p.ledger_account, COALESCE(p.account_id, 0), p.direction, p.amount
+			FROM journal_entries e JOIN ledger_postings p ON p.transaction_id = e.transaction_id
+			WHERE e.transaction_id = $1 ORDER BY p.posting_id`
+
+	rows, err := p.db.QueryContext(ctx, query, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to find journal entry: %w", err)
+	}
+	defer rows.Close()
+
+	entry := &domain.JournalEntry{TransactionID: transactionID}
+	for rows.Next() {
+		var posting domain.Posting
+		if err := rows.Scan(&entry.PostedAt, &entry.Currency, &posting.Ledger, &posting.AccountID, &posting.Direction, &posting.Amount); err != nil {
+			return nil, fmt.Errorf("infrastructure error: failed to scan posting: %w", err)
+		}
+		entry.Postings = append(entry.Postings, posting)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to find journal entry: %w", err)
+	}
+	if len(entry.Postings) == 0 {
+		return nil, common.ErrJournalEntryNotFound
+	}
+	return entry, nil
+}
+
+func (p *PostgresLedgerRepository) TrialBalance(ctx context.Context) (*domain.TrialBalance, error) {
+	tx, err := p.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to check trial balance: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `SELECT p.ledger_account, e.currency,
+				COALESCE(SUM(p.amount) FILTER (WHERE p.direction = 'debit'), 0),
+				COALESCE(SUM(p.amount) FILTER (WHERE p.direction = 'credit'), 0)
+			FROM ledger_postings p JOIN journal_entries e ON e.transaction_id = p.transaction_id
+			GROUP BY p.ledger_account, e.currency ORDER BY p.ledger_account, e.currency`
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to sum ledgers: %w", err)
+	}
+	defer rows.Close()
+
+	balance := &domain.TrialBalance{Ledgers: []domain.LedgerTotal{}}
+	for rows.Next() {
+		var total domain.LedgerTotal
+		if err := rows.Scan(&total.Ledger, &total.Currency, &total.Debits, &total.Credits); err != nil {
+			return nil, fmt.Errorf("infrastructure error: failed to scan ledger total: %w", err)
+		}
+		balance.Ledgers = append(balance.Ledgers, total)
+		balance.Debits += total.Debits
+		balance.Credits += total.Credits
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to sum ledgers: %w", err)
+	}
+
+	query = `SELECT COUNT(*) FROM (
+				SELECT transaction_id FROM ledger_postings GROUP BY transaction_id
+				HAVING SUM(CASE direction WHEN 'debit' THEN amount ELSE -amount END) <> 0
+			) unbalanced`
+	if err := tx.QueryRowContext(ctx, query).Scan(&balance.UnbalancedEntries); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to count unbalanced entries: %w", err)
+	}
+
+	// A transaction without an entry has no net and counts as a mismatch.
+	query = `SELECT COUNT(*) FROM transactions t
+			LEFT JOIN (
+				SELECT transaction_id, SUM(CASE direction WHEN 'credit' THEN amount ELSE -amount END) AS net
+				FROM ledger_postings WHERE ledger_account = 'customer' GROUP BY transaction_id
+			) p ON p.transaction_id = t.transaction_id
+			WHERE p.net IS DISTINCT FROM t.amount`
+	if err := tx.QueryRowContext(ctx, query).Scan(&balance.ProjectionMismatches); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to count projection mismatches: %w", err)
+	}
+	return balance, nil
+}
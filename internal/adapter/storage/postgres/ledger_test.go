@@ -0,0 +1,96 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	postgres "github.com/evythrossell/account-management-api/internal/adapter/storage/postgres"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresLedgerRepository(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := postgres.NewPostgresLedgerRepository(db)
+	ctx := context.Background()
+
+	t.Run("FindEntry - Success", func(t *testing.T) {
+		postedAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+		mock.ExpectQuery("FROM journal_entries e JOIN ledger_postings p (.+) WHERE e.transaction_id = \\$1").
+			WithArgs(int64(100)).
+			WillReturnRows(sqlmock.NewRows([]string{"posted_at", "currency", "ledger_account", "account_id", "direction", "amount"}).
+				AddRow(postedAt, "BRL", "customer", 1, "debit", 50.0).
+				AddRow(postedAt, "BRL", "merchant_settlement", 0, "credit", 50.0))
+
+		entry, err := repo.FindEntry(ctx, 100)
+
+		require.NoError(t, err)
+		assert.Equal(t, &domain.JournalEntry{
+			TransactionID: 100,
+			PostedAt:      postedAt,
+			Currency:      "BRL",
+			Postings: []domain.Posting{
+				{Ledger: domain.CustomerLedger, AccountID: 1, Direction: domain.Debit, Amount: 50},
+				{Ledger: domain.MerchantSettlementLedger, Direction: domain.Credit, Amount: 50},
+			},
+		}, entry)
+	})
+
+	t.Run("FindEntry - Not Found", func(t *testing.T) {
+		mock.ExpectQuery("FROM journal_entries").
+			WithArgs(int64(404)).
+			WillReturnRows(sqlmock.NewRows([]string{"posted_at", "currency", "ledger_account", "account_id", "direction", "amount"}))
+
+		entry, err := repo.FindEntry(ctx, 404)
+
+		assert.ErrorIs(t, err, common.ErrJournalEntryNotFound)
+		assert.Nil(t, entry)
+	})
+
+	t.Run("TrialBalance - Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("FROM ledger_postings p JOIN journal_entries e (.+) GROUP BY p.ledger_account, e.currency").
+			WillReturnRows(sqlmock.NewRows([]string{"ledger_account", "currency", "debits", "credits"}).
+				AddRow("cash", "BRL", 25.0, 10.0).
+				AddRow("customer", "BRL", 60.0, 25.0).
+				AddRow("merchant_settlement", "BRL", 0.0, 50.0))
+		mock.ExpectQuery("HAVING SUM").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery("WHERE p.net IS DISTINCT FROM t.amount").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		balance, err := repo.TrialBalance(ctx)
+
+		require.NoError(t, err)
+		assert.Len(t, balance.Ledgers, 3)
+		assert.Equal(t, 85.0, balance.Debits)
+		assert.Equal(t, 85.0, balance.Credits)
+		assert.Equal(t, 1, balance.ProjectionMismatches)
+		assert.False(t, balance.Balanced())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("TrialBalance - Generic Error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("FROM ledger_postings").
+			WillReturnError(errors.New("db connection lost"))
+		mock.ExpectRollback()
+
+		balance, err := repo.TrialBalance(ctx)
+
+		assert.ErrorContains(t, err, "infrastructure error")
+		assert.Nil(t, balance)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
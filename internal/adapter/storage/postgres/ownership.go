@@ -0,0 +1,25 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type PostgresAccountOwnershipRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAccountOwnershipRepository(db *sql.DB) *PostgresAccountOwnershipRepository {
+	return &PostgresAccountOwnershipRepository{db: db}
+}
+
+func (p *PostgresAccountOwnershipRepository) IsOwner(ctx context.Context, subject string, accountID int64) (bool, error) {
+	var owned bool
+	query := `SELECT EXISTS(SELECT 1 FROM account_owners WHERE subject = $1 AND account_id = $2)`
+
+	if err := p.db.QueryRowContext(ctx, query, subject, accountID).Scan(&owned); err != nil {
+		return false, fmt.Errorf("infrastructure error: failed to check account owner: %w", err)
+	}
+	return owned, nil
+}
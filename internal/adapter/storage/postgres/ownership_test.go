@@ -0,0 +1,55 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	postgres "github.com/evythrossell/account-management-api/internal/adapter/storage/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresAccountOwnershipRepository(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := postgres.NewPostgresAccountOwnershipRepository(db)
+	ctx := context.Background()
+
+	t.Run("IsOwner - True", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT EXISTS`).
+			WithArgs("client-1", int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		owned, err := repo.IsOwner(ctx, "client-1", 1)
+
+		assert.NoError(t, err)
+		assert.True(t, owned)
+	})
+
+	t.Run("IsOwner - False", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT EXISTS`).
+			WithArgs("client-1", int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		owned, err := repo.IsOwner(ctx, "client-1", 2)
+
+		assert.NoError(t, err)
+		assert.False(t, owned)
+	})
+
+	t.Run("IsOwner - Infrastructure Error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT EXISTS`).
+			WithArgs("client-1", int64(1)).
+			WillReturnError(errors.New("timeout"))
+
+		owned, err := repo.IsOwner(ctx, "client-1", 1)
+
+		assert.Error(t, err)
+		assert.False(t, owned)
+	})
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+type PostgresReconciliationRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresReconciliationRepository(db *sql.DB) *PostgresReconciliationRepository {
+	return &PostgresReconciliationRepository{db: db}
+}
+
+func (p *PostgresReconciliationRepository) ScanBalances(ctx context.Context, afterID int64, limit int) ([]domain.BalanceCheck, error) {
+	query := `SELECT a.account_id, a.balance,
+				(SELECT COALESCE(SUM(t.amount), 0) FROM transactions t WHERE t.account_id = a.account_id)
+			FROM accounts a WHERE a.account_id > $1 ORDER BY a.account_id LIMIT $2`
+
+	rows, err := p.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to scan balances: %w", err)
+	}
+	defer rows.Close()
+
+	checks := make([]domain.BalanceCheck, 0, limit)
+	for rows.Next() {
+		var c domain.BalanceCheck
+		if err := rows.Scan(&c.AccountID, &c.Stored, &c.Ledger); err != nil {
+			return nil, fmt.Errorf("infrastructure error: failed to scan balance: %w", err)
+		}
+		checks = append(checks, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to scan balances: %w", err)
+	}
+	return checks, nil
+}
+
+// RepairBalance holds the account row lock while it sums the transactions.
+// Inserts take the same lock in their balance trigger, so the sum includes
+// every committed transaction and later ones add on top of the repair.
+func (p *PostgresReconciliationRepository) RepairBalance(ctx context.Context, accountID int64) (domain.BalanceCheck, bool, error) {
+	check := domain.BalanceCheck{AccountID: accountID}
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return check, false, fmt.Errorf("infrastructure error: failed to repair balance: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `SELECT balance FROM accounts WHERE account_id = $1 FOR UPDATE`, accountID).Scan(&check.Stored)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return check, false, common.ErrAccountNotFound
+		}
+		return check, false, fmt.Errorf("infrastructure error: failed to lock account: %w", err)
+	}
+	err = tx.QueryRowContext(ctx, `SELECT COALESCE(SUM(amount), 0) FROM transactions WHERE account_id = $1`, accountID).Scan(&check.Ledger)
+	if err != nil {
+		return check, false, fmt.Errorf("infrastructure error: failed to sum transactions: %w", err)
+	}
+	if !check.Drifted() {
+		return check, false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE accounts SET balance = $1 WHERE account_id = $2`, check.Ledger, accountID); err != nil {
+		return check, false, fmt.Errorf("infrastructure error: failed to repair balance: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return check, false, fmt.Errorf("infrastructure error: failed to repair balance: %w", err)
+	}
+	return check, true, nil
+}
+
+func (p *PostgresReconciliationRepository) SaveReport(ctx context.Context, report *domain.ReconciliationReport) error {
+	discrepancies, err := json.Marshal(report.Discrepancies)
+	if err != nil {
+		return fmt.Errorf("infrastructure error: failed to encode discrepancies: %w", err)
+	}
+
+	stmt := `INSERT INTO reconciliation_reports
+			(started_at, finished_at, repair, accounts_scanned, discrepancy_count, repaired_count, discrepancies)
+			VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING report_id`
+	err = p.db.QueryRowContext(ctx, stmt, report.StartedAt, report.FinishedAt, report.Repair,
+		report.Accounts, len(report.Discrepancies), report.Repaired, string(discrepancies)).Scan(&report.ID)
+	if err != nil {
+		return fmt.Errorf("infrastructure error: failed to save reconciliation report: %w", err)
+	}
+	return nil
+}
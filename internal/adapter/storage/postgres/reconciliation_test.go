@@ -0,0 +1,110 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	postgres "github.com/evythrossell/account-management-api/internal/adapter/storage/postgres"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresReconciliationRepository(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := postgres.NewPostgresReconciliationRepository(db)
+	ctx := context.Background()
+
+	t.Run("ScanBalances - Success", func(t *testing.T) {
+		mock.ExpectQuery("FROM accounts a WHERE a.account_id > \\$1 ORDER BY a.account_id LIMIT \\$2").
+			WithArgs(int64(10), 2).
+			WillReturnRows(sqlmock.NewRows([]string{"account_id", "balance", "ledger"}).
+				AddRow(11, 50.0, 50.0).
+				AddRow(12, 20.0, -30.0))
+
+		checks, err := repo.ScanBalances(ctx, 10, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.BalanceCheck{
+			{AccountID: 11, Stored: 50, Ledger: 50},
+			{AccountID: 12, Stored: 20, Ledger: -30},
+		}, checks)
+	})
+
+	t.Run("RepairBalance - Drifted", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT balance FROM accounts WHERE account_id = \\$1 FOR UPDATE").
+			WithArgs(int64(12)).
+			WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(20.0))
+		mock.ExpectQuery("SELECT COALESCE\\(SUM\\(amount\\), 0\\) FROM transactions").
+			WithArgs(int64(12)).
+			WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(-30.0))
+		mock.ExpectExec("UPDATE accounts SET balance = \\$1").
+			WithArgs(-30.0, int64(12)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		check, repaired, err := repo.RepairBalance(ctx, 12)
+
+		require.NoError(t, err)
+		assert.True(t, repaired)
+		assert.Equal(t, 50.0, check.Difference())
+	})
+
+	t.Run("RepairBalance - Already Consistent", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("FOR UPDATE").WithArgs(int64(11)).
+			WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(50.0))
+		mock.ExpectQuery("FROM transactions").WithArgs(int64(11)).
+			WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(50.0))
+		mock.ExpectRollback()
+
+		_, repaired, err := repo.RepairBalance(ctx, 11)
+
+		require.NoError(t, err)
+		assert.False(t, repaired)
+	})
+
+	t.Run("RepairBalance - Account Not Found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("FOR UPDATE").WithArgs(int64(99)).
+			WillReturnRows(sqlmock.NewRows([]string{"balance"}))
+		mock.ExpectRollback()
+
+		_, _, err := repo.RepairBalance(ctx, 99)
+
+		assert.ErrorIs(t, err, common.ErrAccountNotFound)
+	})
+
+	t.Run("SaveReport - Success", func(t *testing.T) {
+		start := time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC)
+		report := &domain.ReconciliationReport{StartedAt: start, FinishedAt: start.Add(time.Minute), Accounts: 2}
+		report.Add(domain.BalanceCheck{AccountID: 12, Stored: 20, Ledger: -30})
+		mock.ExpectQuery("INSERT INTO reconciliation_reports").
+			WithArgs(report.StartedAt, report.FinishedAt, false, 3, 1, 0,
+				`[{"account_id":12,"stored_balance":20,"ledger_balance":-30,"difference":50,"repaired":false}]`).
+			WillReturnRows(sqlmock.NewRows([]string{"report_id"}).AddRow(4))
+
+		require.NoError(t, repo.SaveReport(ctx, report))
+		assert.Equal(t, int64(4), report.ID)
+	})
+
+	t.Run("SaveReport - Database Error", func(t *testing.T) {
+		mock.ExpectQuery("INSERT INTO reconciliation_reports").WillReturnError(errors.New("connection reset"))
+
+		err := repo.SaveReport(ctx, &domain.ReconciliationReport{})
+
+		assert.ErrorContains(t, err, "infrastructure error")
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+)
+
+// SchemaVersion is the schema_migrations version this build expects.
+const SchemaVersion = 8
+
+// initScript creates the whole schema. Every statement is idempotent, so it
+// also upgrades older databases.
+//
+//go:embed init.sql
+var initScript string
+
+type SchemaChecker struct {
+	db *sql.DB
+}
+
+func NewSchemaChecker(db *sql.DB) *SchemaChecker {
+	return &SchemaChecker{db: db}
+}
+
+// Version returns the latest applied schema version.
+func (s *SchemaChecker) Version(ctx context.Context) (int, error) {
+	query := `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`
+
+	var version int
+	if err := s.db.QueryRowContext(ctx, query).Scan(&version); err != nil {
+		return 0, fmt.Errorf("infrastructure error: read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// Check fails while the database is behind the schema this build expects.
+func (s *SchemaChecker) Check(ctx context.Context) error {
+	version, err := s.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if version < SchemaVersion {
+		return fmt.Errorf("schema version %d is behind expected version %d", version, SchemaVersion)
+	}
+	return nil
+}
+
+// Migrate brings the database up to SchemaVersion.
+func (s *SchemaChecker) Migrate(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, initScript); err != nil {
+		return fmt.Errorf("infrastructure error: apply schema: %w", err)
+	}
+	return nil
+}
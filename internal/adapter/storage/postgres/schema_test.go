@@ -0,0 +1,89 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	postgres "github.com/evythrossell/account-management-api/internal/adapter/storage/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaChecker(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	checker := postgres.NewSchemaChecker(db)
+	ctx := context.Background()
+
+	t.Run("Check - Up To Date", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COALESCE\\(MAX\\(version\\), 0\\) FROM schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(postgres.SchemaVersion))
+
+		assert.NoError(t, checker.Check(ctx))
+	})
+
+	t.Run("Check - Behind", func(t *testing.T) {
+		mock.ExpectQuery("FROM schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(postgres.SchemaVersion - 1))
+
+		err := checker.Check(ctx)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "behind expected version")
+	})
+
+	t.Run("Check - Missing Table", func(t *testing.T) {
+		mock.ExpectQuery("FROM schema_migrations").
+			WillReturnError(errors.New(`relation "schema_migrations" does not exist`))
+
+		err := checker.Check(ctx)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "infrastructure error")
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchemaChecker_Migrate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	checker := postgres.NewSchemaChecker(db)
+	ctx := context.Background()
+
+	t.Run("Migrate - Success", func(t *testing.T) {
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS api_keys").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.NoError(t, checker.Migrate(ctx))
+	})
+
+	t.Run("Migrate - Failure", func(t *testing.T) {
+		mock.ExpectExec("CREATE TABLE").WillReturnError(errors.New("permission denied"))
+
+		err := checker.Migrate(ctx)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "apply schema")
+	})
+
+	t.Run("Version - Success", func(t *testing.T) {
+		mock.ExpectQuery("FROM schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+
+		version, err := checker.Version(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, version)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,338 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/lib/pq"
+)
+
+const statementColumns = `statement_id, account_id, period_start, period_end, due_date, opening_balance,
+		debits, credits, closing_balance, minimum_payment, amount_paid`
+
+type PostgresStatementRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresStatementRepository(db *sql.DB) *PostgresStatementRepository {
+	return &PostgresStatementRepository{db: db}
+}
+
+func (p *PostgresStatementRepository) BillingDay(ctx context.Context, accountID int64) (int, error) {
+	stmt := `SELECT billing_day FROM billing_cycles WHERE account_id = $1`
+
+	var day int
+	if err := p.db.QueryRowContext(ctx, stmt, accountID).Scan(&day); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("infrastructure error: failed to find billing cycle: %w", err)
+	}
+	return day, nil
+}
+
+func (p *PostgresStatementRepository) SaveBillingCycle(ctx context.Context, cycle *domain.BillingCycle) error {
+	stmt := `INSERT INTO billing_cycles (account_id, billing_day) VALUES ($1, $2)
+			ON CONFLICT (account_id) DO UPDATE SET billing_day = EXCLUDED.billing_day`
+
+	if _, err := p.db.ExecContext(ctx, stmt, cycle.AccountID, cycle.BillingDay); err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("%w: %v", common.ErrAccountNotFound, err)
+		}
+		return fmt.Errorf("infrastructure error: failed to save billing cycle: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresStatementRepository) CycleStates(ctx context.Context) ([]domain.CycleState, error) {
+	query := `SELECT a.account_id, COALESCE(b.billing_day, 0),
+			(SELECT MAX(s.period_end) FROM statements s WHERE s.account_id = a.account_id),
+			(SELECT MIN(t.event_date) FROM transactions t WHERE t.account_id = a.account_id AND t.statement_id IS NULL)
+			FROM accounts a LEFT JOIN billing_cycles b ON b.account_id = a.account_id
+			ORDER BY a.account_id`
+
+	rows, err := p.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to list billing cycles: %w", err)
+	}
+	defer rows.Close()
+
+	var states []domain.CycleState
+	for rows.Next() {
+		var state domain.CycleState
+		var lastClose, firstUnbilled sql.NullTime
+		if err := rows.Scan(&state.AccountID, &state.BillingDay, &lastClose, &firstUnbilled); err != nil {
+			return nil, fmt.Errorf("infrastructure error: failed to list billing cycles: %w", err)
+		}
+		state.LastClose = lastClose.Time
+		state.FirstUnbilled = firstUnbilled.Time
+		states = append(states, state)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to list billing cycles: %w", err)
+	}
+	return states, nil
+}
+
+func (p *PostgresStatementRepository) Close(
+	ctx context.Context,
+	accountID int64,
+	periodStart, periodEnd time.Time,
+	policy domain.StatementPolicy,
+) (*domain.Statement, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to close statement: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockAccount(ctx, tx, accountID); err != nil {
+		return nil, err
+	}
+
+	var closed bool
+	query := `SELECT EXISTS(SELECT 1 FROM statements WHERE account_id = $1 AND period_end >= $2)`
+	if err := tx.QueryRowContext(ctx, query, accountID, periodEnd).Scan(&closed); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to close statement: %w", err)
+	}
+	if closed {
+		return nil, common.ErrStatementAlreadyClosed
+	}
+
+	var opening float64
+	query = `SELECT COALESCE((SELECT closing_balance FROM statements WHERE account_id = $1
+			ORDER BY period_end DESC LIMIT 1), 0)`
+	if err := tx.QueryRowContext(ctx, query, accountID).Scan(&opening); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to close statement: %w", err)
+	}
+
+	var debits, credits float64
+	query = `SELECT COALESCE(SUM(-amount) FILTER (WHERE amount < 0), 0), COALESCE(SUM(amount) FILTER (WHERE amount > 0), 0)
+			FROM transactions WHERE account_id = $1 AND statement_id IS NULL AND event_date < $2`
+	if err := tx.QueryRowContext(ctx, query, accountID, periodEnd).Scan(&debits, &credits); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to close statement: %w", err)
+	}
+
+	s := domain.NewStatement(accountID, periodStart, periodEnd, opening, debits, credits, policy)
+	stmt := `INSERT INTO statements (account_id, period_start, period_end, due_date, opening_balance,
+			debits, credits, closing_balance, minimum_payment)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING statement_id`
+	err = tx.QueryRowContext(ctx, stmt,
+		s.AccountID,
+		s.PeriodStart,
+		s.PeriodEnd,
+		s.DueDate,
+		s.OpeningBalance,
+		s.Debits,
+		s.Credits,
+		s.ClosingBalance,
+		s.MinimumPayment,
+	).Scan(&s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to close statement: %w", err)
+	}
+
+	stmt = `UPDATE transactions SET statement_id = $1
+			WHERE account_id = $2 AND statement_id IS NULL AND event_date < $3`
+	if _, err := tx.ExecContext(ctx, stmt, s.ID, accountID, periodEnd); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to close statement: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to close statement: %w", err)
+	}
+	return s, nil
+}
+
+func (p *PostgresStatementRepository) AllocatePayments(ctx context.Context, accountID int64) ([]domain.PaymentAllocation, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to allocate payments: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockAccount(ctx, tx, accountID); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + statementColumns + ` FROM statements
+			WHERE account_id = $1 AND amount_paid < -closing_balance
+			ORDER BY period_end`
+	statements, err := queryStatements(ctx, tx, query, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to allocate payments: %w", err)
+	}
+	if len(statements) == 0 {
+		return nil, nil
+	}
+
+	payments, err := pendingPayments(ctx, tx, accountID, statements[0].PeriodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to allocate payments: %w", err)
+	}
+
+	allocations := domain.AllocatePayments(statements, payments)
+	for _, a := range allocations {
+		stmt := `INSERT INTO statement_payments (statement_id, transaction_id, amount) VALUES ($1, $2, $3)`
+		if _, err := tx.ExecContext(ctx, stmt, a.StatementID, a.TransactionID, a.Amount); err != nil {
+			return nil, fmt.Errorf("infrastructure error: failed to allocate payments: %w", err)
+		}
+		stmt = `UPDATE statements SET amount_paid = amount_paid + $1 WHERE statement_id = $2`
+		if _, err := tx.ExecContext(ctx, stmt, a.Amount, a.StatementID); err != nil {
+			return nil, fmt.Errorf("infrastructure error: failed to allocate payments: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to allocate payments: %w", err)
+	}
+	return allocations, nil
+}
+
+func (p *PostgresStatementRepository) ListByAccount(ctx context.Context, accountID int64) ([]*domain.Statement, error) {
+	query := `SELECT ` + statementColumns + ` FROM statements WHERE account_id = $1 ORDER BY period_end DESC`
+
+	statements, err := queryStatements(ctx, p.db, query, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to list statements: %w", err)
+	}
+	return statements, nil
+}
+
+func (p *PostgresStatementRepository) FindByID(ctx context.Context, statementID int64) (*domain.Statement, error) {
+	query := `SELECT ` + statementColumns + ` FROM statements WHERE statement_id = $1`
+
+	statements, err := queryStatements(ctx, p.db, query, statementID)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to find statement: %w", err)
+	}
+	if len(statements) == 0 {
+		return nil, common.ErrStatementNotFound
+	}
+	s := statements[0]
+
+	query = `SELECT transaction_id, account_id, operation_type_id, amount, event_date, ` + conversionColumns + `
+			FROM transactions WHERE statement_id = $1 ORDER BY event_date, transaction_id`
+	rows, err := p.db.QueryContext(ctx, query, statementID)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to find statement transactions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			t    domain.Transaction
+			conv conversionRow
+		)
+		dest := append([]any{&t.ID, &t.AccountID, &t.OperationTypeID, &t.Amount, &t.EventDate}, conv.dest(&t)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("infrastructure error: failed to find statement transactions: %w", err)
+		}
+		t.Conversion = conv.conversion()
+		s.Transactions = append(s.Transactions, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to find statement transactions: %w", err)
+	}
+
+	query = `SELECT statement_id, transaction_id, amount FROM statement_payments
+			WHERE statement_id = $1 ORDER BY transaction_id`
+	payments, err := p.db.QueryContext(ctx, query, statementID)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to find statement payments: %w", err)
+	}
+	defer payments.Close()
+	for payments.Next() {
+		var a domain.PaymentAllocation
+		if err := payments.Scan(&a.StatementID, &a.TransactionID, &a.Amount); err != nil {
+			return nil, fmt.Errorf("infrastructure error: failed to find statement payments: %w", err)
+		}
+		s.Payments = append(s.Payments, a)
+	}
+	if err := payments.Err(); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to find statement payments: %w", err)
+	}
+
+	return s, nil
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func queryStatements(ctx context.Context, q queryer, query string, args ...any) ([]*domain.Statement, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statements []*domain.Statement
+	for rows.Next() {
+		var s domain.Statement
+		err := rows.Scan(
+			&s.ID,
+			&s.AccountID,
+			&s.PeriodStart,
+			&s.PeriodEnd,
+			&s.DueDate,
+			&s.OpeningBalance,
+			&s.Debits,
+			&s.Credits,
+			&s.ClosingBalance,
+			&s.MinimumPayment,
+			&s.AmountPaid,
+		)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, &s)
+	}
+	return statements, rows.Err()
+}
+
+// pendingPayments returns the account's payments made since the given time
+// that still have an unallocated remainder, oldest first.
+func pendingPayments(ctx context.Context, tx *sql.Tx, accountID int64, since time.Time) ([]domain.PendingPayment, error) {
+	query := `SELECT t.transaction_id, t.event_date, t.amount - COALESCE(SUM(sp.amount), 0)
+			FROM transactions t LEFT JOIN statement_payments sp ON sp.transaction_id = t.transaction_id
+			WHERE t.account_id = $1 AND t.operation_type_id = $2 AND t.event_date >= $3
+			GROUP BY t.transaction_id
+			HAVING t.amount - COALESCE(SUM(sp.amount), 0) > 0
+			ORDER BY t.event_date, t.transaction_id`
+
+	rows, err := tx.QueryContext(ctx, query, accountID, domain.Payment, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []domain.PendingPayment
+	for rows.Next() {
+		var p domain.PendingPayment
+		if err := rows.Scan(&p.TransactionID, &p.EventDate, &p.Remaining); err != nil {
+			return nil, err
+		}
+		payments = append(payments, p)
+	}
+	return payments, rows.Err()
+}
+
+// lockAccount serializes statement changes for an account until tx ends.
+func lockAccount(ctx context.Context, tx *sql.Tx, accountID int64) error {
+	var id int64
+	query := `SELECT account_id FROM accounts WHERE account_id = $1 FOR UPDATE`
+	if err := tx.QueryRowContext(ctx, query, accountID).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return common.ErrAccountNotFound
+		}
+		return fmt.Errorf("infrastructure error: failed to lock account: %w", err)
+	}
+	return nil
+}
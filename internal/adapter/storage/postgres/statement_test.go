@@ -0,0 +1,189 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	postgres "github.com/evythrossell/account-management-api/internal/adapter/storage/postgres"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var statementRow = []string{
+	"statement_id", "account_id", "period_start", "period_end", "due_date", "opening_balance",
+	"debits", "credits", "closing_balance", "minimum_payment", "amount_paid",
+}
+
+func TestPostgresStatementRepository(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := postgres.NewPostgresStatementRepository(db)
+	ctx := context.Background()
+	policy := domain.StatementPolicy{DueDays: 10, MinimumPaymentRate: 0.15, MinimumPaymentFloor: 20}
+	start := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("BillingDay - Not Set", func(t *testing.T) {
+		mock.ExpectQuery("SELECT billing_day FROM billing_cycles").
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"billing_day"}))
+
+		day, err := repo.BillingDay(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Zero(t, day)
+	})
+
+	t.Run("SaveBillingCycle - Foreign Key Violation (23503)", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO billing_cycles").
+			WithArgs(int64(9), 15).
+			WillReturnError(&pq.Error{Code: "23503"})
+
+		err := repo.SaveBillingCycle(ctx, &domain.BillingCycle{AccountID: 9, BillingDay: 15})
+
+		assert.ErrorIs(t, err, common.ErrAccountNotFound)
+	})
+
+	t.Run("CycleStates - Success", func(t *testing.T) {
+		mock.ExpectQuery("SELECT a.account_id(.+)FROM accounts a LEFT JOIN billing_cycles").
+			WillReturnRows(sqlmock.NewRows([]string{"account_id", "billing_day", "last_close", "first_unbilled"}).
+				AddRow(1, 10, end, nil).
+				AddRow(2, 0, nil, start))
+
+		states, err := repo.CycleStates(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.CycleState{
+			{AccountID: 1, BillingDay: 10, LastClose: end},
+			{AccountID: 2, FirstUnbilled: start},
+		}, states)
+	})
+
+	t.Run("Close - Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT account_id FROM accounts WHERE account_id = \\$1 FOR UPDATE").
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"account_id"}).AddRow(1))
+		mock.ExpectQuery("SELECT EXISTS").
+			WithArgs(int64(1), end).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectQuery("SELECT COALESCE\\(\\(SELECT closing_balance").
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"closing_balance"}).AddRow(-100.0))
+		mock.ExpectQuery("FROM transactions WHERE account_id = \\$1 AND statement_id IS NULL").
+			WithArgs(int64(1), end).
+			WillReturnRows(sqlmock.NewRows([]string{"debits", "credits"}).AddRow(500.0, 100.0))
+		mock.ExpectQuery("INSERT INTO statements").
+			WithArgs(int64(1), start, end, end.AddDate(0, 0, 10), -100.0, 500.0, 100.0, -500.0, 75.0).
+			WillReturnRows(sqlmock.NewRows([]string{"statement_id"}).AddRow(7))
+		mock.ExpectExec("UPDATE transactions SET statement_id").
+			WithArgs(int64(7), int64(1), end).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		s, err := repo.Close(ctx, 1, start, end, policy)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), s.ID)
+		assert.Equal(t, -500.0, s.ClosingBalance)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Close - Already Closed", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("FOR UPDATE").
+			WillReturnRows(sqlmock.NewRows([]string{"account_id"}).AddRow(1))
+		mock.ExpectQuery("SELECT EXISTS").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+
+		s, err := repo.Close(ctx, 1, start, end, policy)
+
+		assert.ErrorIs(t, err, common.ErrStatementAlreadyClosed)
+		assert.Nil(t, s)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("AllocatePayments - Success", func(t *testing.T) {
+		paidAt := end.AddDate(0, 0, 2)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("FOR UPDATE").
+			WillReturnRows(sqlmock.NewRows([]string{"account_id"}).AddRow(1))
+		mock.ExpectQuery("FROM statements(.+)amount_paid < -closing_balance").
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows(statementRow).
+				AddRow(7, 1, start, end, end.AddDate(0, 0, 10), -100.0, 500.0, 100.0, -500.0, 75.0, 0.0))
+		mock.ExpectQuery("FROM transactions t LEFT JOIN statement_payments").
+			WithArgs(int64(1), domain.Payment, end).
+			WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "event_date", "remaining"}).AddRow(42, paidAt, 200.0))
+		mock.ExpectExec("INSERT INTO statement_payments").
+			WithArgs(int64(7), int64(42), 200.0).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE statements SET amount_paid").
+			WithArgs(200.0, int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		allocations, err := repo.AllocatePayments(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.PaymentAllocation{{StatementID: 7, TransactionID: 42, Amount: 200}}, allocations)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("AllocatePayments - Account Not Found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("FOR UPDATE").
+			WillReturnRows(sqlmock.NewRows([]string{"account_id"}))
+		mock.ExpectRollback()
+
+		_, err := repo.AllocatePayments(ctx, 9)
+
+		assert.ErrorIs(t, err, common.ErrAccountNotFound)
+	})
+
+	t.Run("FindByID - Not Found", func(t *testing.T) {
+		mock.ExpectQuery("FROM statements WHERE statement_id = \\$1").
+			WithArgs(int64(99)).
+			WillReturnRows(sqlmock.NewRows(statementRow))
+
+		s, err := repo.FindByID(ctx, 99)
+
+		assert.ErrorIs(t, err, common.ErrStatementNotFound)
+		assert.Nil(t, s)
+	})
+
+	t.Run("FindByID - Success", func(t *testing.T) {
+		mock.ExpectQuery("FROM statements WHERE statement_id = \\$1").
+			WithArgs(int64(7)).
+			WillReturnRows(sqlmock.NewRows(statementRow).
+				AddRow(7, 1, start, end, end.AddDate(0, 0, 10), 0.0, 50.0, 0.0, -50.0, 20.0, 50.0))
+		mock.ExpectQuery("FROM transactions WHERE statement_id = \\$1").
+			WithArgs(int64(7)).
+			WillReturnRows(sqlmock.NewRows(streamColumns).
+				AddRow(40, 1, 1, -50.0, start.AddDate(0, 0, 3), "BRL", "USD", -10.0, 5.0, start))
+		mock.ExpectQuery("FROM statement_payments").
+			WithArgs(int64(7)).
+			WillReturnRows(sqlmock.NewRows([]string{"statement_id", "transaction_id", "amount"}).AddRow(7, 42, 50.0))
+
+		s, err := repo.FindByID(ctx, 7)
+
+		require.NoError(t, err)
+		assert.Len(t, s.Transactions, 1)
+		assert.Equal(t, -50.0, s.Transactions[0].Amount)
+		assert.Equal(t, domain.Currency("BRL"), s.Transactions[0].Currency)
+		assert.Equal(t, &domain.Conversion{Currency: "USD", Amount: -10, Rate: 5, RateAt: start}, s.Transactions[0].Conversion)
+		assert.Equal(t, []domain.PaymentAllocation{{StatementID: 7, TransactionID: 42, Amount: 50}}, s.Payments)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/evythrossell/account-management-api/internal/core/domain"
 	common "github.com/evythrossell/account-management-api/pkg"
@@ -19,16 +21,20 @@ func NewPostgresTransactionRepository(db *sql.DB) *PostgresTransactionRepository
 	return &PostgresTransactionRepository{db: db}
 }
 
+// Save books transaction's journal entry in the same database transaction,
+// so the projection and the ledger never disagree.
 func (p *PostgresTransactionRepository) Save(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, error) {
-	stmt := `INSERT INTO transactions (account_id, operation_type_id, amount, event_date) 
-			VALUES ($1, $2, $3, $4) RETURNING transaction_id`
+	stmt := `INSERT INTO transactions (account_id, operation_type_id, amount, event_date,
+				currency, original_currency, original_amount, fx_rate, fx_rate_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING transaction_id`
 
-	err := p.db.QueryRowContext(ctx, stmt,
-		transaction.AccountID,
-		transaction.OperationTypeID,
-		transaction.Amount,
-		transaction.EventDate,
-	).Scan(&transaction.ID)
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to save transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, stmt, transactionArgs(transaction)...).Scan(&transaction.ID)
 
 	if err != nil {
 		var pgErr *pq.Error
@@ -39,19 +45,180 @@ func (p *PostgresTransactionRepository) Save(ctx context.Context, transaction *d
 		}
 		return nil, fmt.Errorf("infrastructure error: failed to save transaction: %w", err)
 	}
+
+	if err := postEntries(ctx, tx, []*domain.Transaction{transaction}); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to book transaction: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to save transaction: %w", err)
+	}
 	return transaction, nil
 }
 
+// batchInsertRows keeps each multi-row INSERT well under the 65535
+// parameters a Postgres statement accepts.
+const batchInsertRows = 1000
+
+func (p *PostgresTransactionRepository) SaveBatch(ctx context.Context, transactions []*domain.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("infrastructure error: failed to save transactions: %w", err)
+	}
+	defer tx.Rollback()
+
+	for start := 0; start < len(transactions); start += batchInsertRows {
+		chunk := transactions[start:min(start+batchInsertRows, len(transactions))]
+		if err := insertTransactions(ctx, tx, chunk); err != nil {
+			var pgErr *pq.Error
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+				return fmt.Errorf("%w: %v", common.ErrAccountNotFound, err)
+			}
+			return fmt.Errorf("infrastructure error: failed to save transactions: %w", err)
+		}
+	}
+
+	if err := postEntries(ctx, tx, transactions); err != nil {
+		return fmt.Errorf("infrastructure error: failed to book transactions: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("infrastructure error: failed to save transactions: %w", err)
+	}
+	return nil
+}
+
+// transactionColumns is the number of columns transactionArgs fills.
+const transactionColumns = 9
+
+// transactionArgs returns the values of a new transaction's row. The
+// conversion columns are all NULL for transactions made in the account's
+// currency.
+func transactionArgs(t *domain.Transaction) []any {
+	args := []any{t.AccountID, t.OperationTypeID, t.Amount, t.EventDate, t.Currency}
+	if c := t.Conversion; c != nil {
+		return append(args, c.Currency, c.Amount, c.Rate, c.RateAt)
+	}
+	return append(args, nil, nil, nil, nil)
+}
+
+// insertTransactions saves chunk with a single INSERT. Postgres does not
+// promise RETURNING rows come back in VALUES order, so the IDs are reserved
+// from the sequence first and written explicitly.
+func insertTransactions(ctx context.Context, tx *sql.Tx, chunk []*domain.Transaction) error {
+	if err := reserveTransactionIDs(ctx, tx, chunk); err != nil {
+		return err
+	}
+
+	const columns = transactionColumns + 1
+	values := make([]string, len(chunk))
+	args := make([]any, 0, len(chunk)*columns)
+	placeholders := make([]string, columns)
+	for i, t := range chunk {
+		for k := range placeholders {
+			placeholders[k] = fmt.Sprintf("$%d", i*columns+k+1)
+		}
+		values[i] = "(" + strings.Join(placeholders, ", ") + ")"
+		args = append(append(args, t.ID), transactionArgs(t)...)
+	}
+	stmt := `INSERT INTO transactions (transaction_id, account_id, operation_type_id, amount, event_date,
+				currency, original_currency, original_amount, fx_rate, fx_rate_at) VALUES ` +
+		strings.Join(values, ", ")
+
+	_, err := tx.ExecContext(ctx, stmt, args...)
+	return err
+}
+
+// reserveTransactionIDs takes one ID per transaction from the sequence
+// behind transactions.transaction_id.
+func reserveTransactionIDs(ctx context.Context, tx *sql.Tx, chunk []*domain.Transaction) error {
+	stmt := `SELECT nextval('transactions_transaction_id_seq') FROM generate_series(1, $1)`
+
+	rows, err := tx.QueryContext(ctx, stmt, len(chunk))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	i := 0
+	for ; rows.Next(); i++ {
+		if i == len(chunk) {
+			return fmt.Errorf("reserved more than %d transaction ids", len(chunk))
+		}
+		if err := rows.Scan(&chunk[i].ID); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if i != len(chunk) {
+		return fmt.Errorf("reserved %d transaction ids, want %d", i, len(chunk))
+	}
+	return nil
+}
+
+// postEntries books the journal entries of saved transactions. Each
+// statement sends its rows as arrays, so the parameter count stays fixed
+// however large the batch.
+func postEntries(ctx context.Context, tx *sql.Tx, saved []*domain.Transaction) error {
+	var (
+		ids        = make([]int64, 0, len(saved))
+		entries    []int64
+		ledgers    []string
+		accounts   []int64
+		directions []string
+		amounts    []float64
+	)
+	for _, t := range saved {
+		entry, err := t.JournalEntry()
+		if err != nil {
+			return err
+		}
+		if err := entry.Validate(); err != nil {
+			return err
+		}
+		ids = append(ids, t.ID)
+		for _, posting := range entry.Postings {
+			entries = append(entries, t.ID)
+			ledgers = append(ledgers, string(posting.Ledger))
+			accounts = append(accounts, posting.AccountID)
+			directions = append(directions, string(posting.Direction))
+			amounts = append(amounts, posting.Amount)
+		}
+	}
+
+	stmt := `INSERT INTO journal_entries (transaction_id, posted_at, currency)
+			SELECT transaction_id, event_date, currency FROM transactions WHERE transaction_id = ANY($1)`
+	if _, err := tx.ExecContext(ctx, stmt, pq.Array(ids)); err != nil {
+		return err
+	}
+
+	stmt = `INSERT INTO ledger_postings (transaction_id, ledger_account, account_id, direction, amount)
+			SELECT p.transaction_id, p.ledger_account, NULLIF(p.account_id, 0), p.direction, p.amount
+			FROM unnest($1::int[], $2::text[], $3::int[], $4::text[], $5::numeric[])
+				AS p (transaction_id, ledger_account, account_id, direction, amount)`
+	_, err := tx.ExecContext(ctx, stmt,
+		pq.Array(entries), pq.Array(ledgers), pq.Array(accounts), pq.Array(directions), pq.Array(amounts))
+	return err
+}
+
 func (p *PostgresTransactionRepository) FindByTransactionID(ctx context.Context, transactionID int64) (*domain.Transaction, error) {
-	stmt := `SELECT transaction_id, account_id, operation_type_id, amount FROM transactions WHERE transaction_id = $1`
+	stmt := `SELECT transaction_id, account_id, operation_type_id, amount, ` + conversionColumns + `
+			FROM transactions WHERE transaction_id = $1`
 
-	var tx domain.Transaction
-	err := p.db.QueryRowContext(ctx, stmt, transactionID).Scan(
+	var (
+		tx   domain.Transaction
+		conv conversionRow
+	)
+	err := p.db.QueryRowContext(ctx, stmt, transactionID).Scan(append([]any{
 		&tx.ID,
 		&tx.AccountID,
 		&tx.OperationTypeID,
 		&tx.Amount,
-	)
+	}, conv.dest(&tx)...)...)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -59,5 +226,99 @@ func (p *PostgresTransactionRepository) FindByTransactionID(ctx context.Context,
 		}
 		return nil, fmt.Errorf("infrastructure error: failed to find transaction: %w", err)
 	}
+	tx.Conversion = conv.conversion()
 	return &tx, nil
 }
+
+func (p *PostgresTransactionRepository) StreamByAccount(
+	ctx context.Context,
+	accountID int64,
+	from, to time.Time,
+	fn func(*domain.Transaction) error,
+) error {
+	stmt := `SELECT transaction_id, account_id, operation_type_id, amount, event_date, ` + conversionColumns + `
+			FROM transactions
+			WHERE account_id = $1
+			AND ($2::timestamptz IS NULL OR event_date >= $2)
+			AND ($3::timestamptz IS NULL OR event_date < $3)
+			ORDER BY event_date, transaction_id`
+
+	rows, err := p.db.QueryContext(ctx, stmt, accountID, nullTime(from), nullTime(to))
+	if err != nil {
+		return fmt.Errorf("infrastructure error: failed to stream transactions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			tx   domain.Transaction
+			conv conversionRow
+		)
+		dest := append([]any{&tx.ID, &tx.AccountID, &tx.OperationTypeID, &tx.Amount, &tx.EventDate}, conv.dest(&tx)...)
+		if err := rows.Scan(dest...); err != nil {
+			return fmt.Errorf("infrastructure error: failed to scan transaction: %w", err)
+		}
+		tx.Conversion = conv.conversion()
+		if err := fn(&tx); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("infrastructure error: failed to stream transactions: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresTransactionRepository) SumByCurrency(ctx context.Context, accountID int64) ([]domain.CurrencyBalance, error) {
+	query := `SELECT COALESCE(original_currency, currency), SUM(COALESCE(original_amount, amount)), SUM(amount)
+			FROM transactions WHERE account_id = $1 GROUP BY 1 ORDER BY 1`
+
+	rows, err := p.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to sum transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var balances []domain.CurrencyBalance
+	for rows.Next() {
+		var b domain.CurrencyBalance
+		if err := rows.Scan(&b.Currency, &b.Amount, &b.Booked); err != nil {
+			return nil, fmt.Errorf("infrastructure error: failed to scan balance: %w", err)
+		}
+		balances = append(balances, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("infrastructure error: failed to sum transactions: %w", err)
+	}
+	return balances, nil
+}
+
+// conversionColumns are read into a conversionRow.
+const conversionColumns = `currency, original_currency, original_amount, fx_rate, fx_rate_at`
+
+type conversionRow struct {
+	currency sql.NullString
+	amount   sql.NullFloat64
+	rate     sql.NullFloat64
+	rateAt   sql.NullTime
+}
+
+func (c *conversionRow) dest(tx *domain.Transaction) []any {
+	return []any{&tx.Currency, &c.currency, &c.amount, &c.rate, &c.rateAt}
+}
+
+func (c *conversionRow) conversion() *domain.Conversion {
+	if !c.currency.Valid {
+		return nil
+	}
+	return &domain.Conversion{
+		Currency: domain.Currency(c.currency.String),
+		Amount:   c.amount.Float64,
+		Rate:     c.rate.Float64,
+		RateAt:   c.rateAt.Time,
+	}
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
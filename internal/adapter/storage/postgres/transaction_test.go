@@ -31,22 +31,42 @@ func TestPostgresTransactionRepository(t *testing.T) {
 			OperationTypeID: 4,
 			Amount:          123.45,
 			EventDate:       time.Now(),
+			Currency:        "BRL",
+			Conversion:      &domain.Conversion{Currency: "USD", Amount: 24.69, Rate: 5, RateAt: time.Now()},
 		}
 
+		mock.ExpectBegin()
 		mock.ExpectQuery("INSERT INTO transactions").
-			WithArgs(tx.AccountID, tx.OperationTypeID, tx.Amount, tx.EventDate).
+			WithArgs(tx.AccountID, tx.OperationTypeID, tx.Amount, tx.EventDate,
+				tx.Currency, tx.Conversion.Currency, tx.Conversion.Amount, tx.Conversion.Rate, tx.Conversion.RateAt).
 			WillReturnRows(sqlmock.NewRows([]string{"transaction_id"}).AddRow(100))
+		mock.ExpectExec("INSERT INTO journal_entries").
+			WithArgs(pq.Array([]int64{100})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO ledger_postings").
+			WithArgs(
+				pq.Array([]int64{100, 100}),
+				pq.Array([]string{"cash", "customer"}),
+				pq.Array([]int64{0, 1}),
+				pq.Array([]string{"debit", "credit"}),
+				pq.Array([]float64{123.45, 123.45}),
+			).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
 
 		result, err := repo.Save(ctx, tx)
 
 		assert.NoError(t, err)
 		assert.Equal(t, int64(100), result.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("Save - Foreign Key Violation (23503)", func(t *testing.T) {
 		tx := &domain.Transaction{AccountID: 999}
+		mock.ExpectBegin()
 		mock.ExpectQuery("INSERT INTO transactions").
 			WillReturnError(&pq.Error{Code: "23503"})
+		mock.ExpectRollback()
 
 		result, err := repo.Save(ctx, tx)
 
@@ -56,8 +76,10 @@ func TestPostgresTransactionRepository(t *testing.T) {
 
 	t.Run("Save - Generic Error", func(t *testing.T) {
 		tx := &domain.Transaction{AccountID: 1}
+		mock.ExpectBegin()
 		mock.ExpectQuery("INSERT INTO transactions").
 			WillReturnError(errors.New("db connection lost"))
+		mock.ExpectRollback()
 
 		result, err := repo.Save(ctx, tx)
 
@@ -66,17 +88,48 @@ func TestPostgresTransactionRepository(t *testing.T) {
 		assert.Nil(t, result)
 	})
 
+	t.Run("Save - Booking Error Rolls Back", func(t *testing.T) {
+		tx := &domain.Transaction{AccountID: 1, OperationTypeID: 1, Amount: -10, EventDate: time.Now()}
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO transactions").
+			WillReturnRows(sqlmock.NewRows([]string{"transaction_id"}).AddRow(100))
+		mock.ExpectExec("INSERT INTO journal_entries").
+			WillReturnError(errors.New("db connection lost"))
+		mock.ExpectRollback()
+
+		result, err := repo.Save(ctx, tx)
+
+		assert.ErrorContains(t, err, "failed to book transaction")
+		assert.Nil(t, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("FindByTransactionID - Success", func(t *testing.T) {
 		mock.ExpectQuery("SELECT (.+) FROM transactions").
 			WithArgs(int64(100)).
-			WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "operation_type_id", "amount"}).
-				AddRow(100, 1, 4, 123.45))
+			WillReturnRows(sqlmock.NewRows(findColumns).
+				AddRow(100, 1, 4, 123.45, "BRL", nil, nil, nil, nil))
 
 		result, err := repo.FindByTransactionID(ctx, 100)
 
 		assert.NoError(t, err)
 		assert.Equal(t, int64(100), result.ID)
 		assert.Equal(t, 123.45, result.Amount)
+		assert.Equal(t, domain.Currency("BRL"), result.Currency)
+		assert.Nil(t, result.Conversion)
+	})
+
+	t.Run("FindByTransactionID - Converted", func(t *testing.T) {
+		rateAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery("SELECT (.+) FROM transactions").
+			WithArgs(int64(100)).
+			WillReturnRows(sqlmock.NewRows(findColumns).
+				AddRow(100, 1, 4, 50.0, "BRL", "USD", 10.0, 5.0, rateAt))
+
+		result, err := repo.FindByTransactionID(ctx, 100)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.Conversion{Currency: "USD", Amount: 10, Rate: 5, RateAt: rateAt}, result.Conversion)
 	})
 
 	t.Run("FindByTransactionID - Not Found", func(t *testing.T) {
@@ -101,4 +154,188 @@ func TestPostgresTransactionRepository(t *testing.T) {
 		assert.Contains(t, err.Error(), "infrastructure error")
 		assert.Nil(t, result)
 	})
+
+	t.Run("StreamByAccount - Success", func(t *testing.T) {
+		from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery("FROM transactions(.+)ORDER BY event_date, transaction_id").
+			WithArgs(int64(1), sql.NullTime{Time: from, Valid: true}, sql.NullTime{}).
+			WillReturnRows(sqlmock.NewRows(streamColumns).
+				AddRow(10, 1, 1, -50.0, from.Add(time.Hour), "BRL", nil, nil, nil, nil).
+				AddRow(11, 1, 4, 20.0, from.Add(2*time.Hour), "BRL", nil, nil, nil, nil))
+
+		var ids []int64
+		err := repo.StreamByAccount(ctx, 1, from, time.Time{}, func(tx *domain.Transaction) error {
+			ids = append(ids, tx.ID)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{10, 11}, ids)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("StreamByAccount - Callback Error Stops", func(t *testing.T) {
+		stop := errors.New("client gone")
+		mock.ExpectQuery("FROM transactions").
+			WillReturnRows(sqlmock.NewRows(streamColumns).
+				AddRow(10, 1, 1, -50.0, time.Now(), "BRL", nil, nil, nil, nil).
+				AddRow(11, 1, 1, -20.0, time.Now(), "BRL", nil, nil, nil, nil))
+
+		calls := 0
+		err := repo.StreamByAccount(ctx, 1, time.Time{}, time.Time{}, func(*domain.Transaction) error {
+			calls++
+			return stop
+		})
+
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("SaveBatch - Success", func(t *testing.T) {
+		now := time.Now()
+		txs := []*domain.Transaction{
+			{AccountID: 1, OperationTypeID: 1, Amount: -10, EventDate: now, Currency: "BRL"},
+			{AccountID: 2, OperationTypeID: 4, Amount: 25, EventDate: now, Currency: "USD"},
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT nextval\\('transactions_transaction_id_seq'\\) FROM generate_series\\(1, \\$1\\)").
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(100).AddRow(101))
+		mock.ExpectExec("INSERT INTO transactions \\(transaction_id, (.+) VALUES \\(\\$1, (.+), \\$10\\), \\(\\$11, (.+), \\$20\\)$").
+			WithArgs(
+				int64(100), int64(1), domain.OperationType(1), -10.0, now, domain.Currency("BRL"), nil, nil, nil, nil,
+				int64(101), int64(2), domain.OperationType(4), 25.0, now, domain.Currency("USD"), nil, nil, nil, nil,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("INSERT INTO journal_entries").
+			WithArgs(pq.Array([]int64{100, 101})).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("INSERT INTO ledger_postings").
+			WithArgs(
+				pq.Array([]int64{100, 100, 101, 101}),
+				pq.Array([]string{"customer", "merchant_settlement", "cash", "customer"}),
+				pq.Array([]int64{1, 0, 0, 2}),
+				pq.Array([]string{"debit", "credit", "debit", "credit"}),
+				pq.Array([]float64{10, 10, 25, 25}),
+			).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		mock.ExpectCommit()
+
+		err := repo.SaveBatch(ctx, txs)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), txs[0].ID)
+		assert.Equal(t, int64(101), txs[1].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SaveBatch - Reserved IDs Follow Each Row", func(t *testing.T) {
+		now := time.Now()
+		txs := []*domain.Transaction{
+			{AccountID: 7, OperationTypeID: 4, Amount: 5, EventDate: now, Currency: "BRL"},
+			{AccountID: 3, OperationTypeID: 4, Amount: 8, EventDate: now, Currency: "BRL"},
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT nextval").
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(42).AddRow(17))
+		mock.ExpectExec("INSERT INTO transactions").
+			WithArgs(
+				int64(42), int64(7), domain.OperationType(4), 5.0, now, domain.Currency("BRL"), nil, nil, nil, nil,
+				int64(17), int64(3), domain.OperationType(4), 8.0, now, domain.Currency("BRL"), nil, nil, nil, nil,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("INSERT INTO journal_entries").
+			WithArgs(pq.Array([]int64{42, 17})).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("INSERT INTO ledger_postings").
+			WithArgs(
+				pq.Array([]int64{42, 42, 17, 17}),
+				pq.Array([]string{"cash", "customer", "cash", "customer"}),
+				pq.Array([]int64{0, 7, 0, 3}),
+				pq.Array([]string{"debit", "credit", "debit", "credit"}),
+				pq.Array([]float64{5, 5, 8, 8}),
+			).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		mock.ExpectCommit()
+
+		err := repo.SaveBatch(ctx, txs)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), txs[0].ID)
+		assert.Equal(t, int64(17), txs[1].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SaveBatch - Sequence Returns Too Few IDs", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT nextval").
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(1))
+		mock.ExpectRollback()
+
+		err := repo.SaveBatch(ctx, []*domain.Transaction{{AccountID: 1}, {AccountID: 2}})
+
+		assert.ErrorContains(t, err, "reserved 1 transaction ids, want 2")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SaveBatch - Foreign Key Violation (23503)", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT nextval").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(1))
+		mock.ExpectExec("INSERT INTO transactions").
+			WillReturnError(&pq.Error{Code: "23503"})
+		mock.ExpectRollback()
+
+		err := repo.SaveBatch(ctx, []*domain.Transaction{{AccountID: 999}})
+
+		assert.ErrorIs(t, err, common.ErrAccountNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SaveBatch - Empty", func(t *testing.T) {
+		assert.NoError(t, repo.SaveBatch(ctx, nil))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SumByCurrency - Success", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COALESCE\\(original_currency, currency\\)(.+)GROUP BY 1").
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"currency", "amount", "booked"}).
+				AddRow("BRL", -30.0, -30.0).
+				AddRow("USD", -10.0, -50.0))
+
+		balances, err := repo.SumByCurrency(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.CurrencyBalance{
+			{Currency: "BRL", Amount: -30, Booked: -30},
+			{Currency: "USD", Amount: -10, Booked: -50},
+		}, balances)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SumByCurrency - Generic Error", func(t *testing.T) {
+		mock.ExpectQuery("FROM transactions").
+			WithArgs(int64(1)).
+			WillReturnError(errors.New("timeout"))
+
+		balances, err := repo.SumByCurrency(ctx, 1)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "infrastructure error")
+		assert.Nil(t, balances)
+	})
+}
+
+var streamColumns = []string{
+	"transaction_id", "account_id", "operation_type_id", "amount", "event_date",
+	"currency", "original_currency", "original_amount", "fx_rate", "fx_rate_at",
+}
+
+var findColumns = []string{
+	"transaction_id", "account_id", "operation_type_id", "amount",
+	"currency", "original_currency", "original_amount", "fx_rate", "fx_rate_at",
 }
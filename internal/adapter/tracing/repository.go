@@ -0,0 +1,283 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+)
+
+// statement describes a repository query by name only; bound parameters such
+// as document numbers never reach the span.
+func statement(name, operation, table string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.DBSystemNamePostgreSQL,
+		semconv.DBOperationName(operation),
+		semconv.DBCollectionName(table),
+		semconv.DBQuerySummary(operation + " " + table),
+		attribute.String("db.statement.name", name),
+	}
+}
+
+type accountRepository struct {
+	next port.AccountRepository
+}
+
+func NewAccountRepository(next port.AccountRepository) port.AccountRepository {
+	return &accountRepository{next: next}
+}
+
+func (r *accountRepository) Save(ctx context.Context, account *domain.Account, owner string) (*domain.Account, error) {
+	return run(ctx, "AccountRepository.Save", statement("insert_account", "INSERT", "accounts"),
+		func(ctx context.Context) (*domain.Account, error) { return r.next.Save(ctx, account, owner) })
+}
+
+func (r *accountRepository) SaveBatch(ctx context.Context, accounts []*domain.Account, owner string) ([]bool, error) {
+	attrs := append(statement("insert_accounts", "INSERT", "accounts"), semconv.DBOperationBatchSize(len(accounts)))
+	return run(ctx, "AccountRepository.SaveBatch", attrs,
+		func(ctx context.Context) ([]bool, error) { return r.next.SaveBatch(ctx, accounts, owner) })
+}
+
+func (r *accountRepository) FindByDocument(ctx context.Context, documentNumber string) (*domain.Account, error) {
+	return run(ctx, "AccountRepository.FindByDocument", statement("find_account_by_document", "SELECT", "accounts"),
+		func(ctx context.Context) (*domain.Account, error) { return r.next.FindByDocument(ctx, documentNumber) })
+}
+
+func (r *accountRepository) FindByAccountID(ctx context.Context, accountID int64) (*domain.Account, error) {
+	return run(ctx, "AccountRepository.FindByAccountID", statement("find_account_by_id", "SELECT", "accounts"),
+		func(ctx context.Context) (*domain.Account, error) { return r.next.FindByAccountID(ctx, accountID) })
+}
+
+type transactionRepository struct {
+	next port.TransactionRepository
+}
+
+func NewTransactionRepository(next port.TransactionRepository) port.TransactionRepository {
+	return &transactionRepository{next: next}
+}
+
+func (r *transactionRepository) Save(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, error) {
+	return run(ctx, "TransactionRepository.Save", statement("insert_transaction", "INSERT", "transactions"),
+		func(ctx context.Context) (*domain.Transaction, error) { return r.next.Save(ctx, transaction) })
+}
+
+func (r *transactionRepository) SaveBatch(ctx context.Context, transactions []*domain.Transaction) error {
+	attrs := append(statement("insert_transactions", "INSERT", "transactions"), semconv.DBOperationBatchSize(len(transactions)))
+	return runErr(ctx, "TransactionRepository.SaveBatch", attrs,
+		func(ctx context.Context) error { return r.next.SaveBatch(ctx, transactions) })
+}
+
+func (r *transactionRepository) FindByTransactionID(ctx context.Context, transactionID int64) (*domain.Transaction, error) {
+	return run(ctx, "TransactionRepository.FindByTransactionID", statement("find_transaction_by_id", "SELECT", "transactions"),
+		func(ctx context.Context) (*domain.Transaction, error) {
+			return r.next.FindByTransactionID(ctx, transactionID)
+		})
+}
+
+func (r *transactionRepository) SumByCurrency(ctx context.Context, accountID int64) ([]domain.CurrencyBalance, error) {
+	return run(ctx, "TransactionRepository.SumByCurrency", statement("sum_transactions_by_currency", "SELECT", "transactions"),
+		func(ctx context.Context) ([]domain.CurrencyBalance, error) {
+			return r.next.SumByCurrency(ctx, accountID)
+		})
+}
+
+func (r *transactionRepository) StreamByAccount(
+	ctx context.Context,
+	accountID int64,
+	from, to time.Time,
+	fn func(*domain.Transaction) error,
+) error {
+	return runErr(ctx, "TransactionRepository.StreamByAccount", statement("stream_transactions_by_account", "SELECT", "transactions"),
+		func(ctx context.Context) error { return r.next.StreamByAccount(ctx, accountID, from, to, fn) })
+}
+
+type operationRepository struct {
+	next port.OperationRepository
+}
+
+func NewOperationRepository(next port.OperationRepository) port.OperationRepository {
+	return &operationRepository{next: next}
+}
+
+func (r *operationRepository) Exists(ctx context.Context, operationType int16) (bool, error) {
+	return run(ctx, "OperationRepository.Exists", statement("operation_type_exists", "SELECT", "operations_types"),
+		func(ctx context.Context) (bool, error) { return r.next.Exists(ctx, operationType) })
+}
+
+type ownershipRepository struct {
+	next port.AccountOwnershipRepository
+}
+
+func NewAccountOwnershipRepository(next port.AccountOwnershipRepository) port.AccountOwnershipRepository {
+	return &ownershipRepository{next: next}
+}
+
+func (r *ownershipRepository) IsOwner(ctx context.Context, subject string, accountID int64) (bool, error) {
+	return run(ctx, "AccountOwnershipRepository.IsOwner", statement("account_owner_exists", "SELECT", "account_owners"),
+		func(ctx context.Context) (bool, error) { return r.next.IsOwner(ctx, subject, accountID) })
+}
+
+type statementRepository struct {
+	next port.StatementRepository
+}
+
+func NewStatementRepository(next port.StatementRepository) port.StatementRepository {
+	return &statementRepository{next: next}
+}
+
+func (r *statementRepository) BillingDay(ctx context.Context, accountID int64) (int, error) {
+	return run(ctx, "StatementRepository.BillingDay", statement("find_billing_cycle", "SELECT", "billing_cycles"),
+		func(ctx context.Context) (int, error) { return r.next.BillingDay(ctx, accountID) })
+}
+
+func (r *statementRepository) SaveBillingCycle(ctx context.Context, cycle *domain.BillingCycle) error {
+	return runErr(ctx, "StatementRepository.SaveBillingCycle", statement("upsert_billing_cycle", "INSERT", "billing_cycles"),
+		func(ctx context.Context) error { return r.next.SaveBillingCycle(ctx, cycle) })
+}
+
+func (r *statementRepository) CycleStates(ctx context.Context) ([]domain.CycleState, error) {
+	return run(ctx, "StatementRepository.CycleStates", statement("list_billing_cycles", "SELECT", "accounts"),
+		func(ctx context.Context) ([]domain.CycleState, error) { return r.next.CycleStates(ctx) })
+}
+
+func (r *statementRepository) Close(ctx context.Context, accountID int64, periodStart, periodEnd time.Time, policy domain.StatementPolicy) (*domain.Statement, error) {
+	return run(ctx, "StatementRepository.Close", statement("close_statement", "INSERT", "statements"),
+		func(ctx context.Context) (*domain.Statement, error) {
+			return r.next.Close(ctx, accountID, periodStart, periodEnd, policy)
+		})
+}
+
+func (r *statementRepository) AllocatePayments(ctx context.Context, accountID int64) ([]domain.PaymentAllocation, error) {
+	return run(ctx, "StatementRepository.AllocatePayments", statement("allocate_payments", "INSERT", "statement_payments"),
+		func(ctx context.Context) ([]domain.PaymentAllocation, error) {
+			return r.next.AllocatePayments(ctx, accountID)
+		})
+}
+
+func (r *statementRepository) ListByAccount(ctx context.Context, accountID int64) ([]*domain.Statement, error) {
+	return run(ctx, "StatementRepository.ListByAccount", statement("list_statements", "SELECT", "statements"),
+		func(ctx context.Context) ([]*domain.Statement, error) { return r.next.ListByAccount(ctx, accountID) })
+}
+
+func (r *statementRepository) FindByID(ctx context.Context, statementID int64) (*domain.Statement, error) {
+	return run(ctx, "StatementRepository.FindByID", statement("find_statement_by_id", "SELECT", "statements"),
+		func(ctx context.Context) (*domain.Statement, error) { return r.next.FindByID(ctx, statementID) })
+}
+
+type apiKeyRepository struct {
+	next port.APIKeyRepository
+}
+
+func NewAPIKeyRepository(next port.APIKeyRepository) port.APIKeyRepository {
+	return &apiKeyRepository{next: next}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *domain.APIKey, hash string) error {
+	return runErr(ctx, "APIKeyRepository.Create", statement("insert_api_key", "INSERT", "api_keys"),
+		func(ctx context.Context) error { return r.next.Create(ctx, key, hash) })
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	return run(ctx, "APIKeyRepository.FindByHash", statement("find_api_key", "SELECT", "api_keys"),
+		func(ctx context.Context) (*domain.APIKey, error) { return r.next.FindByHash(ctx, hash) })
+}
+
+func (r *apiKeyRepository) List(ctx context.Context) ([]*domain.APIKey, error) {
+	return run(ctx, "APIKeyRepository.List", statement("list_api_keys", "SELECT", "api_keys"),
+		func(ctx context.Context) ([]*domain.APIKey, error) { return r.next.List(ctx) })
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, keyID int64) error {
+	return runErr(ctx, "APIKeyRepository.Revoke", statement("revoke_api_key", "UPDATE", "api_keys"),
+		func(ctx context.Context) error { return r.next.Revoke(ctx, keyID) })
+}
+
+type reconciliationRepository struct {
+	next port.ReconciliationRepository
+}
+
+func NewReconciliationRepository(next port.ReconciliationRepository) port.ReconciliationRepository {
+	return &reconciliationRepository{next: next}
+}
+
+func (r *reconciliationRepository) ScanBalances(ctx context.Context, afterID int64, limit int) ([]domain.BalanceCheck, error) {
+	return run(ctx, "ReconciliationRepository.ScanBalances", statement("scan_balances", "SELECT", "accounts"),
+		func(ctx context.Context) ([]domain.BalanceCheck, error) {
+			return r.next.ScanBalances(ctx, afterID, limit)
+		})
+}
+
+func (r *reconciliationRepository) RepairBalance(ctx context.Context, accountID int64) (domain.BalanceCheck, bool, error) {
+	type repair struct {
+		check    domain.BalanceCheck
+		repaired bool
+	}
+	res, err := run(ctx, "ReconciliationRepository.RepairBalance", statement("repair_balance", "UPDATE", "accounts"),
+		func(ctx context.Context) (repair, error) {
+			check, repaired, err := r.next.RepairBalance(ctx, accountID)
+			return repair{check: check, repaired: repaired}, err
+		})
+	return res.check, res.repaired, err
+}
+
+func (r *reconciliationRepository) SaveReport(ctx context.Context, report *domain.ReconciliationReport) error {
+	return runErr(ctx, "ReconciliationRepository.SaveReport", statement("insert_reconciliation_report", "INSERT", "reconciliation_reports"),
+		func(ctx context.Context) error { return r.next.SaveReport(ctx, report) })
+}
+
+type ledgerRepository struct {
+	next port.LedgerRepository
+}
+
+func NewLedgerRepository(next port.LedgerRepository) port.LedgerRepository {
+	return &ledgerRepository{next: next}
+}
+
+func (r *ledgerRepository) FindEntry(ctx context.Context, transactionID int64) (*domain.JournalEntry, error) {
+	return run(ctx, "LedgerRepository.FindEntry", statement("select_journal_entry", "SELECT", "ledger_postings"),
+		func(ctx context.Context) (*domain.JournalEntry, error) { return r.next.FindEntry(ctx, transactionID) })
+}
+
+func (r *ledgerRepository) TrialBalance(ctx context.Context) (*domain.TrialBalance, error) {
+	return run(ctx, "LedgerRepository.TrialBalance", statement("trial_balance", "SELECT", "ledger_postings"),
+		func(ctx context.Context) (*domain.TrialBalance, error) { return r.next.TrialBalance(ctx) })
+}
+
+type importJobRepository struct {
+	next port.ImportJobRepository
+}
+
+func NewImportJobRepository(next port.ImportJobRepository) port.ImportJobRepository {
+	return &importJobRepository{next: next}
+}
+
+func (r *importJobRepository) Create(ctx context.Context, job *domain.ImportJob, rows []domain.ImportRow) (*domain.ImportJob, error) {
+	return run(ctx, "ImportJobRepository.Create", statement("insert_import_job", "INSERT", "import_jobs"),
+		func(ctx context.Context) (*domain.ImportJob, error) { return r.next.Create(ctx, job, rows) })
+}
+
+func (r *importJobRepository) FindByID(ctx context.Context, jobID int64) (*domain.ImportJob, error) {
+	return run(ctx, "ImportJobRepository.FindByID", statement("find_import_job", "SELECT", "import_jobs"),
+		func(ctx context.Context) (*domain.ImportJob, error) { return r.next.FindByID(ctx, jobID) })
+}
+
+func (r *importJobRepository) Claim(ctx context.Context, staleBefore time.Time) (*domain.ImportJob, []domain.ImportRow, error) {
+	type claimed struct {
+		job  *domain.ImportJob
+		rows []domain.ImportRow
+	}
+	c, err := run(ctx, "ImportJobRepository.Claim", statement("claim_import_job", "UPDATE", "import_jobs"),
+		func(ctx context.Context) (claimed, error) {
+			job, rows, err := r.next.Claim(ctx, staleBefore)
+			return claimed{job: job, rows: rows}, err
+		})
+	return c.job, c.rows, err
+}
+
+func (r *importJobRepository) Finish(ctx context.Context, job *domain.ImportJob) error {
+	return runErr(ctx, "ImportJobRepository.Finish", statement("finish_import_job", "UPDATE", "import_jobs"),
+		func(ctx context.Context) error { return r.next.Finish(ctx, job) })
+}
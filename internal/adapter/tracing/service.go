@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type accountService struct {
+	next port.AccountService
+}
+
+func NewAccountService(next port.AccountService) port.AccountService {
+	return &accountService{next: next}
+}
+
+func (s *accountService) CreateAccount(ctx context.Context, documentNumber string, currency domain.Currency) (*domain.Account, error) {
+	return run(ctx, "AccountService.CreateAccount", nil,
+		func(ctx context.Context) (*domain.Account, error) {
+			return s.next.CreateAccount(ctx, documentNumber, currency)
+		})
+}
+
+func (s *accountService) CreateAccounts(ctx context.Context, documentNumbers []string, currency domain.Currency) (*domain.AccountBatchReport, error) {
+	return run(ctx, "AccountService.CreateAccounts", []attribute.KeyValue{attribute.Int("accounts.batch_size", len(documentNumbers))},
+		func(ctx context.Context) (*domain.AccountBatchReport, error) {
+			return s.next.CreateAccounts(ctx, documentNumbers, currency)
+		})
+}
+
+func (s *accountService) GetAccountByDocument(ctx context.Context, documentNumber string) (*domain.Account, error) {
+	return run(ctx, "AccountService.GetAccountByDocument", nil,
+		func(ctx context.Context) (*domain.Account, error) {
+			return s.next.GetAccountByDocument(ctx, documentNumber)
+		})
+}
+
+func (s *accountService) GetAccountByID(ctx context.Context, accountID int64) (*domain.Account, error) {
+	return run(ctx, "AccountService.GetAccountByID", []attribute.KeyValue{attribute.Int64("account.id", accountID)},
+		func(ctx context.Context) (*domain.Account, error) { return s.next.GetAccountByID(ctx, accountID) })
+}
+
+type transactionService struct {
+	next port.TransactionService
+}
+
+func NewTransactionService(next port.TransactionService) port.TransactionService {
+	return &transactionService{next: next}
+}
+
+func (s *transactionService) CreateTransaction(ctx context.Context, accountID int64, operationType int16, amount float64, currency domain.Currency) (*domain.Transaction, error) {
+	attrs := []attribute.KeyValue{
+		attribute.Int64("account.id", accountID),
+		attribute.Int("transaction.operation_type", int(operationType)),
+	}
+	return run(ctx, "TransactionService.CreateTransaction", attrs,
+		func(ctx context.Context) (*domain.Transaction, error) {
+			return s.next.CreateTransaction(ctx, accountID, operationType, amount, currency)
+		})
+}
+
+func (s *transactionService) GetByTransactionID(ctx context.Context, transactionID int64) (*domain.Transaction, error) {
+	return run(ctx, "TransactionService.GetByTransactionID", []attribute.KeyValue{attribute.Int64("transaction.id", transactionID)},
+		func(ctx context.Context) (*domain.Transaction, error) {
+			return s.next.GetByTransactionID(ctx, transactionID)
+		})
+}
+
+func (s *transactionService) ExportTransactions(
+	ctx context.Context,
+	accountID int64,
+	from, to time.Time,
+	start func(*domain.Account) error,
+	fn func(*domain.Transaction) error,
+) error {
+	return runErr(ctx, "TransactionService.ExportTransactions", []attribute.KeyValue{attribute.Int64("account.id", accountID)},
+		func(ctx context.Context) error { return s.next.ExportTransactions(ctx, accountID, from, to, start, fn) })
+}
+
+func (s *transactionService) GetBalances(ctx context.Context, accountID int64) (*domain.AccountBalances, error) {
+	return run(ctx, "TransactionService.GetBalances", []attribute.KeyValue{attribute.Int64("account.id", accountID)},
+		func(ctx context.Context) (*domain.AccountBalances, error) { return s.next.GetBalances(ctx, accountID) })
+}
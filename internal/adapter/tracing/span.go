@@ -0,0 +1,30 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// run wraps fn in an internal span named name, recording a returned error.
+func run[T any](ctx context.Context, name string, attrs []attribute.KeyValue, fn func(context.Context) (T, error)) (T, error) {
+	ctx, span := tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+	defer span.End()
+
+	result, err := fn(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+// runErr is run for methods that only return an error.
+func runErr(ctx context.Context, name string, attrs []attribute.KeyValue, fn func(context.Context) error) error {
+	_, err := run(ctx, name, attrs, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
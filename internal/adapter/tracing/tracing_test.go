@@ -0,0 +1,203 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evythrossell/account-management-api/internal/adapter/tracing"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type MockAccountRepository struct{ mock.Mock }
+
+func (m *MockAccountRepository) Save(ctx context.Context, acc *domain.Account, owner string) (*domain.Account, error) {
+	args := m.Called(ctx, acc, owner)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) SaveBatch(ctx context.Context, accounts []*domain.Account, owner string) ([]bool, error) {
+	args := m.Called(ctx, accounts, owner)
+	created, _ := args.Get(0).([]bool)
+	return created, args.Error(1)
+}
+
+func (m *MockAccountRepository) FindByDocument(ctx context.Context, doc string) (*domain.Account, error) {
+	args := m.Called(ctx, doc)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) FindByAccountID(ctx context.Context, id int64) (*domain.Account, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Account), args.Error(1)
+}
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	_, err := tracing.Setup(context.Background(), tracing.Config{Exporter: tracing.ExporterNone}, io.Discard)
+	require.NoError(t, err)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func attr(span sdktrace.ReadOnlySpan, key string) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := setupRecorder(t)
+
+	var logs bytes.Buffer
+	base := common.NewSlogLogger(&logs, common.InfoLevel, common.JSONFormat)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(common.WithLogger(c.Request.Context(), base))
+	})
+	r.Use(tracing.Middleware())
+	r.GET("/v1/accounts/:accountId", func(c *gin.Context) {
+		common.FromContext(c.Request.Context()).Info("handled")
+		c.Status(http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest("GET", "/v1/accounts/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "GET /v1/accounts/:accountId", span.Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+	assert.Equal(t, "/v1/accounts/:accountId", attr(span, "http.route").AsString())
+	assert.Equal(t, int64(500), attr(span, "http.response.status_code").AsInt64())
+	assert.Equal(t, codes.Error, span.Status().Code)
+
+	assert.Contains(t, logs.String(), `"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"`)
+	assert.Contains(t, logs.String(), `"span_id":"`+span.SpanContext().SpanID().String()+`"`)
+}
+
+func TestAccountRepository(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	repo := new(MockAccountRepository)
+	repo.On("FindByDocument", mock.Anything, "12345678900").Return(nil, errors.New("db down"))
+
+	_, err := tracing.NewAccountRepository(repo).FindByDocument(context.Background(), "12345678900")
+	assert.EqualError(t, err, "db down")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "AccountRepository.FindByDocument", span.Name())
+	assert.Equal(t, "find_account_by_document", attr(span, "db.statement.name").AsString())
+	assert.Equal(t, "postgresql", attr(span, "db.system.name").AsString())
+	assert.Equal(t, "SELECT accounts", attr(span, "db.query.summary").AsString())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	for _, kv := range span.Attributes() {
+		assert.NotContains(t, kv.Value.Emit(), "12345678900")
+	}
+}
+
+func TestAccountService(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	repo := new(MockAccountRepository)
+	repo.On("FindByAccountID", mock.Anything, int64(7)).Return(&domain.Account{ID: 7}, nil)
+
+	svc := tracing.NewAccountService(&lookupService{repo: tracing.NewAccountRepository(repo)})
+	_, err := svc.GetAccountByID(context.Background(), 7)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "AccountRepository.FindByAccountID", spans[0].Name())
+	assert.Equal(t, "AccountService.GetAccountByID", spans[1].Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, int64(7), attr(spans[1], "account.id").AsInt64())
+}
+
+// lookupService is a minimal port.AccountService that hits the repository,
+// enough to check span nesting.
+type lookupService struct {
+	repo interface {
+		FindByAccountID(ctx context.Context, id int64) (*domain.Account, error)
+	}
+}
+
+func (s *lookupService) CreateAccount(ctx context.Context, documentNumber string, currency domain.Currency) (*domain.Account, error) {
+	return nil, nil
+}
+
+func (s *lookupService) CreateAccounts(ctx context.Context, documentNumbers []string, currency domain.Currency) (*domain.AccountBatchReport, error) {
+	return nil, nil
+}
+
+func (s *lookupService) GetAccountByDocument(ctx context.Context, documentNumber string) (*domain.Account, error) {
+	return nil, nil
+}
+
+func (s *lookupService) GetAccountByID(ctx context.Context, accountID int64) (*domain.Account, error) {
+	return s.repo.FindByAccountID(ctx, accountID)
+}
+
+func TestSetup(t *testing.T) {
+	t.Run("should export spans to stdout writer", func(t *testing.T) {
+		previous := otel.GetTracerProvider()
+		defer otel.SetTracerProvider(previous)
+
+		var out bytes.Buffer
+		shutdown, err := tracing.Setup(context.Background(), tracing.Config{
+			Exporter:    tracing.ExporterStdout,
+			ServiceName: "test",
+			SampleRatio: 1,
+		}, &out)
+		require.NoError(t, err)
+
+		_, span := otel.Tracer("test").Start(context.Background(), "work")
+		span.End()
+		require.NoError(t, shutdown(context.Background()))
+
+		assert.Contains(t, out.String(), `"Name":"work"`)
+	})
+
+	t.Run("should reject unknown exporters", func(t *testing.T) {
+		_, err := tracing.Setup(context.Background(), tracing.Config{Exporter: "zipkin"}, io.Discard)
+		assert.Error(t, err)
+	})
+}
@@ -8,11 +8,13 @@ import (
 )
 
 type Account struct {
-	ID             int64  `json:"account_id"`
-	DocumentNumber string `json:"document_number"`
+	ID             int64    `json:"account_id"`
+	DocumentNumber string   `json:"document_number"`
+	Currency       Currency `json:"currency"`
 }
 
-func NewAccount(docNumber string) (*Account, error) {
+// NewAccount opens an account whose amounts are kept in currency.
+func NewAccount(docNumber string, currency Currency) (*Account, error) {
 	doc := strings.TrimSpace(docNumber)
 	length := utf8.RuneCountInString(doc)
 
@@ -26,6 +28,7 @@ func NewAccount(docNumber string) (*Account, error) {
 
 	return &Account{
 		DocumentNumber: doc,
+		Currency:       currency,
 	}, nil
 }
 
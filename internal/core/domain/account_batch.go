@@ -0,0 +1,49 @@
+package domain
+
+// MaxAccountBatch bounds the document numbers accepted in one batch.
+const MaxAccountBatch = 1000
+
+type AccountBatchStatus string
+
+const (
+	// AccountBatchCreated items opened a new account.
+	AccountBatchCreated AccountBatchStatus = "created"
+	// AccountBatchDuplicate items name a document that already has an
+	// account, or that appears earlier in the batch.
+	AccountBatchDuplicate AccountBatchStatus = "duplicate"
+	// AccountBatchInvalid items have a malformed document number.
+	AccountBatchInvalid AccountBatchStatus = "invalid"
+)
+
+// AccountBatchResult reports the outcome of the item at Index in the
+// request. AccountID is left out for duplicates the caller may not see.
+// Reason is a message key.
+type AccountBatchResult struct {
+	Index          int                `json:"index"`
+	DocumentNumber string             `json:"document_number"`
+	Status         AccountBatchStatus `json:"status"`
+	AccountID      int64              `json:"account_id,omitempty"`
+	Reason         string             `json:"reason,omitempty"`
+}
+
+type AccountBatchReport struct {
+	Total      int                  `json:"total"`
+	Created    int                  `json:"created"`
+	Duplicates int                  `json:"duplicates"`
+	Invalid    int                  `json:"invalid"`
+	Results    []AccountBatchResult `json:"results"`
+}
+
+// Add records result and updates the counts.
+func (r *AccountBatchReport) Add(result AccountBatchResult) {
+	r.Total++
+	switch result.Status {
+	case AccountBatchCreated:
+		r.Created++
+	case AccountBatchDuplicate:
+		r.Duplicates++
+	case AccountBatchInvalid:
+		r.Invalid++
+	}
+	r.Results = append(r.Results, result)
+}
@@ -62,7 +62,7 @@ func TestNewAccount(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			acc, err := domain.NewAccount(tt.documentNumber)
+			acc, err := domain.NewAccount(tt.documentNumber, "BRL")
 
 			if tt.expectedErr != nil {
 				assert.ErrorIs(t, err, tt.expectedErr)
@@ -75,6 +75,7 @@ func TestNewAccount(t *testing.T) {
 
 				expectedClean := strings.TrimSpace(tt.documentNumber)
 				assert.Equal(t, expectedClean, acc.DocumentNumber)
+				assert.Equal(t, domain.Currency("BRL"), acc.Currency)
 			}
 		})
 	}
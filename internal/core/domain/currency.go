@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+// Currency is an ISO 4217 alphabetic code, such as BRL or USD.
+type Currency string
+
+// ParseCurrency normalizes code to upper case. Whether the currency can be
+// used is up to the rate provider.
+func ParseCurrency(code string) (Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", common.ErrInvalidCurrency
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return "", common.ErrInvalidCurrency
+		}
+	}
+	return Currency(code), nil
+}
+
+// FXRate converts From into To: one unit of From buys Rate units of To, as
+// quoted at AsOf.
+type FXRate struct {
+	From Currency
+	To   Currency
+	Rate float64
+	AsOf time.Time
+}
+
+// Convert rounds the converted amount to the cent.
+func (r FXRate) Convert(amount float64) float64 {
+	return math.Round(amount*r.Rate*100) / 100
+}
+
+// Conversion records how a transaction made in another currency was booked
+// in the account's currency.
+type Conversion struct {
+	Currency Currency  `json:"original_currency" example:"USD"`
+	Amount   float64   `json:"original_amount" example:"-20"`
+	Rate     float64   `json:"rate" example:"5.42"`
+	RateAt   time.Time `json:"rate_at"`
+}
+
+// Convert books the transaction's amount in rate.To, keeping the original
+// amount and the rate used. Amounts that round to zero are rejected.
+func (t *Transaction) Convert(rate FXRate) error {
+	converted := rate.Convert(t.Amount)
+	if converted == 0 {
+		return common.ErrInvalidAmount
+	}
+	t.Conversion = &Conversion{Currency: rate.From, Amount: t.Amount, Rate: rate.Rate, RateAt: rate.AsOf}
+	t.Amount, t.Currency = converted, rate.To
+	return nil
+}
+
+// CurrencyBalance sums an account's transactions made in one currency, both
+// as made and as booked in the account's currency.
+type CurrencyBalance struct {
+	Currency Currency `json:"currency" example:"USD"`
+	Amount   float64  `json:"amount" example:"-20"`
+	Booked   float64  `json:"booked" example:"-108.4"`
+}
+
+// AccountBalances breaks an account's balance down by the currency its
+// transactions were made in. Balance is in the account's currency.
+type AccountBalances struct {
+	AccountID  int64             `json:"account_id"`
+	Currency   Currency          `json:"currency" example:"BRL"`
+	Balance    float64           `json:"balance"`
+	Currencies []CurrencyBalance `json:"currencies"`
+}
+
+func NewAccountBalances(account *Account, currencies []CurrencyBalance) *AccountBalances {
+	b := &AccountBalances{AccountID: account.ID, Currency: account.Currency, Currencies: currencies}
+	if b.Currencies == nil {
+		b.Currencies = []CurrencyBalance{}
+	}
+	for _, c := range b.Currencies {
+		b.Balance += c.Booked
+	}
+	b.Balance = math.Round(b.Balance*100) / 100
+	return b
+}
@@ -0,0 +1,74 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCurrency(t *testing.T) {
+	t.Run("Success - Normalizes case and spaces", func(t *testing.T) {
+		currency, err := domain.ParseCurrency(" usd ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Currency("USD"), currency)
+	})
+
+	t.Run("Error - Malformed codes", func(t *testing.T) {
+		for _, code := range []string{"", "US", "USDT", "U5D", "réa"} {
+			_, err := domain.ParseCurrency(code)
+			assert.ErrorIs(t, err, common.ErrInvalidCurrency, code)
+		}
+	})
+}
+
+func TestTransactionConvert(t *testing.T) {
+	asOf := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	rate := domain.FXRate{From: "USD", To: "BRL", Rate: 5.4321, AsOf: asOf}
+
+	t.Run("Success - Books the converted amount", func(t *testing.T) {
+		tx := &domain.Transaction{Amount: -20, Currency: "BRL"}
+
+		err := tx.Convert(rate)
+
+		assert.NoError(t, err)
+		assert.Equal(t, -108.64, tx.Amount)
+		assert.Equal(t, domain.Currency("BRL"), tx.Currency)
+		assert.Equal(t, &domain.Conversion{Currency: "USD", Amount: -20, Rate: 5.4321, RateAt: asOf}, tx.Conversion)
+	})
+
+	t.Run("Error - Rounds to zero", func(t *testing.T) {
+		tx := &domain.Transaction{Amount: 0.01, Currency: "BRL"}
+
+		err := tx.Convert(domain.FXRate{From: "JPY", To: "BRL", Rate: 0.036})
+
+		assert.ErrorIs(t, err, common.ErrInvalidAmount)
+		assert.Nil(t, tx.Conversion)
+		assert.Equal(t, 0.01, tx.Amount)
+	})
+}
+
+func TestNewAccountBalances(t *testing.T) {
+	account := &domain.Account{ID: 1, Currency: "BRL"}
+
+	t.Run("Success - Sums the booked amounts", func(t *testing.T) {
+		balances := domain.NewAccountBalances(account, []domain.CurrencyBalance{
+			{Currency: "BRL", Amount: -50.1, Booked: -50.1},
+			{Currency: "USD", Amount: -20, Booked: -108.64},
+		})
+
+		assert.Equal(t, int64(1), balances.AccountID)
+		assert.Equal(t, domain.Currency("BRL"), balances.Currency)
+		assert.Equal(t, -158.74, balances.Balance)
+	})
+
+	t.Run("Success - No transactions", func(t *testing.T) {
+		balances := domain.NewAccountBalances(account, nil)
+
+		assert.Zero(t, balances.Balance)
+		assert.NotNil(t, balances.Currencies)
+	})
+}
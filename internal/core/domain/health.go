@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+type ComponentHealth struct {
+	Name      string  `json:"name" example:"database"`
+	Status    string  `json:"status" example:"up"`
+	LatencyMs float64 `json:"latency_ms" example:"1.25"`
+	// Error is a coarse reason ("timeout" or "unavailable"); the underlying
+	// error is only logged.
+	Error string `json:"error,omitempty" example:"timeout"`
+}
+
+type HealthReport struct {
+	Status     string            `json:"status" example:"up"`
+	Reason     string            `json:"reason,omitempty" example:"shutting down"`
+	Components []ComponentHealth `json:"components,omitempty"`
+	CheckedAt  time.Time         `json:"checked_at"`
+}
+
+func (r HealthReport) Healthy() bool {
+	return r.Status == HealthStatusUp
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"time"
+
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+// ImportMode decides what happens to the valid lines of a batch when other
+// lines are rejected: all-or-nothing imports none of them, best-effort
+// imports every line it can.
+type ImportMode string
+
+const (
+	ImportAllOrNothing ImportMode = "all_or_nothing"
+	ImportBestEffort   ImportMode = "best_effort"
+)
+
+// ImportModes lists the supported modes, comma separated.
+func ImportModes() string {
+	return string(ImportAllOrNothing) + ", " + string(ImportBestEffort)
+}
+
+func ParseImportMode(s string) (ImportMode, error) {
+	switch mode := ImportMode(s); mode {
+	case ImportAllOrNothing, ImportBestEffort:
+		return mode, nil
+	case "":
+		return ImportAllOrNothing, nil
+	default:
+		return "", common.ErrInvalidImportMode
+	}
+}
+
+type ImportStatus string
+
+const (
+	ImportPending   ImportStatus = "pending"
+	ImportRunning   ImportStatus = "running"
+	ImportSucceeded ImportStatus = "succeeded"
+	ImportFailed    ImportStatus = "failed"
+)
+
+// ImportRow is one transaction read from a batch file. Problem holds a
+// reason key when the line could not be parsed; the other fields are then
+// meaningless. A zero EventDate means the time of the import.
+type ImportRow struct {
+	Line            int       `json:"line"`
+	AccountID       int64     `json:"account_id"`
+	OperationTypeID int16     `json:"operation_type_id"`
+	Amount          float64   `json:"amount"`
+	Currency        Currency  `json:"currency,omitempty"`
+	EventDate       time.Time `json:"event_date,omitzero"`
+	Problem         string    `json:"problem,omitempty"`
+}
+
+type ImportLineStatus string
+
+const (
+	// ImportLineCreated lines were saved as TransactionID.
+	ImportLineCreated ImportLineStatus = "created"
+	// ImportLineRejected lines broke a rule and were not saved.
+	ImportLineRejected ImportLineStatus = "rejected"
+	// ImportLineSkipped lines were valid but not saved because the batch as
+	// a whole was not.
+	ImportLineSkipped ImportLineStatus = "skipped"
+)
+
+// ImportLineResult reports the outcome of one line. Reason is a message key.
+type ImportLineResult struct {
+	Line          int              `json:"line"`
+	Status        ImportLineStatus `json:"status"`
+	TransactionID int64            `json:"transaction_id,omitempty"`
+	Reason        string           `json:"reason,omitempty"`
+}
+
+type ImportReport struct {
+	Total    int                `json:"total"`
+	Created  int                `json:"created"`
+	Rejected int                `json:"rejected"`
+	Skipped  int                `json:"skipped"`
+	Lines    []ImportLineResult `json:"lines"`
+	// Transactions are the ones the import saved, for callers that need
+	// more than their IDs. They are not part of the report clients see.
+	Transactions []*Transaction `json:"-"`
+}
+
+// NewImportReport starts a report for rows with every line skipped until
+// it is marked otherwise.
+func NewImportReport(rows []ImportRow) *ImportReport {
+	report := &ImportReport{Total: len(rows), Skipped: len(rows), Lines: make([]ImportLineResult, len(rows))}
+	for i, row := range rows {
+		report.Lines[i] = ImportLineResult{Line: row.Line, Status: ImportLineSkipped}
+	}
+	return report
+}
+
+// Reject marks the i-th line rejected for reason.
+func (r *ImportReport) Reject(i int, reason string) {
+	r.move(i, ImportLineRejected)
+	r.Lines[i].Reason = reason
+}
+
+// Create marks the i-th line saved as transactionID.
+func (r *ImportReport) Create(i int, transactionID int64) {
+	r.move(i, ImportLineCreated)
+	r.Lines[i].TransactionID = transactionID
+}
+
+// Skip marks the i-th line skipped for reason.
+func (r *ImportReport) Skip(i int, reason string) {
+	r.move(i, ImportLineSkipped)
+	r.Lines[i].Reason = reason
+}
+
+func (r *ImportReport) move(i int, status ImportLineStatus) {
+	r.count(r.Lines[i].Status, -1)
+	r.count(status, 1)
+	r.Lines[i].Status = status
+}
+
+func (r *ImportReport) count(status ImportLineStatus, delta int) {
+	switch status {
+	case ImportLineCreated:
+		r.Created += delta
+	case ImportLineRejected:
+		r.Rejected += delta
+	case ImportLineSkipped:
+		r.Skipped += delta
+	}
+}
+
+// ImportJob is a batch import submitted for background processing. Error
+// is a message key set when the job failed as a whole.
+type ImportJob struct {
+	ID          int64         `json:"job_id"`
+	Status      ImportStatus  `json:"status"`
+	Mode        ImportMode    `json:"mode"`
+	Lines       int           `json:"lines"`
+	SubmittedAt time.Time     `json:"submitted_at"`
+	StartedAt   *time.Time    `json:"started_at,omitempty"`
+	FinishedAt  *time.Time    `json:"finished_at,omitempty"`
+	Error       string        `json:"error,omitempty"`
+	Report      *ImportReport `json:"report,omitempty"`
+	// Principal submitted the job; the job runs with its permissions.
+	Principal *Principal `json:"-"`
+}
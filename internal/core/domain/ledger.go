@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+// LedgerAccount names a ledger that postings are booked to. Customer
+// postings also carry the account ID; the other ledgers are system-wide.
+type LedgerAccount string
+
+const (
+	CustomerLedger           LedgerAccount = "customer"
+	MerchantSettlementLedger LedgerAccount = "merchant_settlement"
+	CashLedger               LedgerAccount = "cash"
+	// FeesLedger receives fee income. No operation charges fees yet.
+	FeesLedger LedgerAccount = "fees"
+)
+
+type Direction string
+
+const (
+	Debit  Direction = "debit"
+	Credit Direction = "credit"
+)
+
+type Posting struct {
+	Ledger    LedgerAccount `json:"ledger_account"`
+	AccountID int64         `json:"account_id,omitempty"`
+	Direction Direction     `json:"direction"`
+	Amount    float64       `json:"amount"`
+}
+
+// JournalEntry books one transaction, in the account's currency. Entries
+// are immutable once posted.
+type JournalEntry struct {
+	TransactionID int64     `json:"transaction_id"`
+	PostedAt      time.Time `json:"posted_at"`
+	Currency      Currency  `json:"currency"`
+	Postings      []Posting `json:"postings"`
+}
+
+// Validate checks that the entry has postings on both sides, all positive,
+// and that debits equal credits to the cent.
+func (e *JournalEntry) Validate() error {
+	var debits, credits int64
+	for _, p := range e.Postings {
+		if p.Amount <= 0 {
+			return common.ErrUnbalancedEntry
+		}
+		cents := int64(math.Round(p.Amount * 100))
+		switch p.Direction {
+		case Debit:
+			debits += cents
+		case Credit:
+			credits += cents
+		default:
+			return common.ErrUnbalancedEntry
+		}
+	}
+	if debits == 0 || debits != credits {
+		return common.ErrUnbalancedEntry
+	}
+	return nil
+}
+
+// CustomerNet is what the entry adds to the customer's balance: credits
+// minus debits on the customer ledger.
+func (e *JournalEntry) CustomerNet() float64 {
+	var net float64
+	for _, p := range e.Postings {
+		if p.Ledger != CustomerLedger {
+			continue
+		}
+		if p.Direction == Credit {
+			net += p.Amount
+		} else {
+			net -= p.Amount
+		}
+	}
+	return net
+}
+
+// PostingRule books an operation by debiting one ledger and crediting
+// another with the same amount.
+type PostingRule struct {
+	Debit  LedgerAccount
+	Credit LedgerAccount
+}
+
+var postingRules = map[OperationType]PostingRule{
+	Purchase:            {Debit: CustomerLedger, Credit: MerchantSettlementLedger},
+	InstallmentPurchase: {Debit: CustomerLedger, Credit: MerchantSettlementLedger},
+	Withdrawal:          {Debit: CustomerLedger, Credit: CashLedger},
+	Payment:             {Debit: CashLedger, Credit: CustomerLedger},
+}
+
+// Post returns the postings for amount, tying customer postings to
+// accountID.
+func (r PostingRule) Post(accountID int64, amount float64) []Posting {
+	posting := func(ledger LedgerAccount, direction Direction) Posting {
+		p := Posting{Ledger: ledger, Direction: direction, Amount: amount}
+		if ledger == CustomerLedger {
+			p.AccountID = accountID
+		}
+		return p
+	}
+	return []Posting{posting(r.Debit, Debit), posting(r.Credit, Credit)}
+}
+
+// LedgerTotal sums the postings booked to one ledger in one currency.
+type LedgerTotal struct {
+	Ledger   LedgerAccount `json:"ledger_account"`
+	Currency Currency      `json:"currency"`
+	Debits   float64       `json:"debits"`
+	Credits  float64       `json:"credits"`
+}
+
+// TrialBalance is the ledger-wide invariant check. UnbalancedEntries
+// counts entries whose debits and credits differ; ProjectionMismatches
+// counts transactions whose amount does not match their customer postings,
+// or that have no entry at all. Debits and Credits add up every currency;
+// each entry is in a single one, so balanced entries balance each currency.
+type TrialBalance struct {
+	Ledgers              []LedgerTotal `json:"ledgers"`
+	Debits               float64       `json:"debits"`
+	Credits              float64       `json:"credits"`
+	UnbalancedEntries    int           `json:"unbalanced_entries"`
+	ProjectionMismatches int           `json:"projection_mismatches"`
+}
+
+func (t *TrialBalance) Balanced() bool {
+	return math.Round(t.Debits*100) == math.Round(t.Credits*100) &&
+		t.UnbalancedEntries == 0 && t.ProjectionMismatches == 0
+}
@@ -0,0 +1,87 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostingRules(t *testing.T) {
+	tests := []struct {
+		op     domain.OperationType
+		debit  domain.LedgerAccount
+		credit domain.LedgerAccount
+	}{
+		{domain.Purchase, domain.CustomerLedger, domain.MerchantSettlementLedger},
+		{domain.InstallmentPurchase, domain.CustomerLedger, domain.MerchantSettlementLedger},
+		{domain.Withdrawal, domain.CustomerLedger, domain.CashLedger},
+		{domain.Payment, domain.CashLedger, domain.CustomerLedger},
+	}
+
+	for _, tt := range tests {
+		rule, ok := tt.op.PostingRule()
+		require.True(t, ok)
+		assert.Equal(t, domain.PostingRule{Debit: tt.debit, Credit: tt.credit}, rule)
+	}
+
+	_, ok := domain.OperationType(99).PostingRule()
+	assert.False(t, ok)
+}
+
+func TestTransaction_JournalEntry(t *testing.T) {
+	t.Run("JournalEntry - Purchase", func(t *testing.T) {
+		tx, err := domain.NewTransaction(7, domain.Purchase, 50)
+		require.NoError(t, err)
+
+		entry, err := tx.JournalEntry()
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Posting{
+			{Ledger: domain.CustomerLedger, AccountID: 7, Direction: domain.Debit, Amount: 50},
+			{Ledger: domain.MerchantSettlementLedger, Direction: domain.Credit, Amount: 50},
+		}, entry.Postings)
+		assert.Equal(t, tx.Amount, entry.CustomerNet())
+	})
+
+	t.Run("JournalEntry - Payment", func(t *testing.T) {
+		tx := &domain.Transaction{ID: 3, AccountID: 7, OperationTypeID: domain.Payment, Amount: 20}
+
+		entry, err := tx.JournalEntry()
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), entry.TransactionID)
+		assert.Equal(t, 20.0, entry.CustomerNet())
+	})
+
+	t.Run("JournalEntry - Unknown Operation", func(t *testing.T) {
+		_, err := (&domain.Transaction{OperationTypeID: 99, Amount: 1}).JournalEntry()
+
+		assert.ErrorIs(t, err, common.ErrInvalidOperation)
+	})
+}
+
+func TestJournalEntry_Validate(t *testing.T) {
+	balanced := &domain.JournalEntry{Postings: []domain.Posting{
+		{Ledger: domain.CustomerLedger, Direction: domain.Debit, Amount: 0.1},
+		{Ledger: domain.CustomerLedger, Direction: domain.Debit, Amount: 0.2},
+		{Ledger: domain.CashLedger, Direction: domain.Credit, Amount: 0.3},
+	}}
+	assert.NoError(t, balanced.Validate())
+
+	unbalanced := &domain.JournalEntry{Postings: []domain.Posting{
+		{Ledger: domain.CustomerLedger, Direction: domain.Debit, Amount: 10},
+		{Ledger: domain.CashLedger, Direction: domain.Credit, Amount: 9.99},
+	}}
+	assert.ErrorIs(t, unbalanced.Validate(), common.ErrUnbalancedEntry)
+
+	assert.ErrorIs(t, (&domain.JournalEntry{}).Validate(), common.ErrUnbalancedEntry)
+}
+
+func TestTrialBalance_Balanced(t *testing.T) {
+	assert.True(t, (&domain.TrialBalance{Debits: 10.1, Credits: 10.1}).Balanced())
+	assert.False(t, (&domain.TrialBalance{Debits: 10, Credits: 9}).Balanced())
+	assert.False(t, (&domain.TrialBalance{ProjectionMismatches: 1}).Balanced())
+}
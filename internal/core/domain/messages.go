@@ -1,26 +1,67 @@
 package domain
 
+// Message keys. The text for each locale lives in the HTTP adapter's
+// catalog; see internal/adapter/http/i18n.
 const (
-	ErrMsgDocumentInvalid         = "document must be between 11 and 14 digits"
-	ErrMsgAccountExists           = "account with this document already exists"
-	ErrMsgAccountNotFound         = "account not found"
-	ErrMsgAccountIDInvalid        = "the account ID must be a valid integer"
-	ErrMsgTransactionNotFound     = "transaction not found"
-	ErrMsgTransactionIDInvalid    = "the transaction ID must be a valid integer"
-	ErrMsgAccountIDDoesNotExist   = "account id does not exist"
-	ErrMsgOperationTypeInvalid    = "invalid operation type"
-	ErrMsgAmountInvalid           = "amount must be greater than zero"
-	ErrMsgDatabaseError           = "database error"
-	ErrMsgSaveAccountFailed       = "failed to save account"
-	ErrMsgCreateTransactionFailed = "failed to create transaction"
+	ErrMsgDocumentInvalid         = "document_invalid"
+	ErrMsgAccountExists           = "account_exists"
+	ErrMsgAccountNotFound         = "account_not_found"
+	ErrMsgAccountIDInvalid        = "account_id_invalid"
+	ErrMsgTransactionNotFound     = "transaction_not_found"
+	ErrMsgTransactionIDInvalid    = "transaction_id_invalid"
+	ErrMsgAccountIDDoesNotExist   = "account_id_does_not_exist"
+	ErrMsgOperationTypeInvalid    = "operation_type_invalid"
+	ErrMsgAmountInvalid           = "amount_invalid"
+	ErrMsgDatabaseError           = "database_error"
+	ErrMsgSaveAccountFailed       = "save_account_failed"
+	ErrMsgCreateTransactionFailed = "create_transaction_failed"
+	ErrMsgMissingToken            = "missing_token"
+	ErrMsgInvalidToken            = "invalid_token"
+	ErrMsgRateLimited             = "rate_limited"
+	ErrMsgBodyTooLarge            = "body_too_large"
+	ErrMsgRequestTimeout          = "request_timeout"
+	ErrMsgBillingDayInvalid       = "billing_day_invalid"
+	ErrMsgStatementNotFound       = "statement_not_found"
+	ErrMsgStatementIDInvalid      = "statement_id_invalid"
+	ErrMsgExportFormatInvalid     = "export_format_invalid"
+	ErrMsgPeriodDateInvalid       = "period_date_invalid"
+	ErrMsgPeriodRangeInvalid      = "period_range_invalid"
+	ErrMsgImportModeInvalid       = "import_mode_invalid"
+	ErrMsgImportFormatInvalid     = "import_format_invalid"
+	ErrMsgImportFileInvalid       = "import_file_invalid"
+	ErrMsgImportFileEmpty         = "import_file_empty"
+	ErrMsgImportJobNotFound       = "import_job_not_found"
+	ErrMsgImportJobIDInvalid      = "import_job_id_invalid"
+	ErrMsgImportLineMalformed     = "import_line_malformed"
+	ErrMsgImportBatchRejected     = "import_batch_rejected"
+	ErrMsgImportInterrupted       = "import_interrupted"
+	ErrMsgAccountBatchInvalid     = "account_batch_invalid"
+	ErrMsgDocumentRepeated        = "document_repeated"
+	ErrMsgInvalidAPIKey           = "invalid_api_key"
+	ErrMsgJournalEntryNotFound    = "journal_entry_not_found"
+	ErrMsgCurrencyInvalid         = "currency_invalid"
+	ErrMsgCurrencyUnsupported     = "currency_unsupported"
+	ErrMsgAPIKeyNotFound          = "api_key_not_found"
+	ErrMsgAPIKeyNameInvalid       = "api_key_name_invalid"
 
-	ErrCodeInvalidBody   = "INVALID_BODY"
-	ErrCodeInvalidID     = "INVALID_ID"
-	ErrCodeNotFound      = "NOT_FOUND_ERROR"
-	ErrCodeValidation    = "VALIDATION_ERROR"
-	ErrCodeInternalError = "INTERNAL_SERVER_ERROR"
-	ErrCodeConflict      = "CONFLICT_ERROR"
+	ErrMsgInvalidBodyRequest = "invalid_body_request"
+	ErrMsgUnexpectedError    = "unexpected_error"
+)
 
-	ErrMsgInvalidBodyRequest = "invalid request body or missing required fields"
-	ErrMsgUnexpectedError    = "an unexpected error occurred"
+// Field reasons reported when a request body cannot be bound.
+const (
+	ReasonRequired    = "required"
+	ReasonMin         = "min"
+	ReasonMax         = "max"
+	ReasonGreaterThan = "gt"
+	ReasonLessThan    = "lt"
+	ReasonOneOf       = "oneof"
+	ReasonRule        = "rule"
+	ReasonInteger     = "type_integer"
+	ReasonNumber      = "type_number"
+	ReasonBoolean     = "type_boolean"
+	ReasonString      = "type_string"
+	ReasonArray       = "type_array"
+	ReasonObject      = "type_object"
+	ReasonSyntax      = "syntax"
 )
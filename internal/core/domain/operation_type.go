@@ -9,24 +9,25 @@ const (
 	Payment             OperationType = 4
 )
 
+// PostingRule returns how the operation is booked in the ledger.
+func (op OperationType) PostingRule() (PostingRule, bool) {
+	rule, ok := postingRules[op]
+	return rule, ok
+}
+
+// IsDebt reports whether the operation debits the customer.
 func (op OperationType) IsDebt() bool {
-	switch op {
-	case Purchase, InstallmentPurchase, Withdrawal:
-		return true
-	default:
-		return false
-	}
+	rule, ok := op.PostingRule()
+	return ok && rule.Debit == CustomerLedger
 }
 
+// IsCredit reports whether the operation credits the customer.
 func (op OperationType) IsCredit() bool {
-	return op == Payment
+	rule, ok := op.PostingRule()
+	return ok && rule.Credit == CustomerLedger
 }
 
 func (op OperationType) IsValid() bool {
-	switch op {
-	case Purchase, InstallmentPurchase, Withdrawal, Payment:
-		return true
-	default:
-		return false
-	}
+	_, ok := op.PostingRule()
+	return ok
 }
@@ -0,0 +1,54 @@
+package domain
+
+import "context"
+
+// RoleAdmin grants access to every account regardless of ownership.
+const RoleAdmin = "admin"
+
+// Principal is the authenticated caller of a request, as asserted by the
+// identity provider that issued its credentials.
+type Principal struct {
+	Subject string   `json:"sub"`
+	Tenant  string   `json:"tenant,omitempty"`
+	Scopes  []string `json:"scopes,omitempty"`
+	Roles   []string `json:"roles,omitempty"`
+}
+
+func (p *Principal) HasScope(scope string) bool {
+	if p == nil {
+		return false
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Principal) HasRole(role string) bool {
+	if p == nil {
+		return false
+	}
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Principal) IsAdmin() bool {
+	return p.HasRole(RoleAdmin)
+}
+
+type principalContextKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return p, ok && p != nil
+}
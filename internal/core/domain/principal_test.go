@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipal_HasScope(t *testing.T) {
+	p := &domain.Principal{Subject: "client-1", Scopes: []string{"accounts:read", "transactions:write"}}
+
+	assert.True(t, p.HasScope("accounts:read"))
+	assert.True(t, p.HasScope("transactions:write"))
+	assert.False(t, p.HasScope("accounts:write"))
+
+	var nilPrincipal *domain.Principal
+	assert.False(t, nilPrincipal.HasScope("accounts:read"))
+}
+
+func TestPrincipal_Roles(t *testing.T) {
+	admin := &domain.Principal{Subject: "ops", Roles: []string{"support", domain.RoleAdmin}}
+	customer := &domain.Principal{Subject: "client-1", Roles: []string{"customer"}}
+
+	assert.True(t, admin.HasRole("support"))
+	assert.True(t, admin.IsAdmin())
+	assert.True(t, customer.HasRole("customer"))
+	assert.False(t, customer.IsAdmin())
+
+	var nilPrincipal *domain.Principal
+	assert.False(t, nilPrincipal.IsAdmin())
+}
+
+func TestPrincipalContext(t *testing.T) {
+	t.Run("should return principal stored in context", func(t *testing.T) {
+		p := &domain.Principal{Subject: "client-1", Tenant: "acme"}
+		ctx := domain.ContextWithPrincipal(context.Background(), p)
+
+		got, ok := domain.PrincipalFromContext(ctx)
+
+		assert.True(t, ok)
+		assert.Equal(t, p, got)
+	})
+
+	t.Run("should report missing principal", func(t *testing.T) {
+		got, ok := domain.PrincipalFromContext(context.Background())
+
+		assert.False(t, ok)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should treat nil principal as missing", func(t *testing.T) {
+		ctx := domain.ContextWithPrincipal(context.Background(), nil)
+
+		_, ok := domain.PrincipalFromContext(ctx)
+
+		assert.False(t, ok)
+	})
+}
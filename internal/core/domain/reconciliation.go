@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// BalanceCheck compares an account's stored balance with the balance its
+// transactions add up to.
+type BalanceCheck struct {
+	AccountID int64   `json:"account_id"`
+	Stored    float64 `json:"stored_balance"`
+	Ledger    float64 `json:"ledger_balance"`
+}
+
+// Difference is how far the stored balance is from the ledger, in cents
+// precision.
+func (c BalanceCheck) Difference() float64 {
+	return math.Round((c.Stored-c.Ledger)*100) / 100
+}
+
+func (c BalanceCheck) Drifted() bool {
+	return c.Difference() != 0
+}
+
+type BalanceDiscrepancy struct {
+	BalanceCheck
+	Difference float64 `json:"difference"`
+	Repaired   bool    `json:"repaired"`
+}
+
+// ReconciliationReport is the outcome of one pass over every account.
+type ReconciliationReport struct {
+	ID            int64                `json:"report_id"`
+	StartedAt     time.Time            `json:"started_at"`
+	FinishedAt    time.Time            `json:"finished_at"`
+	Repair        bool                 `json:"repair"`
+	Accounts      int                  `json:"accounts_scanned"`
+	Repaired      int                  `json:"repaired"`
+	Discrepancies []BalanceDiscrepancy `json:"discrepancies"`
+}
+
+// Add records a check, noting it as a discrepancy if it drifted.
+func (r *ReconciliationReport) Add(check BalanceCheck) {
+	r.Accounts++
+	if check.Drifted() {
+		r.Discrepancies = append(r.Discrepancies, BalanceDiscrepancy{BalanceCheck: check, Difference: check.Difference()})
+	}
+}
+
+// Unresolved counts the discrepancies left unrepaired.
+func (r *ReconciliationReport) Unresolved() int {
+	return len(r.Discrepancies) - r.Repaired
+}
@@ -0,0 +1,24 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalanceCheck_Drifted(t *testing.T) {
+	assert.False(t, domain.BalanceCheck{Stored: 0.3, Ledger: 0.1 + 0.2}.Drifted())
+	assert.True(t, domain.BalanceCheck{Stored: 10, Ledger: 9.99}.Drifted())
+	assert.Equal(t, -0.01, domain.BalanceCheck{Stored: 9.99, Ledger: 10}.Difference())
+}
+
+func TestReconciliationReport_Add(t *testing.T) {
+	report := &domain.ReconciliationReport{}
+	report.Add(domain.BalanceCheck{AccountID: 1, Stored: 5, Ledger: 5})
+	report.Add(domain.BalanceCheck{AccountID: 2, Stored: 5, Ledger: 0})
+
+	assert.Equal(t, 2, report.Accounts)
+	assert.Len(t, report.Discrepancies, 1)
+	assert.Equal(t, 1, report.Unresolved())
+}
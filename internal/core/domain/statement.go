@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+// Billing days run from 1 to 28 so that every month has one.
+const (
+	MinBillingDay = 1
+	MaxBillingDay = 28
+)
+
+// BillingCycle closes an account's statement at midnight UTC on BillingDay
+// each month.
+type BillingCycle struct {
+	AccountID  int64 `json:"account_id"`
+	BillingDay int   `json:"billing_day"`
+}
+
+func NewBillingCycle(accountID int64, billingDay int) (*BillingCycle, error) {
+	if billingDay < MinBillingDay || billingDay > MaxBillingDay {
+		return nil, common.ErrInvalidBillingDay
+	}
+	return &BillingCycle{AccountID: accountID, BillingDay: billingDay}, nil
+}
+
+// NextClose returns the first closing time strictly after t.
+func (b BillingCycle) NextClose(t time.Time) time.Time {
+	t = t.UTC()
+	close := time.Date(t.Year(), t.Month(), b.BillingDay, 0, 0, 0, 0, time.UTC)
+	if !close.After(t) {
+		close = close.AddDate(0, 1, 0)
+	}
+	return close
+}
+
+// CycleState is what the closing job needs to know about an account: its
+// billing day (zero when the default applies), when its last statement
+// closed and when its oldest transaction not yet on a statement was made.
+// Either time is zero when there is none.
+type CycleState struct {
+	AccountID     int64
+	BillingDay    int
+	LastClose     time.Time
+	FirstUnbilled time.Time
+}
+
+// StatementPolicy sets the payment terms of a closed statement. The minimum
+// payment is MinimumPaymentRate of the amount due, but at least
+// MinimumPaymentFloor and never more than the amount due.
+type StatementPolicy struct {
+	DueDays             int
+	MinimumPaymentRate  float64
+	MinimumPaymentFloor float64
+}
+
+type StatementStatus string
+
+const (
+	StatementPaid    StatementStatus = "paid"
+	StatementOpen    StatementStatus = "open"
+	StatementOverdue StatementStatus = "overdue"
+)
+
+// Statement summarizes the transactions of one billing period. Balances use
+// the sign of transaction amounts, so a negative balance is owed; Debits and
+// Credits are totals and always positive. Payments made after the statement
+// closed are allocated to it until AmountPaid covers the amount due.
+type Statement struct {
+	ID             int64               `json:"statement_id"`
+	AccountID      int64               `json:"account_id"`
+	PeriodStart    time.Time           `json:"period_start"`
+	PeriodEnd      time.Time           `json:"period_end"`
+	DueDate        time.Time           `json:"due_date"`
+	OpeningBalance float64             `json:"opening_balance"`
+	Debits         float64             `json:"debits"`
+	Credits        float64             `json:"credits"`
+	ClosingBalance float64             `json:"closing_balance"`
+	MinimumPayment float64             `json:"minimum_payment"`
+	AmountPaid     float64             `json:"amount_paid"`
+	Status         StatementStatus     `json:"status"`
+	Transactions   []Transaction       `json:"transactions,omitempty"`
+	Payments       []PaymentAllocation `json:"payments,omitempty"`
+}
+
+// NewStatement closes the period [periodStart, periodEnd).
+func NewStatement(accountID int64, periodStart, periodEnd time.Time, opening, debits, credits float64, policy StatementPolicy) *Statement {
+	s := &Statement{
+		AccountID:      accountID,
+		PeriodStart:    periodStart,
+		PeriodEnd:      periodEnd,
+		DueDate:        periodEnd.AddDate(0, 0, policy.DueDays),
+		OpeningBalance: roundCents(opening),
+		Debits:         roundCents(debits),
+		Credits:        roundCents(credits),
+		ClosingBalance: roundCents(opening - debits + credits),
+	}
+
+	due := s.AmountDue()
+	s.MinimumPayment = roundCents(math.Min(due, math.Max(due*policy.MinimumPaymentRate, policy.MinimumPaymentFloor)))
+	return s
+}
+
+// AmountDue is the debt at close; a statement with a credit balance asks for
+// nothing.
+func (s *Statement) AmountDue() float64 {
+	return math.Max(0, -s.ClosingBalance)
+}
+
+// Outstanding is the part of the amount due not yet settled by payments.
+func (s *Statement) Outstanding() float64 {
+	return roundCents(math.Max(0, s.AmountDue()-s.AmountPaid))
+}
+
+func (s *Statement) StatusAt(now time.Time) StatementStatus {
+	switch {
+	case s.Outstanding() == 0:
+		return StatementPaid
+	case now.Before(s.DueDate):
+		return StatementOpen
+	default:
+		return StatementOverdue
+	}
+}
+
+// PaymentAllocation records the part of a payment that settled a statement.
+type PaymentAllocation struct {
+	StatementID   int64   `json:"statement_id"`
+	TransactionID int64   `json:"transaction_id"`
+	Amount        float64 `json:"amount"`
+}
+
+// PendingPayment is a payment with Remaining still unallocated.
+type PendingPayment struct {
+	TransactionID int64
+	EventDate     time.Time
+	Remaining     float64
+}
+
+// AllocatePayments settles statements oldest first. A payment only settles
+// statements that had closed when it was made; earlier payments are already
+// part of those statements' balances. statements and payments must be in
+// chronological order; AmountPaid is updated in place.
+func AllocatePayments(statements []*Statement, payments []PendingPayment) []PaymentAllocation {
+	var allocations []PaymentAllocation
+	for _, p := range payments {
+		remaining := p.Remaining
+		for _, s := range statements {
+			if remaining <= 0 {
+				break
+			}
+			if s.PeriodEnd.After(p.EventDate) {
+				break
+			}
+			amount := roundCents(math.Min(remaining, s.Outstanding()))
+			if amount <= 0 {
+				continue
+			}
+			s.AmountPaid = roundCents(s.AmountPaid + amount)
+			remaining = roundCents(remaining - amount)
+			allocations = append(allocations, PaymentAllocation{StatementID: s.ID, TransactionID: p.TransactionID, Amount: amount})
+		}
+	}
+	return allocations
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,114 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestBillingCycle(t *testing.T) {
+	t.Run("Error - Billing day out of range", func(t *testing.T) {
+		for _, day := range []int{0, 29, -1} {
+			_, err := domain.NewBillingCycle(1, day)
+			assert.ErrorIs(t, err, common.ErrInvalidBillingDay, day)
+		}
+	})
+
+	t.Run("Success - Next close", func(t *testing.T) {
+		cycle, err := domain.NewBillingCycle(1, 10)
+		require.NoError(t, err)
+
+		assert.Equal(t, date(2026, 3, 10), cycle.NextClose(date(2026, 3, 9)))
+		assert.Equal(t, date(2026, 4, 10), cycle.NextClose(date(2026, 3, 10)), "close is strictly after")
+		assert.Equal(t, date(2027, 1, 10), cycle.NextClose(date(2026, 12, 25)))
+	})
+}
+
+func TestNewStatement(t *testing.T) {
+	policy := domain.StatementPolicy{DueDays: 10, MinimumPaymentRate: 0.15, MinimumPaymentFloor: 20}
+
+	tests := []struct {
+		name     string
+		opening  float64
+		debits   float64
+		credits  float64
+		closing  float64
+		minimum  float64
+		dueTotal float64
+	}{
+		{name: "Success - Rate above floor", opening: -100, debits: 500, credits: 100, closing: -500, minimum: 75, dueTotal: 500},
+		{name: "Success - Floor above rate", opening: 0, debits: 50, credits: 0, closing: -50, minimum: 20, dueTotal: 50},
+		{name: "Success - Minimum capped at amount due", opening: 0, debits: 10.5, credits: 0, closing: -10.5, minimum: 10.5, dueTotal: 10.5},
+		{name: "Success - Credit balance owes nothing", opening: 0, debits: 10, credits: 30, closing: 20, minimum: 0, dueTotal: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := domain.NewStatement(1, date(2026, 2, 10), date(2026, 3, 10), tt.opening, tt.debits, tt.credits, policy)
+
+			assert.Equal(t, tt.closing, s.ClosingBalance)
+			assert.Equal(t, tt.minimum, s.MinimumPayment)
+			assert.Equal(t, tt.dueTotal, s.AmountDue())
+			assert.Equal(t, date(2026, 3, 20), s.DueDate)
+		})
+	}
+}
+
+func TestStatementStatus(t *testing.T) {
+	s := &domain.Statement{ClosingBalance: -100, DueDate: date(2026, 3, 20)}
+
+	assert.Equal(t, domain.StatementOpen, s.StatusAt(date(2026, 3, 15)))
+	assert.Equal(t, domain.StatementOverdue, s.StatusAt(date(2026, 3, 20)))
+
+	s.AmountPaid = 100
+	assert.Equal(t, domain.StatementPaid, s.StatusAt(date(2026, 3, 25)))
+}
+
+func TestAllocatePayments(t *testing.T) {
+	statements := func() []*domain.Statement {
+		return []*domain.Statement{
+			{ID: 1, PeriodEnd: date(2026, 1, 10), ClosingBalance: -100, AmountPaid: 40},
+			{ID: 2, PeriodEnd: date(2026, 2, 10), ClosingBalance: -200},
+		}
+	}
+
+	t.Run("Success - Oldest statement first", func(t *testing.T) {
+		s := statements()
+		allocations := domain.AllocatePayments(s, []domain.PendingPayment{
+			{TransactionID: 7, EventDate: date(2026, 2, 15), Remaining: 100},
+		})
+
+		assert.Equal(t, []domain.PaymentAllocation{
+			{StatementID: 1, TransactionID: 7, Amount: 60},
+			{StatementID: 2, TransactionID: 7, Amount: 40},
+		}, allocations)
+		assert.Equal(t, 100.0, s[0].AmountPaid)
+		assert.Equal(t, 40.0, s[1].AmountPaid)
+	})
+
+	t.Run("Success - Payment only settles statements closed before it", func(t *testing.T) {
+		s := statements()
+		allocations := domain.AllocatePayments(s, []domain.PendingPayment{
+			{TransactionID: 7, EventDate: date(2026, 1, 20), Remaining: 500},
+		})
+
+		assert.Equal(t, []domain.PaymentAllocation{{StatementID: 1, TransactionID: 7, Amount: 60}}, allocations)
+		assert.Zero(t, s[1].AmountPaid)
+	})
+
+	t.Run("Success - Nothing to settle", func(t *testing.T) {
+		allocations := domain.AllocatePayments(nil, []domain.PendingPayment{
+			{TransactionID: 7, EventDate: date(2026, 1, 20), Remaining: 500},
+		})
+
+		assert.Empty(t, allocations)
+	})
+}
@@ -12,27 +12,47 @@ type Transaction struct {
 	AccountID       int64         `json:"account_id"`
 	OperationTypeID OperationType `json:"operation_type_id"`
 	Amount          float64       `json:"amount"`
+	Currency        Currency      `json:"currency"`
+	Conversion      *Conversion   `json:"conversion,omitempty"`
 	EventDate       time.Time     `json:"-"`
 }
 
+// NewTransaction books amount according to the operation's posting rule.
+// The transaction's signed amount is the entry's effect on the customer.
 func NewTransaction(accountID int64, opType OperationType, amount float64) (*Transaction, error) {
 	if amount <= 0 {
 		return nil, common.ErrInvalidAmount
 	}
 
-	if !opType.IsValid() {
-		return nil, common.ErrInvalidOperation
+	tx := &Transaction{
+		AccountID:       accountID,
+		OperationTypeID: opType,
+		Amount:          amount,
+		EventDate:       time.Now(),
+	}
+	entry, err := tx.JournalEntry()
+	if err != nil {
+		return nil, err
 	}
+	tx.Amount = entry.CustomerNet()
+	return tx, nil
+}
 
-	normalizedAmount := math.Abs(amount)
-	if opType.IsDebt() {
-		normalizedAmount = -normalizedAmount
+// JournalEntry returns the balanced entry that books the transaction.
+func (t *Transaction) JournalEntry() (*JournalEntry, error) {
+	rule, ok := t.OperationTypeID.PostingRule()
+	if !ok {
+		return nil, common.ErrInvalidOperation
 	}
 
-	return &Transaction{
-		AccountID:       accountID,
-		OperationTypeID: opType,
-		Amount:          normalizedAmount,
-		EventDate:       time.Now(),
-	}, nil
+	entry := &JournalEntry{
+		TransactionID: t.ID,
+		PostedAt:      t.EventDate,
+		Currency:      t.Currency,
+		Postings:      rule.Post(t.AccountID, math.Abs(t.Amount)),
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+	return entry, nil
 }
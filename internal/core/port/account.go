@@ -7,13 +7,40 @@ import (
 )
 
 type AccountRepository interface {
-	Save(ctx context.Context, account *domain.Account) (*domain.Account, error)
+	// Save inserts the account and, unless owner is empty, binds it to owner
+	// in the same statement, so an account is never left without its owner.
+	Save(ctx context.Context, account *domain.Account, owner string) (*domain.Account, error)
+	// SaveBatch inserts the accounts whose document number is not taken,
+	// binding each one it inserts to owner like Save, and sets the ID of
+	// every account, new or existing. created[i] reports whether accounts[i]
+	// was inserted. Document numbers must be distinct.
+	SaveBatch(ctx context.Context, accounts []*domain.Account, owner string) (created []bool, err error)
 	FindByDocument(ctx context.Context, documentNumber string) (*domain.Account, error)
 	FindByAccountID(ctx context.Context, accountId int64) (*domain.Account, error)
 }
 
 type AccountService interface {
-	CreateAccount(ctx context.Context, documentNumber string) (*domain.Account, error)
+	// CreateAccount opens the account in currency, or in the default
+	// currency when it is empty.
+	CreateAccount(ctx context.Context, documentNumber string, currency domain.Currency) (*domain.Account, error)
+	// CreateAccounts opens an account for each new document number and
+	// reports every item, so duplicates and invalid documents do not fail
+	// the batch.
+	CreateAccounts(ctx context.Context, documentNumbers []string, currency domain.Currency) (*domain.AccountBatchReport, error)
 	GetAccountByDocument(ctx context.Context, documentNumber string) (*domain.Account, error)
 	GetAccountByID(ctx context.Context, accountID int64) (*domain.Account, error)
 }
+
+type AccountOwnershipRepository interface {
+	IsOwner(ctx context.Context, subject string, accountID int64) (bool, error)
+}
+
+// AccountAuthorizer decides whether the principal in the context may act on
+// an account. Denials are reported as "account not found" so callers cannot
+// probe for accounts they do not own.
+type AccountAuthorizer interface {
+	Authorize(ctx context.Context, accountID int64) error
+	// Owner is the subject new accounts are bound to, empty when there is no
+	// principal to bind.
+	Owner(ctx context.Context) string
+}
@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+)
+
+type APIKeyRepository interface {
+	// Create stores key under hash and sets its ID and creation time.
+	Create(ctx context.Context, key *domain.APIKey, hash string) error
+	FindByHash(ctx context.Context, hash string) (*domain.APIKey, error)
+	List(ctx context.Context) ([]*domain.APIKey, error)
+	// Revoke marks the key revoked. Revoking it again keeps the first time.
+	Revoke(ctx context.Context, keyID int64) error
+}
+
+// APIKeyService manages keys for operators and verifies them for the HTTP
+// layer, through the TokenVerifier it embeds.
+type APIKeyService interface {
+	TokenVerifier
+	// Create returns the new key with its secret, which is shown only once.
+	Create(ctx context.Context, name, subject string, roles, scopes []string) (*domain.APIKey, string, error)
+	List(ctx context.Context) ([]*domain.APIKey, error)
+	Revoke(ctx context.Context, keyID int64) error
+}
@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"crypto/x509"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+)
+
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*domain.Principal, error)
+}
+
+// ClientCertMapper resolves a verified TLS client certificate to the
+// principal it acts as.
+type ClientCertMapper interface {
+	Principal(cert *x509.Certificate) (*domain.Principal, bool)
+}
@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+)
+
+// FXRateProvider quotes exchange rates between the currencies it supports.
+// Rate fails with common.ErrUnsupportedCurrency when either currency is
+// unknown to it.
+type FXRateProvider interface {
+	Rate(ctx context.Context, from, to domain.Currency) (domain.FXRate, error)
+	Supports(currency domain.Currency) bool
+}
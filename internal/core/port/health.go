@@ -1,11 +1,25 @@
 package port
 
-import "context"
+import (
+	"context"
+	"time"
 
-type HealthService interface {
-	Check(ctx context.Context) error
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+)
+
+type HealthCheckFunc func(ctx context.Context) error
+
+// HealthChecker is the registry components use to contribute a named
+// readiness check. Each check runs with its own timeout.
+type HealthChecker interface {
+	Register(name string, timeout time.Duration, check HealthCheckFunc)
 }
 
-type DBHealthChecker interface {
-	PingContext(ctx context.Context) error
+type HealthService interface {
+	HealthChecker
+	Live(ctx context.Context) domain.HealthReport
+	Ready(ctx context.Context) domain.HealthReport
+	// SetNotReady makes readiness fail from now on, so load balancers stop
+	// routing traffic while the server drains.
+	SetNotReady()
 }
@@ -0,0 +1,32 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+)
+
+type ImportJobRepository interface {
+	// Create stores a pending job together with the rows it will import.
+	Create(ctx context.Context, job *domain.ImportJob, rows []domain.ImportRow) (*domain.ImportJob, error)
+	FindByID(ctx context.Context, jobID int64) (*domain.ImportJob, error)
+	// Claim marks the oldest pending job running and returns it with its
+	// rows, or a nil job when none is pending. Jobs that started before
+	// staleBefore and are still running are failed first. Concurrent
+	// callers never claim the same job.
+	Claim(ctx context.Context, staleBefore time.Time) (*domain.ImportJob, []domain.ImportRow, error)
+	// Finish records the job's outcome and drops its rows.
+	Finish(ctx context.Context, job *domain.ImportJob) error
+}
+
+type ImportService interface {
+	// Import validates and saves rows right away.
+	Import(ctx context.Context, mode domain.ImportMode, rows []domain.ImportRow) (*domain.ImportReport, error)
+	// Submit queues rows for a background import on behalf of the caller.
+	Submit(ctx context.Context, mode domain.ImportMode, rows []domain.ImportRow) (*domain.ImportJob, error)
+	GetJob(ctx context.Context, jobID int64) (*domain.ImportJob, error)
+	// RunPending imports queued jobs until none is left and returns the
+	// jobs it ran with their outcome.
+	RunPending(ctx context.Context) ([]*domain.ImportJob, error)
+}
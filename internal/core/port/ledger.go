@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+)
+
+type LedgerRepository interface {
+	FindEntry(ctx context.Context, transactionID int64) (*domain.JournalEntry, error)
+	// TrialBalance sums every posting within one snapshot, so entries
+	// being posted meanwhile are either fully counted or not at all.
+	TrialBalance(ctx context.Context) (*domain.TrialBalance, error)
+}
+
+type LedgerService interface {
+	// GetJournalEntry returns the entry booking a transaction the caller
+	// can see.
+	GetJournalEntry(ctx context.Context, transactionID int64) (*domain.JournalEntry, error)
+	TrialBalance(ctx context.Context) (*domain.TrialBalance, error)
+}
@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+)
+
+type ReconciliationRepository interface {
+	// ScanBalances checks up to limit accounts with an ID above afterID, in
+	// ID order. Each balance is compared within one snapshot, so
+	// transactions being posted meanwhile never show up as drift.
+	ScanBalances(ctx context.Context, afterID int64, limit int) ([]domain.BalanceCheck, error)
+	// RepairBalance locks the account, recomputes its ledger balance and
+	// stores it if it still differs, returning the check it acted on.
+	RepairBalance(ctx context.Context, accountID int64) (check domain.BalanceCheck, repaired bool, err error)
+	SaveReport(ctx context.Context, report *domain.ReconciliationReport) error
+}
+
+type ReconciliationService interface {
+	// Reconcile compares every stored balance with the transactions and
+	// records the report. With repair set, drifted balances are corrected.
+	Reconcile(ctx context.Context, repair bool) (*domain.ReconciliationReport, error)
+}
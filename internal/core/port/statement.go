@@ -0,0 +1,40 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+)
+
+type StatementRepository interface {
+	// BillingDay returns zero when the account has no billing cycle of its own.
+	BillingDay(ctx context.Context, accountID int64) (int, error)
+	SaveBillingCycle(ctx context.Context, cycle *domain.BillingCycle) error
+	CycleStates(ctx context.Context) ([]domain.CycleState, error)
+	// Close atomically closes the period ending at periodEnd, moving every
+	// transaction made before it and not yet billed onto the new statement.
+	Close(ctx context.Context, accountID int64, periodStart, periodEnd time.Time, policy domain.StatementPolicy) (*domain.Statement, error)
+	// AllocatePayments settles the account's open statements with payments
+	// that have not been fully allocated yet.
+	AllocatePayments(ctx context.Context, accountID int64) ([]domain.PaymentAllocation, error)
+	ListByAccount(ctx context.Context, accountID int64) ([]*domain.Statement, error)
+	// FindByID returns the statement with its transactions and payments.
+	FindByID(ctx context.Context, statementID int64) (*domain.Statement, error)
+}
+
+// PaymentAllocator settles closed statements with an account's payments.
+type PaymentAllocator interface {
+	AllocatePayments(ctx context.Context, accountID int64) error
+}
+
+type StatementService interface {
+	PaymentAllocator
+	GetBillingCycle(ctx context.Context, accountID int64) (*domain.BillingCycle, error)
+	SetBillingCycle(ctx context.Context, accountID int64, billingDay int) (*domain.BillingCycle, error)
+	ListStatements(ctx context.Context, accountID int64) ([]*domain.Statement, error)
+	GetStatement(ctx context.Context, accountID, statementID int64) (*domain.Statement, error)
+	// CloseDue closes every statement period that ended at or before now and
+	// reports how many statements were closed.
+	CloseDue(ctx context.Context, now time.Time) (int, error)
+}
@@ -2,16 +2,33 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/evythrossell/account-management-api/internal/core/domain"
 )
 
 type TransactionRepository interface {
 	Save(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, error)
+	// SaveBatch saves every transaction or none, setting their IDs.
+	SaveBatch(ctx context.Context, transactions []*domain.Transaction) error
 	FindByTransactionID(ctx context.Context, transactionID int64) (*domain.Transaction, error)
+	// StreamByAccount calls fn for each of the account's transactions with
+	// from <= event_date < to, oldest first, as rows arrive from the
+	// database. A zero from or to leaves that end open. An error from fn
+	// stops the stream and is returned.
+	StreamByAccount(ctx context.Context, accountID int64, from, to time.Time, fn func(*domain.Transaction) error) error
+	// SumByCurrency totals the account's transactions per currency they
+	// were made in, ordered by currency.
+	SumByCurrency(ctx context.Context, accountID int64) ([]domain.CurrencyBalance, error)
 }
 
 type TransactionService interface {
-	CreateTransaction(ctx context.Context, accountID int64, operationType int16, amount float64) (*domain.Transaction, error)
+	// CreateTransaction books amount, given in currency, in the account's
+	// currency. An empty currency means the account's own.
+	CreateTransaction(ctx context.Context, accountID int64, operationType int16, amount float64, currency domain.Currency) (*domain.Transaction, error)
 	GetByTransactionID(ctx context.Context, transactionID int64) (*domain.Transaction, error)
+	// ExportTransactions calls start, when set, with the account before
+	// streaming its transactions to fn.
+	ExportTransactions(ctx context.Context, accountID int64, from, to time.Time, start func(*domain.Account) error, fn func(*domain.Transaction) error) error
+	GetBalances(ctx context.Context, accountID int64) (*domain.AccountBalances, error)
 }
@@ -10,20 +10,43 @@ import (
 )
 
 type accountService struct {
-	repo port.AccountRepository
+	repo            port.AccountRepository
+	authorizer      port.AccountAuthorizer
+	rates           port.FXRateProvider
+	defaultCurrency domain.Currency
 }
 
-func NewAccountService(repo port.AccountRepository) port.AccountService {
-	return &accountService{repo: repo}
+// NewAccountService opens accounts in defaultCurrency unless the caller asks
+// for another currency that rates supports.
+func NewAccountService(
+	repo port.AccountRepository,
+	authorizer port.AccountAuthorizer,
+	rates port.FXRateProvider,
+	defaultCurrency domain.Currency,
+) port.AccountService {
+	return &accountService{repo: repo, authorizer: authorizer, rates: rates, defaultCurrency: defaultCurrency}
 }
 
-func (service *accountService) CreateAccount(ctx context.Context, docNumber string) (*domain.Account, error) {
-	acc, err := domain.NewAccount(docNumber)
+func (service *accountService) currency(code domain.Currency) (domain.Currency, error) {
+	if code == "" {
+		return service.defaultCurrency, nil
+	}
+	return parseCurrency(service.rates, code)
+}
+
+func (service *accountService) CreateAccount(ctx context.Context, docNumber string, currency domain.Currency) (*domain.Account, error) {
+	currency, err := service.currency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := domain.NewAccount(docNumber, currency)
 	if err != nil {
-		return nil, common.NewValidationError(domain.ErrMsgDocumentInvalid, err)
+		return nil, common.NewValidationError(domain.ErrMsgDocumentInvalid, err).
+			WithField("document_number", domain.ErrMsgDocumentInvalid)
 	}
 
-	savedAcc, err := service.repo.Save(ctx, acc)
+	savedAcc, err := service.repo.Save(ctx, acc, service.authorizer.Owner(ctx))
 	if err != nil {
 		if errors.Is(err, common.ErrAccountAlreadyExists) {
 			return nil, common.NewConflictError(domain.ErrMsgAccountExists, err)
@@ -31,19 +54,120 @@ func (service *accountService) CreateAccount(ctx context.Context, docNumber stri
 		return nil, common.NewInternalError(domain.ErrMsgSaveAccountFailed, err)
 	}
 
+	common.FromContext(ctx).Info("account created", common.Int64("account_id", savedAcc.ID))
+
 	return savedAcc, nil
 }
 
+func (service *accountService) CreateAccounts(ctx context.Context, documentNumbers []string, currency domain.Currency) (*domain.AccountBatchReport, error) {
+	if len(documentNumbers) == 0 || len(documentNumbers) > domain.MaxAccountBatch {
+		return nil, common.NewValidationError(domain.ErrMsgAccountBatchInvalid, common.ErrInvalidAccountBatch).
+			WithParam("max", domain.MaxAccountBatch).
+			WithField("document_numbers", domain.ErrMsgAccountBatchInvalid)
+	}
+
+	currency, err := service.currency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// slot[k] is the index in accounts of item k's document, or -1 when the
+	// document is invalid. Repeated documents share a slot.
+	slot := make([]int, len(documentNumbers))
+	first := make(map[string]int, len(documentNumbers))
+	accounts := make([]*domain.Account, 0, len(documentNumbers))
+	for k, doc := range documentNumbers {
+		acc, err := domain.NewAccount(doc, currency)
+		if err != nil {
+			slot[k] = -1
+			continue
+		}
+		i, ok := first[acc.DocumentNumber]
+		if !ok {
+			i = len(accounts)
+			first[acc.DocumentNumber] = i
+			accounts = append(accounts, acc)
+		}
+		slot[k] = i
+	}
+
+	created, err := service.repo.SaveBatch(ctx, accounts, service.authorizer.Owner(ctx))
+	if err != nil {
+		return nil, common.NewInternalError(domain.ErrMsgSaveAccountFailed, err)
+	}
+
+	report := &domain.AccountBatchReport{Results: make([]domain.AccountBatchResult, 0, len(documentNumbers))}
+	reported := make([]bool, len(accounts))
+	visible := make(map[int64]bool)
+	for k, doc := range documentNumbers {
+		result := domain.AccountBatchResult{Index: k, DocumentNumber: doc}
+		i := slot[k]
+		switch {
+		case i < 0:
+			result.Status, result.Reason = domain.AccountBatchInvalid, domain.ErrMsgDocumentInvalid
+		case created[i] && !reported[i]:
+			result.Status, result.AccountID = domain.AccountBatchCreated, accounts[i].ID
+		default:
+			result.Status, result.Reason = domain.AccountBatchDuplicate, domain.ErrMsgAccountExists
+			if created[i] {
+				result.Reason = domain.ErrMsgDocumentRepeated
+			}
+			shown, err := service.visible(ctx, accounts[i].ID, visible)
+			if err != nil {
+				return nil, err
+			}
+			if shown {
+				result.AccountID = accounts[i].ID
+			}
+		}
+		if i >= 0 {
+			reported[i] = true
+		}
+		report.Add(result)
+	}
+
+	common.FromContext(ctx).Info("accounts created",
+		common.Int("total", report.Total),
+		common.Int("created", report.Created),
+		common.Int("duplicates", report.Duplicates),
+		common.Int("invalid", report.Invalid),
+	)
+	return report, nil
+}
+
+// visible reports whether the caller may see accountID, caching answers in
+// cache. Duplicates of accounts owned by others hide the ID, as a lookup
+// would.
+func (service *accountService) visible(ctx context.Context, accountID int64, cache map[int64]bool) (bool, error) {
+	if shown, ok := cache[accountID]; ok {
+		return shown, nil
+	}
+	err := service.authorizer.Authorize(ctx, accountID)
+	if err != nil && !errors.Is(err, common.ErrAccountNotFound) {
+		return false, err
+	}
+	cache[accountID] = err == nil
+	return err == nil, nil
+}
+
 func (service *accountService) GetAccountByDocument(ctx context.Context, documentNumber string) (*domain.Account, error) {
 	acc, err := service.repo.FindByDocument(ctx, documentNumber)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := service.authorizer.Authorize(ctx, acc.ID); err != nil {
+		return nil, err
+	}
+
 	return acc, nil
 }
 
 func (s *accountService) GetAccountByID(ctx context.Context, id int64) (*domain.Account, error) {
+	if err := s.authorizer.Authorize(ctx, id); err != nil {
+		return nil, err
+	}
+
 	acc, err := s.repo.FindByAccountID(ctx, id)
 	if err != nil {
 		if errors.Is(err, common.ErrAccountNotFound) {
@@ -16,14 +16,20 @@ type MockAccountRepository struct {
 	mock.Mock
 }
 
-func (m *MockAccountRepository) Save(ctx context.Context, account *domain.Account) (*domain.Account, error) {
-	args := m.Called(ctx, account)
+func (m *MockAccountRepository) Save(ctx context.Context, account *domain.Account, owner string) (*domain.Account, error) {
+	args := m.Called(ctx, account, owner)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Account), args.Error(1)
 }
 
+func (m *MockAccountRepository) SaveBatch(ctx context.Context, accounts []*domain.Account, owner string) ([]bool, error) {
+	args := m.Called(ctx, accounts, owner)
+	created, _ := args.Get(0).([]bool)
+	return created, args.Error(1)
+}
+
 func (m *MockAccountRepository) FindByDocument(ctx context.Context, doc string) (*domain.Account, error) {
 	args := m.Called(ctx, doc)
 	if args.Get(0) == nil {
@@ -45,52 +51,75 @@ func TestAccountService(t *testing.T) {
 
 	t.Run("CreateAccount - Success", func(t *testing.T) {
 		repo := new(MockAccountRepository)
-		svc := services.NewAccountService(repo)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(nil), nil, "BRL")
 		doc := "12345678901"
 		acc := &domain.Account{DocumentNumber: doc}
 
 		repo.On("FindByDocument", ctx, doc).Return(nil, common.ErrAccountNotFound)
-		repo.On("Save", ctx, mock.Anything).Return(acc, nil)
+		repo.On("Save", ctx, mock.Anything, "").Return(acc, nil)
 
-		result, err := svc.CreateAccount(ctx, doc)
+		result, err := svc.CreateAccount(ctx, doc, "")
 
 		assert.NoError(t, err)
 		assert.Equal(t, doc, result.DocumentNumber)
 	})
 
+	t.Run("CreateAccount - Requested Currency", func(t *testing.T) {
+		repo := new(MockAccountRepository)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(nil), rates, "BRL")
+
+		repo.On("Save", ctx, &domain.Account{DocumentNumber: "12345678901", Currency: "USD"}, "").
+			Return(&domain.Account{ID: 1, DocumentNumber: "12345678901", Currency: "USD"}, nil)
+
+		result, err := svc.CreateAccount(ctx, "12345678901", "usd")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Currency("USD"), result.Currency)
+	})
+
+	t.Run("CreateAccount - Unsupported Currency", func(t *testing.T) {
+		repo := new(MockAccountRepository)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(nil), rates, "BRL")
+
+		_, err := svc.CreateAccount(ctx, "12345678901", "JPY")
+
+		assert.ErrorIs(t, err, common.ErrUnsupportedCurrency)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("CreateAccount - Invalid Document", func(t *testing.T) {
-		svc := services.NewAccountService(nil)
-		_, err := svc.CreateAccount(ctx, "invalid")
+		svc := services.NewAccountService(nil, services.NewAccountAuthorizer(nil), nil, "BRL")
+		_, err := svc.CreateAccount(ctx, "invalid", "")
 		assert.ErrorIs(t, err, common.ErrInvalidDocument)
 	})
 
 	t.Run("CreateAccount - Already Exists", func(t *testing.T) {
 		repo := new(MockAccountRepository)
-		svc := services.NewAccountService(repo)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(nil), nil, "BRL")
 		doc := "12345678901"
 
-		repo.On("Save", ctx, mock.Anything).Return(nil, common.ErrAccountAlreadyExists)
+		repo.On("Save", ctx, mock.Anything, "").Return(nil, common.ErrAccountAlreadyExists)
 
-		_, err := svc.CreateAccount(ctx, doc)
+		_, err := svc.CreateAccount(ctx, doc, "")
 
 		assert.ErrorIs(t, err, common.ErrAccountAlreadyExists)
 	})
 
 	t.Run("CreateAccount - Repository Error on Save", func(t *testing.T) {
 		repo := new(MockAccountRepository)
-		svc := services.NewAccountService(repo)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(nil), nil, "BRL")
 		doc := "12345678901"
 
-		repo.On("Save", ctx, mock.Anything).Return(nil, errors.New("db down"))
+		repo.On("Save", ctx, mock.Anything, "").Return(nil, errors.New("db down"))
 
-		_, err := svc.CreateAccount(ctx, doc)
+		_, err := svc.CreateAccount(ctx, doc, "")
 
 		assert.Error(t, err)
 	})
 
 	t.Run("GetAccountByDocument - Success", func(t *testing.T) {
 		repo := new(MockAccountRepository)
-		svc := services.NewAccountService(repo)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(nil), nil, "BRL")
 		repo.On("FindByDocument", ctx, "123").Return(&domain.Account{ID: 1}, nil)
 
 		res, err := svc.GetAccountByDocument(ctx, "123")
@@ -100,7 +129,7 @@ func TestAccountService(t *testing.T) {
 
 	t.Run("GetAccountByDocument - Error", func(t *testing.T) {
 		repo := new(MockAccountRepository)
-		svc := services.NewAccountService(repo)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(nil), nil, "BRL")
 		repo.On("FindByDocument", ctx, "123").Return(nil, errors.New("error"))
 
 		_, err := svc.GetAccountByDocument(ctx, "123")
@@ -109,7 +138,7 @@ func TestAccountService(t *testing.T) {
 
 	t.Run("GetAccountByID - Success", func(t *testing.T) {
 		repo := new(MockAccountRepository)
-		svc := services.NewAccountService(repo)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(nil), nil, "BRL")
 		repo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1}, nil)
 
 		res, err := svc.GetAccountByID(ctx, 1)
@@ -119,7 +148,7 @@ func TestAccountService(t *testing.T) {
 
 	t.Run("GetAccountByID - Not Found", func(t *testing.T) {
 		repo := new(MockAccountRepository)
-		svc := services.NewAccountService(repo)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(nil), nil, "BRL")
 		repo.On("FindByAccountID", ctx, int64(999)).Return(nil, common.ErrAccountNotFound)
 
 		res, err := svc.GetAccountByID(ctx, 999)
@@ -130,21 +159,136 @@ func TestAccountService(t *testing.T) {
 
 	t.Run("GetAccountByID - Database Error", func(t *testing.T) {
 		repo := new(MockAccountRepository)
-		svc := services.NewAccountService(repo)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(nil), nil, "BRL")
 		repo.On("FindByAccountID", ctx, int64(1)).Return(nil, errors.New("connection failed"))
 
 		res, err := svc.GetAccountByID(ctx, 1)
 		assert.Error(t, err)
 		assert.Nil(t, res)
-		assert.Contains(t, err.Error(), "database error")
+		assert.Contains(t, err.Error(), domain.ErrMsgDatabaseError)
 	})
 
 	t.Run("GetAccountByID - Generic Error", func(t *testing.T) {
 		repo := new(MockAccountRepository)
-		svc := services.NewAccountService(repo)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(nil), nil, "BRL")
 		repo.On("FindByAccountID", ctx, int64(1)).Return(nil, errors.New("error"))
 
 		_, err := svc.GetAccountByID(ctx, 1)
 		assert.Error(t, err)
 	})
 }
+
+func TestAccountServiceOwnership(t *testing.T) {
+	t.Run("CreateAccount - Binds account to caller", func(t *testing.T) {
+		repo := new(MockAccountRepository)
+		owners := new(MockAccountOwnershipRepository)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(owners), nil, "BRL")
+		ctx := customerContext("client-1")
+
+		repo.On("Save", ctx, mock.Anything, "client-1").Return(&domain.Account{ID: 10, DocumentNumber: "12345678901"}, nil)
+
+		res, err := svc.CreateAccount(ctx, "12345678901", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), res.ID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("GetAccountByID - Foreign account is not found", func(t *testing.T) {
+		repo := new(MockAccountRepository)
+		owners := new(MockAccountOwnershipRepository)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(owners), nil, "BRL")
+		ctx := customerContext("client-1")
+
+		owners.On("IsOwner", ctx, "client-1", int64(2)).Return(false, nil)
+
+		res, err := svc.GetAccountByID(ctx, 2)
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, common.ErrAccountNotFound)
+		repo.AssertNotCalled(t, "FindByAccountID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("GetAccountByID - Admin reads any account", func(t *testing.T) {
+		repo := new(MockAccountRepository)
+		owners := new(MockAccountOwnershipRepository)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(owners), nil, "BRL")
+		ctx := adminContext()
+
+		repo.On("FindByAccountID", ctx, int64(2)).Return(&domain.Account{ID: 2}, nil)
+
+		res, err := svc.GetAccountByID(ctx, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res.ID)
+	})
+
+	t.Run("GetAccountByDocument - Foreign account is not found", func(t *testing.T) {
+		repo := new(MockAccountRepository)
+		owners := new(MockAccountOwnershipRepository)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(owners), nil, "BRL")
+		ctx := customerContext("client-1")
+
+		repo.On("FindByDocument", ctx, "123").Return(&domain.Account{ID: 2}, nil)
+		owners.On("IsOwner", ctx, "client-1", int64(2)).Return(false, nil)
+
+		res, err := svc.GetAccountByDocument(ctx, "123")
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, common.ErrAccountNotFound)
+	})
+
+	t.Run("CreateAccounts - Per Item Results", func(t *testing.T) {
+		repo := new(MockAccountRepository)
+		owners := new(MockAccountOwnershipRepository)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(owners), nil, "BRL")
+		ctx := customerContext("client-1")
+
+		repo.On("SaveBatch", ctx, []*domain.Account{
+			{DocumentNumber: "12345678901", Currency: "BRL"},
+			{DocumentNumber: "98765432100", Currency: "BRL"},
+			{DocumentNumber: "11111111111", Currency: "BRL"},
+		}, "client-1").Run(func(args mock.Arguments) {
+			for i, acc := range args.Get(1).([]*domain.Account) {
+				acc.ID = int64(10 + i)
+			}
+		}).Return([]bool{true, false, false}, nil)
+		owners.On("IsOwner", ctx, "client-1", int64(10)).Return(true, nil).Once()
+		owners.On("IsOwner", ctx, "client-1", int64(11)).Return(true, nil).Once()
+		owners.On("IsOwner", ctx, "client-1", int64(12)).Return(false, nil).Once()
+
+		report, err := svc.CreateAccounts(ctx, []string{"12345678901", "98765432100", "123", " 12345678901", "11111111111"}, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.AccountBatchReport{Total: 5, Created: 1, Duplicates: 3, Invalid: 1, Results: []domain.AccountBatchResult{
+			{Index: 0, DocumentNumber: "12345678901", Status: domain.AccountBatchCreated, AccountID: 10},
+			{Index: 1, DocumentNumber: "98765432100", Status: domain.AccountBatchDuplicate, AccountID: 11, Reason: domain.ErrMsgAccountExists},
+			{Index: 2, DocumentNumber: "123", Status: domain.AccountBatchInvalid, Reason: domain.ErrMsgDocumentInvalid},
+			{Index: 3, DocumentNumber: " 12345678901", Status: domain.AccountBatchDuplicate, AccountID: 10, Reason: domain.ErrMsgDocumentRepeated},
+			{Index: 4, DocumentNumber: "11111111111", Status: domain.AccountBatchDuplicate, Reason: domain.ErrMsgAccountExists},
+		}}, report)
+		owners.AssertExpectations(t)
+	})
+
+	t.Run("CreateAccounts - Batch Too Large", func(t *testing.T) {
+		svc := services.NewAccountService(nil, services.NewAccountAuthorizer(nil), nil, "BRL")
+
+		_, err := svc.CreateAccounts(context.Background(), make([]string, domain.MaxAccountBatch+1), "")
+
+		var de *common.DomainError
+		assert.ErrorAs(t, err, &de)
+		assert.Equal(t, common.CodeValidation, de.Code)
+		assert.Equal(t, map[string]any{"max": domain.MaxAccountBatch}, de.Params)
+	})
+
+	t.Run("CreateAccounts - Save Fails", func(t *testing.T) {
+		repo := new(MockAccountRepository)
+		svc := services.NewAccountService(repo, services.NewAccountAuthorizer(nil), nil, "BRL")
+
+		repo.On("SaveBatch", mock.Anything, mock.Anything, "").Return(nil, errors.New("db down"))
+
+		_, err := svc.CreateAccounts(context.Background(), []string{"12345678901"}, "")
+
+		assert.True(t, common.Is(err, common.ErrInternal))
+	})
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+type apiKeyService struct {
+	keys port.APIKeyRepository
+}
+
+func NewAPIKeyService(keys port.APIKeyRepository) port.APIKeyService {
+	return &apiKeyService{keys: keys}
+}
+
+func (service *apiKeyService) Create(ctx context.Context, name, subject string, roles, scopes []string) (*domain.APIKey, string, error) {
+	key, secret, err := domain.NewAPIKey(name, subject, roles, scopes)
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidAPIKeyName) {
+			return nil, "", common.NewValidationError(domain.ErrMsgAPIKeyNameInvalid, err)
+		}
+		return nil, "", common.NewInternalError(domain.ErrMsgUnexpectedError, err)
+	}
+
+	if err := service.keys.Create(ctx, key, domain.HashAPIKey(secret)); err != nil {
+		return nil, "", common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+
+	common.FromContext(ctx).Info("api key created",
+		common.Int64("key_id", key.ID),
+		common.String("prefix", key.Prefix),
+		common.String("subject", key.Subject),
+	)
+	return key, secret, nil
+}
+
+func (service *apiKeyService) List(ctx context.Context) ([]*domain.APIKey, error) {
+	keys, err := service.keys.List(ctx)
+	if err != nil {
+		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+	return keys, nil
+}
+
+func (service *apiKeyService) Revoke(ctx context.Context, keyID int64) error {
+	if err := service.keys.Revoke(ctx, keyID); err != nil {
+		if errors.Is(err, common.ErrAPIKeyNotFound) {
+			return common.NewNotFoundError(domain.ErrMsgAPIKeyNotFound, err)
+		}
+		return common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+
+	common.FromContext(ctx).Info("api key revoked", common.Int64("key_id", keyID))
+	return nil
+}
+
+// Verify returns the principal of an active key. Unknown and revoked keys
+// fail alike so callers cannot tell them apart.
+func (service *apiKeyService) Verify(ctx context.Context, secret string) (*domain.Principal, error) {
+	if !strings.HasPrefix(secret, domain.APIKeyPrefix) {
+		return nil, common.ErrInvalidAPIKey
+	}
+
+	key, err := service.keys.FindByHash(ctx, domain.HashAPIKey(secret))
+	if err != nil {
+		if errors.Is(err, common.ErrAPIKeyNotFound) {
+			return nil, common.ErrInvalidAPIKey
+		}
+		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+	if key.Revoked() {
+		return nil, common.ErrInvalidAPIKey
+	}
+	return key.Principal(), nil
+}
@@ -0,0 +1,131 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	services "github.com/evythrossell/account-management-api/internal/core/service"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockAPIKeyRepository struct{ mock.Mock }
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey, hash string) error {
+	return m.Called(ctx, key, hash).Error(0)
+}
+
+func (m *MockAPIKeyRepository) FindByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	args := m.Called(ctx, hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context) ([]*domain.APIKey, error) {
+	args := m.Called(ctx)
+	keys, _ := args.Get(0).([]*domain.APIKey)
+	return keys, args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, keyID int64) error {
+	return m.Called(ctx, keyID).Error(0)
+}
+
+func TestAPIKeyService(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Create - Stores Only The Hash", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		var hash string
+		repo.On("Create", ctx, mock.AnythingOfType("*domain.APIKey"), mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { hash = args.String(2) }).
+			Return(nil)
+
+		key, secret, err := services.NewAPIKeyService(repo).Create(ctx, "billing", "", []string{domain.RoleAdmin}, nil)
+
+		require.NoError(t, err)
+		assert.True(t, len(secret) > len(domain.APIKeyPrefix))
+		assert.Equal(t, domain.HashAPIKey(secret), hash)
+		assert.NotContains(t, hash, secret)
+		assert.Equal(t, "apikey:billing", key.Subject)
+		assert.Equal(t, secret[:len(key.Prefix)], key.Prefix)
+	})
+
+	t.Run("Create - Missing Name", func(t *testing.T) {
+		_, _, err := services.NewAPIKeyService(new(MockAPIKeyRepository)).Create(ctx, " ", "", nil, nil)
+
+		var de *common.DomainError
+		require.ErrorAs(t, err, &de)
+		assert.Equal(t, domain.ErrMsgAPIKeyNameInvalid, de.Key)
+	})
+
+	t.Run("Revoke - Not Found", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		repo.On("Revoke", ctx, int64(9)).Return(common.ErrAPIKeyNotFound)
+
+		err := services.NewAPIKeyService(repo).Revoke(ctx, 9)
+
+		var de *common.DomainError
+		require.ErrorAs(t, err, &de)
+		assert.Equal(t, domain.ErrMsgAPIKeyNotFound, de.Key)
+	})
+
+	t.Run("Verify - Active Key", func(t *testing.T) {
+		secret := domain.APIKeyPrefix + "0123abcd"
+		repo := new(MockAPIKeyRepository)
+		repo.On("FindByHash", ctx, domain.HashAPIKey(secret)).
+			Return(&domain.APIKey{Subject: "apikey:billing", Roles: []string{domain.RoleAdmin}}, nil)
+
+		principal, err := services.NewAPIKeyService(repo).Verify(ctx, secret)
+
+		require.NoError(t, err)
+		assert.Equal(t, "apikey:billing", principal.Subject)
+		assert.True(t, principal.IsAdmin())
+	})
+
+	t.Run("Verify - Revoked Key", func(t *testing.T) {
+		revoked := time.Now()
+		repo := new(MockAPIKeyRepository)
+		repo.On("FindByHash", ctx, mock.Anything).Return(&domain.APIKey{RevokedAt: &revoked}, nil)
+
+		_, err := services.NewAPIKeyService(repo).Verify(ctx, domain.APIKeyPrefix+"0123abcd")
+
+		assert.ErrorIs(t, err, common.ErrInvalidAPIKey)
+	})
+
+	t.Run("Verify - Unknown Key", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		repo.On("FindByHash", ctx, mock.Anything).Return(nil, common.ErrAPIKeyNotFound)
+
+		_, err := services.NewAPIKeyService(repo).Verify(ctx, domain.APIKeyPrefix+"0123abcd")
+
+		assert.ErrorIs(t, err, common.ErrInvalidAPIKey)
+	})
+
+	t.Run("Verify - Wrong Format Skips Lookup", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+
+		_, err := services.NewAPIKeyService(repo).Verify(ctx, "not-a-key")
+
+		assert.ErrorIs(t, err, common.ErrInvalidAPIKey)
+		repo.AssertNotCalled(t, "FindByHash", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Verify - Database Error", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		repo.On("FindByHash", ctx, mock.Anything).Return(nil, errors.New("connection reset"))
+
+		_, err := services.NewAPIKeyService(repo).Verify(ctx, domain.APIKeyPrefix+"0123abcd")
+
+		var de *common.DomainError
+		require.ErrorAs(t, err, &de)
+		assert.Equal(t, domain.ErrMsgDatabaseError, de.Key)
+	})
+}
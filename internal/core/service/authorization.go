@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+type accountAuthorizer struct {
+	owners port.AccountOwnershipRepository
+}
+
+// NewAccountAuthorizer enforces account ownership for the principal found in
+// the context. Calls without a principal come from trusted in-process callers
+// (or from an API running with authentication disabled) and are allowed.
+func NewAccountAuthorizer(owners port.AccountOwnershipRepository) port.AccountAuthorizer {
+	return &accountAuthorizer{owners: owners}
+}
+
+func (a *accountAuthorizer) Authorize(ctx context.Context, accountID int64) error {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok || principal.IsAdmin() {
+		return nil
+	}
+
+	owned, err := a.owners.IsOwner(ctx, principal.Subject, accountID)
+	if err != nil {
+		return common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+	if !owned {
+		return common.NewNotFoundError(domain.ErrMsgAccountNotFound, common.ErrAccountNotFound)
+	}
+
+	return nil
+}
+
+func (a *accountAuthorizer) Owner(ctx context.Context) string {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return principal.Subject
+}
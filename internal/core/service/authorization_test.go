@@ -0,0 +1,85 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	services "github.com/evythrossell/account-management-api/internal/core/service"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAccountOwnershipRepository struct{ mock.Mock }
+
+func (m *MockAccountOwnershipRepository) IsOwner(ctx context.Context, subject string, accountID int64) (bool, error) {
+	args := m.Called(ctx, subject, accountID)
+	return args.Bool(0), args.Error(1)
+}
+
+func customerContext(subject string) context.Context {
+	return domain.ContextWithPrincipal(context.Background(), &domain.Principal{Subject: subject})
+}
+
+func adminContext() context.Context {
+	return domain.ContextWithPrincipal(context.Background(), &domain.Principal{Subject: "ops", Roles: []string{domain.RoleAdmin}})
+}
+
+func TestAccountAuthorizer(t *testing.T) {
+	t.Run("Authorize - No principal is trusted", func(t *testing.T) {
+		owners := new(MockAccountOwnershipRepository)
+		authz := services.NewAccountAuthorizer(owners)
+
+		assert.NoError(t, authz.Authorize(context.Background(), 1))
+		owners.AssertNotCalled(t, "IsOwner", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Authorize - Admin bypasses ownership", func(t *testing.T) {
+		owners := new(MockAccountOwnershipRepository)
+		authz := services.NewAccountAuthorizer(owners)
+
+		assert.NoError(t, authz.Authorize(adminContext(), 1))
+		owners.AssertNotCalled(t, "IsOwner", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Authorize - Owner is allowed", func(t *testing.T) {
+		owners := new(MockAccountOwnershipRepository)
+		authz := services.NewAccountAuthorizer(owners)
+		ctx := customerContext("client-1")
+		owners.On("IsOwner", ctx, "client-1", int64(1)).Return(true, nil)
+
+		assert.NoError(t, authz.Authorize(ctx, 1))
+	})
+
+	t.Run("Authorize - Non owner gets not found", func(t *testing.T) {
+		owners := new(MockAccountOwnershipRepository)
+		authz := services.NewAccountAuthorizer(owners)
+		ctx := customerContext("client-1")
+		owners.On("IsOwner", ctx, "client-1", int64(2)).Return(false, nil)
+
+		err := authz.Authorize(ctx, 2)
+
+		assert.ErrorIs(t, err, common.ErrAccountNotFound)
+		assert.True(t, common.Is(err, common.ErrNotFound))
+	})
+
+	t.Run("Authorize - Repository error", func(t *testing.T) {
+		owners := new(MockAccountOwnershipRepository)
+		authz := services.NewAccountAuthorizer(owners)
+		ctx := customerContext("client-1")
+		owners.On("IsOwner", ctx, "client-1", int64(1)).Return(false, errors.New("connection failed"))
+
+		err := authz.Authorize(ctx, 1)
+
+		assert.True(t, common.Is(err, common.ErrInternal))
+	})
+
+	t.Run("Owner - Caller's subject", func(t *testing.T) {
+		authz := services.NewAccountAuthorizer(nil)
+
+		assert.Equal(t, "client-1", authz.Owner(customerContext("client-1")))
+		assert.Empty(t, authz.Owner(context.Background()))
+	})
+}
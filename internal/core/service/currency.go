@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+// parseCurrency accepts only currencies rates can convert. A nil rates
+// supports none.
+func parseCurrency(rates port.FXRateProvider, code domain.Currency) (domain.Currency, error) {
+	currency, err := domain.ParseCurrency(string(code))
+	if err != nil {
+		return "", common.NewValidationError(domain.ErrMsgCurrencyInvalid, err).
+			WithField("currency", domain.ErrMsgCurrencyInvalid)
+	}
+	if rates == nil || !rates.Supports(currency) {
+		return "", unsupportedCurrency(currency, common.ErrUnsupportedCurrency)
+	}
+	return currency, nil
+}
+
+func unsupportedCurrency(currency domain.Currency, err error) error {
+	return common.NewValidationError(domain.ErrMsgCurrencyUnsupported, err).
+		WithParam("currency", string(currency)).
+		WithField("currency", domain.ErrMsgCurrencyUnsupported)
+}
+
+// bookInCurrency books tx in the account's currency, converting it when it
+// was made in another one. An empty currency means the account's own.
+func bookInCurrency(ctx context.Context, rates port.FXRateProvider, tx *domain.Transaction, currency domain.Currency, account *domain.Account) error {
+	tx.Currency = account.Currency
+	if currency == "" || currency == account.Currency {
+		return nil
+	}
+
+	from, err := parseCurrency(rates, currency)
+	if err != nil {
+		return err
+	}
+	if from == account.Currency {
+		return nil
+	}
+	rate, err := rates.Rate(ctx, from, account.Currency)
+	if err != nil {
+		if errors.Is(err, common.ErrUnsupportedCurrency) {
+			return unsupportedCurrency(from, err)
+		}
+		return common.NewInternalError(domain.ErrMsgUnexpectedError, err)
+	}
+	if err := tx.Convert(rate); err != nil {
+		return common.NewValidationError(domain.ErrMsgAmountInvalid, err).
+			WithField("amount", domain.ErrMsgAmountInvalid)
+	}
+	return nil
+}
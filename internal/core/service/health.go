@@ -2,18 +2,125 @@ package services
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/evythrossell/account-management-api/internal/core/domain"
 	"github.com/evythrossell/account-management-api/internal/core/port"
+	common "github.com/evythrossell/account-management-api/pkg"
 )
 
+type healthCheck struct {
+	name    string
+	timeout time.Duration
+	check   port.HealthCheckFunc
+}
+
 type healthService struct {
-	checker port.DBHealthChecker
+	cacheTTL time.Duration
+	now      func() time.Time
+	notReady atomic.Bool
+
+	mu       sync.Mutex
+	checks   []healthCheck
+	cached   *domain.HealthReport
+	cachedAt time.Time
+}
+
+// NewHealthService returns an empty check registry. Readiness results are
+// reused for cacheTTL so frequent probes do not hammer dependencies.
+func NewHealthService(cacheTTL time.Duration) port.HealthService {
+	return &healthService{cacheTTL: cacheTTL, now: time.Now}
+}
+
+func (s *healthService) Register(name string, timeout time.Duration, check port.HealthCheckFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checks = append(s.checks, healthCheck{name: name, timeout: timeout, check: check})
+	s.cached = nil
+}
+
+func (s *healthService) Live(ctx context.Context) domain.HealthReport {
+	return domain.HealthReport{Status: domain.HealthStatusUp, CheckedAt: s.now()}
+}
+
+func (s *healthService) SetNotReady() {
+	s.notReady.Store(true)
 }
 
-func NewHealthService(checker port.DBHealthChecker) port.HealthService {
-	return &healthService{checker: checker}
+func (s *healthService) Ready(ctx context.Context) domain.HealthReport {
+	if s.notReady.Load() {
+		return domain.HealthReport{Status: domain.HealthStatusDown, Reason: "shutting down", CheckedAt: s.now()}
+	}
+
+	// Holding the lock while checking lets concurrent probes share one run.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cached != nil && s.now().Sub(s.cachedAt) < s.cacheTTL {
+		return *s.cached
+	}
+
+	report := s.run(context.WithoutCancel(ctx))
+	s.cached = &report
+	s.cachedAt = s.now()
+	return report
 }
 
-func (service *healthService) Check(ctx context.Context) error {
-	return service.checker.PingContext(ctx)
+func (s *healthService) run(ctx context.Context) domain.HealthReport {
+	report := domain.HealthReport{
+		Status:     domain.HealthStatusUp,
+		Components: make([]domain.ComponentHealth, len(s.checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, hc := range s.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Components[i] = s.runCheck(ctx, hc)
+		}()
+	}
+	wg.Wait()
+
+	for _, component := range report.Components {
+		if component.Status != domain.HealthStatusUp {
+			report.Status = domain.HealthStatusDown
+		}
+	}
+	report.CheckedAt = s.now()
+	return report
+}
+
+func (s *healthService) runCheck(ctx context.Context, hc healthCheck) domain.ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, hc.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := hc.check(ctx)
+	latency := time.Since(start)
+
+	component := domain.ComponentHealth{
+		Name:      hc.name,
+		Status:    domain.HealthStatusUp,
+		LatencyMs: float64(latency.Microseconds()) / 1000,
+	}
+	if err == nil {
+		return component
+	}
+
+	component.Status = domain.HealthStatusDown
+	component.Error = "unavailable"
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		component.Error = "timeout"
+	}
+	common.FromContext(ctx).Warn("health check failed",
+		common.String("check", hc.name),
+		common.Duration("latency", latency),
+		common.Err(err),
+	)
+	return component
 }
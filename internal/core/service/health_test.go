@@ -3,47 +3,91 @@ package services_test
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/evythrossell/account-management-api/internal/core/domain"
 	services "github.com/evythrossell/account-management-api/internal/core/service"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
-type MockDBHealthChecker struct {
-	mock.Mock
-}
-
-func (m *MockDBHealthChecker) PingContext(ctx context.Context) error {
-	args := m.Called(ctx)
-	return args.Error(0)
-}
-
 func TestHealthService(t *testing.T) {
 	ctx := context.Background()
 
-	t.Run("Check - Success", func(t *testing.T) {
-		mockChecker := new(MockDBHealthChecker)
-		svc := services.NewHealthService(mockChecker)
+	t.Run("Live - Always Up", func(t *testing.T) {
+		svc := services.NewHealthService(0)
+		svc.Register("database", time.Second, func(ctx context.Context) error { return errors.New("down") })
 
-		mockChecker.On("PingContext", ctx).Return(nil)
+		assert.Equal(t, domain.HealthStatusUp, svc.Live(ctx).Status)
+	})
 
-		err := svc.Check(ctx)
+	t.Run("Ready - All Checks Up", func(t *testing.T) {
+		svc := services.NewHealthService(0)
+		svc.Register("database", time.Second, func(ctx context.Context) error { return nil })
+		svc.Register("migrations", time.Second, func(ctx context.Context) error { return nil })
 
-		assert.NoError(t, err)
-		mockChecker.AssertExpectations(t)
+		report := svc.Ready(ctx)
+
+		assert.True(t, report.Healthy())
+		require.Len(t, report.Components, 2)
+		assert.Equal(t, "database", report.Components[0].Name)
+		assert.Equal(t, domain.HealthStatusUp, report.Components[0].Status)
+		assert.Equal(t, "migrations", report.Components[1].Name)
 	})
 
-	t.Run("Check - Failure", func(t *testing.T) {
-		mockChecker := new(MockDBHealthChecker)
-		svc := services.NewHealthService(mockChecker)
+	t.Run("Ready - Failing Check Hides Cause", func(t *testing.T) {
+		svc := services.NewHealthService(0)
+		svc.Register("database", time.Second, func(ctx context.Context) error {
+			return errors.New("dial tcp 10.0.0.5:5432: connection refused")
+		})
+
+		report := svc.Ready(ctx)
+
+		assert.False(t, report.Healthy())
+		assert.Equal(t, domain.HealthStatusDown, report.Components[0].Status)
+		assert.Equal(t, "unavailable", report.Components[0].Error)
+	})
+
+	t.Run("Ready - Check Timeout", func(t *testing.T) {
+		svc := services.NewHealthService(0)
+		svc.Register("slow", 10*time.Millisecond, func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		report := svc.Ready(ctx)
+
+		assert.Equal(t, domain.HealthStatusDown, report.Status)
+		assert.Equal(t, "timeout", report.Components[0].Error)
+		assert.GreaterOrEqual(t, report.Components[0].LatencyMs, 10.0)
+	})
+
+	t.Run("Ready - Cached Within TTL", func(t *testing.T) {
+		var calls atomic.Int32
+		svc := services.NewHealthService(time.Minute)
+		svc.Register("database", time.Second, func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		})
+
+		svc.Ready(ctx)
+		svc.Ready(ctx)
+
+		assert.Equal(t, int32(1), calls.Load())
+	})
 
-		mockChecker.On("PingContext", ctx).Return(errors.New("db connection failed"))
+	t.Run("Ready - Fails After SetNotReady", func(t *testing.T) {
+		svc := services.NewHealthService(time.Minute)
+		svc.Register("database", time.Second, func(ctx context.Context) error { return nil })
+		assert.True(t, svc.Ready(ctx).Healthy())
 
-		err := svc.Check(ctx)
+		svc.SetNotReady()
+		report := svc.Ready(ctx)
 
-		assert.Error(t, err)
-		assert.Equal(t, "db connection failed", err.Error())
-		mockChecker.AssertExpectations(t)
+		assert.False(t, report.Healthy())
+		assert.Equal(t, "shutting down", report.Reason)
+		assert.Equal(t, domain.HealthStatusUp, svc.Live(ctx).Status)
 	})
 }
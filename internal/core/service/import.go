@@ -0,0 +1,304 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+// finishTimeout bounds recording a job's outcome, which happens even after
+// the worker has been asked to stop.
+const finishTimeout = 5 * time.Second
+
+type importService struct {
+	jobs       port.ImportJobRepository
+	accRepo    port.AccountRepository
+	txRepo     port.TransactionRepository
+	authorizer port.AccountAuthorizer
+	allocator  port.PaymentAllocator
+	rates      port.FXRateProvider
+	chunkSize  int
+	jobTimeout time.Duration
+	now        func() time.Time
+}
+
+// NewImportService saves best-effort imports chunkSize rows at a time and
+// gives each background job jobTimeout to finish. allocator may be nil when
+// statements are not in use. Rows in another currency than their account's
+// are converted with rates.
+func NewImportService(
+	jobs port.ImportJobRepository,
+	ar port.AccountRepository,
+	tr port.TransactionRepository,
+	authorizer port.AccountAuthorizer,
+	allocator port.PaymentAllocator,
+	rates port.FXRateProvider,
+	chunkSize int,
+	jobTimeout time.Duration,
+) port.ImportService {
+	return &importService{
+		jobs:       jobs,
+		accRepo:    ar,
+		txRepo:     tr,
+		authorizer: authorizer,
+		allocator:  allocator,
+		rates:      rates,
+		chunkSize:  chunkSize,
+		jobTimeout: jobTimeout,
+		now:        time.Now,
+	}
+}
+
+func (service *importService) Import(ctx context.Context, mode domain.ImportMode, rows []domain.ImportRow) (*domain.ImportReport, error) {
+	report := domain.NewImportReport(rows)
+	accounts := make(map[int64]*domain.Account)
+
+	var (
+		valid []*domain.Transaction
+		index []int
+	)
+	for i, row := range rows {
+		tx, reason, err := service.validate(ctx, row, accounts)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			report.Reject(i, reason)
+			continue
+		}
+		valid = append(valid, tx)
+		index = append(index, i)
+	}
+
+	switch {
+	case mode == domain.ImportAllOrNothing && report.Rejected > 0:
+		for _, i := range index {
+			report.Skip(i, domain.ErrMsgImportBatchRejected)
+		}
+		valid = nil
+	case mode == domain.ImportAllOrNothing:
+		if err := service.txRepo.SaveBatch(ctx, valid); err != nil {
+			return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+		}
+		for k, tx := range valid {
+			report.Create(index[k], tx.ID)
+		}
+	default:
+		valid = service.saveChunks(ctx, valid, index, report)
+	}
+
+	report.Transactions = valid
+	service.allocatePayments(ctx, valid)
+
+	common.FromContext(ctx).Info("transactions imported",
+		common.String("mode", string(mode)),
+		common.Int("total", report.Total),
+		common.Int("created", report.Created),
+		common.Int("rejected", report.Rejected),
+		common.Int("skipped", report.Skipped),
+	)
+	return report, nil
+}
+
+// saveChunks saves valid in chunks, each on its own, and returns the
+// transactions that were saved. Lines of a chunk that fails are skipped.
+func (service *importService) saveChunks(ctx context.Context, valid []*domain.Transaction, index []int, report *domain.ImportReport) []*domain.Transaction {
+	saved := make([]*domain.Transaction, 0, len(valid))
+	for start := 0; start < len(valid); start += service.chunkSize {
+		end := min(start+service.chunkSize, len(valid))
+		if err := service.txRepo.SaveBatch(ctx, valid[start:end]); err != nil {
+			common.FromContext(ctx).Warn("import chunk failed",
+				common.Int("first_line", report.Lines[index[start]].Line),
+				common.Int("lines", end-start),
+				common.Err(err),
+			)
+			for k := start; k < end; k++ {
+				report.Skip(index[k], domain.ErrMsgDatabaseError)
+			}
+			continue
+		}
+		for k := start; k < end; k++ {
+			report.Create(index[k], valid[k].ID)
+		}
+		saved = append(saved, valid[start:end]...)
+	}
+	return saved
+}
+
+// validate returns the transaction for row, or the reason it was rejected.
+// accounts caches the accounts visible to the caller, nil for those that
+// do not exist or are hidden.
+func (service *importService) validate(ctx context.Context, row domain.ImportRow, accounts map[int64]*domain.Account) (*domain.Transaction, string, error) {
+	if row.Problem != "" {
+		return nil, row.Problem, nil
+	}
+
+	tx, err := domain.NewTransaction(row.AccountID, domain.OperationType(row.OperationTypeID), row.Amount)
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidAmount) {
+			return nil, domain.ErrMsgAmountInvalid, nil
+		}
+		return nil, domain.ErrMsgOperationTypeInvalid, nil
+	}
+
+	account, ok := accounts[row.AccountID]
+	if !ok {
+		account, err = service.findAccount(ctx, row.AccountID)
+		if err != nil {
+			return nil, "", err
+		}
+		accounts[row.AccountID] = account
+	}
+	if account == nil {
+		return nil, domain.ErrMsgAccountIDDoesNotExist, nil
+	}
+
+	if err := bookInCurrency(ctx, service.rates, tx, row.Currency, account); err != nil {
+		var de *common.DomainError
+		if errors.As(err, &de) && de.Code == common.CodeValidation {
+			return nil, de.Key, nil
+		}
+		return nil, "", err
+	}
+
+	if !row.EventDate.IsZero() {
+		tx.EventDate = row.EventDate
+	}
+	return tx, "", nil
+}
+
+func (service *importService) findAccount(ctx context.Context, accountID int64) (*domain.Account, error) {
+	if err := service.authorizer.Authorize(ctx, accountID); err != nil {
+		if errors.Is(err, common.ErrAccountNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	account, err := service.accRepo.FindByAccountID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, common.ErrAccountNotFound) {
+			return nil, nil
+		}
+		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+	return account, nil
+}
+
+// allocatePayments settles statements for every account that received a
+// payment. As with single payments, a failure waits for the next one.
+func (service *importService) allocatePayments(ctx context.Context, saved []*domain.Transaction) {
+	if service.allocator == nil {
+		return
+	}
+
+	allocated := make(map[int64]bool)
+	for _, tx := range saved {
+		if !tx.OperationTypeID.IsCredit() || allocated[tx.AccountID] {
+			continue
+		}
+		allocated[tx.AccountID] = true
+		if err := service.allocator.AllocatePayments(ctx, tx.AccountID); err != nil {
+			common.FromContext(ctx).Warn("payment allocation failed",
+				common.Int64("account_id", tx.AccountID),
+				common.Err(err),
+			)
+		}
+	}
+}
+
+func (service *importService) Submit(ctx context.Context, mode domain.ImportMode, rows []domain.ImportRow) (*domain.ImportJob, error) {
+	if len(rows) == 0 {
+		return nil, common.NewValidationError(domain.ErrMsgImportFileEmpty, common.ErrInvalidImportFile)
+	}
+
+	principal, _ := domain.PrincipalFromContext(ctx)
+	job, err := service.jobs.Create(ctx, &domain.ImportJob{
+		Status:    domain.ImportPending,
+		Mode:      mode,
+		Lines:     len(rows),
+		Principal: principal,
+	}, rows)
+	if err != nil {
+		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+
+	common.FromContext(ctx).Info("import job submitted",
+		common.Int64("import_job_id", job.ID),
+		common.Int("lines", job.Lines),
+	)
+	return job, nil
+}
+
+// GetJob only shows a job to the principal that submitted it and to
+// admins.
+func (service *importService) GetJob(ctx context.Context, jobID int64) (*domain.ImportJob, error) {
+	job, err := service.jobs.FindByID(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, common.ErrImportJobNotFound) {
+			return nil, common.NewNotFoundError(domain.ErrMsgImportJobNotFound, err)
+		}
+		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+
+	if principal, ok := domain.PrincipalFromContext(ctx); ok && !principal.IsAdmin() {
+		if job.Principal == nil || job.Principal.Subject != principal.Subject {
+			return nil, common.NewNotFoundError(domain.ErrMsgImportJobNotFound, common.ErrImportJobNotFound)
+		}
+	}
+	return job, nil
+}
+
+func (service *importService) RunPending(ctx context.Context) ([]*domain.ImportJob, error) {
+	var ran []*domain.ImportJob
+	for ctx.Err() == nil {
+		// Jobs are cancelled at jobTimeout; twice that leaves room to record
+		// the outcome before a job counts as abandoned.
+		job, rows, err := service.jobs.Claim(ctx, service.now().Add(-2*service.jobTimeout))
+		if err != nil {
+			return ran, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+		}
+		if job == nil {
+			return ran, nil
+		}
+		service.run(ctx, job, rows)
+		ran = append(ran, job)
+	}
+	return ran, nil
+}
+
+func (service *importService) run(ctx context.Context, job *domain.ImportJob, rows []domain.ImportRow) {
+	log := common.FromContext(ctx).With(common.Int64("import_job_id", job.ID))
+	runCtx, cancel := context.WithTimeout(common.WithLogger(ctx, log), service.jobTimeout)
+	defer cancel()
+	if job.Principal != nil {
+		runCtx = domain.ContextWithPrincipal(runCtx, job.Principal)
+	}
+
+	report, err := service.Import(runCtx, job.Mode, rows)
+	job.Status, job.Report = domain.ImportSucceeded, report
+	switch {
+	case runCtx.Err() != nil:
+		// Lines after the interruption were skipped rather than rejected,
+		// so the report is kept but the job does not count as done.
+		log.Warn("import job interrupted", common.Err(runCtx.Err()))
+		job.Status, job.Error = domain.ImportFailed, domain.ErrMsgImportInterrupted
+	case err != nil:
+		log.Error("import job failed", common.Err(err))
+		job.Status, job.Error = domain.ImportFailed, domain.ErrMsgDatabaseError
+		var de *common.DomainError
+		if errors.As(err, &de) {
+			job.Error = de.Key
+		}
+	}
+
+	finishCtx, cancelFinish := context.WithTimeout(context.WithoutCancel(ctx), finishTimeout)
+	defer cancelFinish()
+	if err := service.jobs.Finish(finishCtx, job); err != nil {
+		log.Error("recording import job outcome failed", common.Err(err))
+	}
+}
@@ -0,0 +1,257 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	services "github.com/evythrossell/account-management-api/internal/core/service"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockImportJobRepository struct{ mock.Mock }
+
+func (m *MockImportJobRepository) Create(ctx context.Context, job *domain.ImportJob, rows []domain.ImportRow) (*domain.ImportJob, error) {
+	args := m.Called(ctx, job, rows)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportJob), args.Error(1)
+}
+
+func (m *MockImportJobRepository) FindByID(ctx context.Context, jobID int64) (*domain.ImportJob, error) {
+	args := m.Called(ctx, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportJob), args.Error(1)
+}
+
+func (m *MockImportJobRepository) Claim(ctx context.Context, staleBefore time.Time) (*domain.ImportJob, []domain.ImportRow, error) {
+	args := m.Called(ctx, staleBefore)
+	job, _ := args.Get(0).(*domain.ImportJob)
+	rows, _ := args.Get(1).([]domain.ImportRow)
+	return job, rows, args.Error(2)
+}
+
+func (m *MockImportJobRepository) Finish(ctx context.Context, job *domain.ImportJob) error {
+	return m.Called(ctx, job).Error(0)
+}
+
+// assignIDs numbers saved transactions from first, as the database would.
+func assignIDs(first int64) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		for i, tx := range args.Get(1).([]*domain.Transaction) {
+			tx.ID = first + int64(i)
+		}
+	}
+}
+
+func TestImportService(t *testing.T) {
+	ctx := context.Background()
+	newService := func(jobs *MockImportJobRepository, accRepo *MockAccountRepository, txRepo *MockTransactionRepository, allocator port.PaymentAllocator) port.ImportService {
+		return services.NewImportService(jobs, accRepo, txRepo, services.NewAccountAuthorizer(nil), allocator, rates, 2, time.Minute)
+	}
+	rows := []domain.ImportRow{
+		{Line: 2, AccountID: 1, OperationTypeID: 1, Amount: 10},
+		{Line: 3, AccountID: 1, OperationTypeID: 4, Amount: 25},
+		{Line: 4, AccountID: 9, OperationTypeID: 1, Amount: 5},
+		{Line: 5, AccountID: 1, OperationTypeID: 7, Amount: 5},
+		{Line: 6, Problem: domain.ErrMsgImportLineMalformed},
+	}
+	accounts := func() *MockAccountRepository {
+		accRepo := new(MockAccountRepository)
+		accRepo.On("FindByAccountID", mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil).Once()
+		accRepo.On("FindByAccountID", mock.Anything, int64(9)).Return(nil, common.ErrAccountNotFound).Once()
+		return accRepo
+	}
+
+	t.Run("Import - All Or Nothing Saves Every Line", func(t *testing.T) {
+		txRepo := new(MockTransactionRepository)
+		txRepo.On("SaveBatch", ctx, mock.Anything).Run(assignIDs(100)).Return(nil).Once()
+		allocator := new(MockPaymentAllocator)
+		allocator.On("AllocatePayments", ctx, int64(1)).Return(nil).Once()
+		eventDate := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+		report, err := newService(nil, accounts(), txRepo, allocator).Import(ctx, domain.ImportAllOrNothing, []domain.ImportRow{
+			{Line: 1, AccountID: 1, OperationTypeID: 1, Amount: 10, EventDate: eventDate},
+			{Line: 2, AccountID: 1, OperationTypeID: 4, Amount: 25},
+		})
+
+		require.NoError(t, err)
+		saved := txRepo.Calls[0].Arguments.Get(1).([]*domain.Transaction)
+		assert.Equal(t, &domain.ImportReport{Total: 2, Created: 2, Lines: []domain.ImportLineResult{
+			{Line: 1, Status: domain.ImportLineCreated, TransactionID: 100},
+			{Line: 2, Status: domain.ImportLineCreated, TransactionID: 101},
+		}, Transactions: saved}, report)
+		assert.Equal(t, -10.0, saved[0].Amount)
+		assert.Equal(t, eventDate, saved[0].EventDate)
+		allocator.AssertExpectations(t)
+	})
+
+	t.Run("Import - All Or Nothing Skips Valid Lines On Rejection", func(t *testing.T) {
+		txRepo := new(MockTransactionRepository)
+
+		report, err := newService(nil, accounts(), txRepo, nil).Import(ctx, domain.ImportAllOrNothing, rows)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, report.Created)
+		assert.Equal(t, 3, report.Rejected)
+		assert.Equal(t, 2, report.Skipped)
+		assert.Equal(t, domain.ErrMsgImportBatchRejected, report.Lines[0].Reason)
+		assert.Equal(t, domain.ErrMsgAccountIDDoesNotExist, report.Lines[2].Reason)
+		assert.Equal(t, domain.ErrMsgOperationTypeInvalid, report.Lines[3].Reason)
+		assert.Equal(t, domain.ErrMsgImportLineMalformed, report.Lines[4].Reason)
+		txRepo.AssertNotCalled(t, "SaveBatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Import - Converts Lines In Other Currencies", func(t *testing.T) {
+		accRepo := new(MockAccountRepository)
+		accRepo.On("FindByAccountID", mock.Anything, int64(1)).Return(&domain.Account{ID: 1, Currency: "BRL"}, nil).Once()
+		txRepo := new(MockTransactionRepository)
+		txRepo.On("SaveBatch", ctx, mock.Anything).Run(assignIDs(100)).Return(nil).Once()
+
+		report, err := newService(nil, accRepo, txRepo, nil).Import(ctx, domain.ImportBestEffort, []domain.ImportRow{
+			{Line: 1, AccountID: 1, OperationTypeID: 1, Amount: 10, Currency: "EUR"},
+			{Line: 2, AccountID: 1, OperationTypeID: 1, Amount: 10, Currency: "JPY"},
+			{Line: 3, AccountID: 1, OperationTypeID: 1, Amount: 10, Currency: "euro"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Created)
+		assert.Equal(t, domain.ErrMsgCurrencyUnsupported, report.Lines[1].Reason)
+		assert.Equal(t, domain.ErrMsgCurrencyInvalid, report.Lines[2].Reason)
+		saved := txRepo.Calls[0].Arguments.Get(1).([]*domain.Transaction)
+		assert.Equal(t, -60.0, saved[0].Amount)
+		assert.Equal(t, domain.Currency("EUR"), saved[0].Conversion.Currency)
+	})
+
+	t.Run("Import - All Or Nothing Save Fails", func(t *testing.T) {
+		txRepo := new(MockTransactionRepository)
+		txRepo.On("SaveBatch", ctx, mock.Anything).Return(errors.New("db down")).Once()
+
+		_, err := newService(nil, accounts(), txRepo, nil).Import(ctx, domain.ImportAllOrNothing, rows[:2])
+
+		assert.True(t, common.Is(err, common.ErrInternal))
+	})
+
+	t.Run("Import - Best Effort Saves Valid Lines In Chunks", func(t *testing.T) {
+		batch := append([]domain.ImportRow{{Line: 1, AccountID: 1, OperationTypeID: 1, Amount: 1}}, rows...)
+		batch = append(batch, domain.ImportRow{Line: 7, AccountID: 1, OperationTypeID: 4, Amount: 3})
+		txRepo := new(MockTransactionRepository)
+		txRepo.On("SaveBatch", ctx, mock.Anything).Run(assignIDs(100)).Return(nil).Once()
+		txRepo.On("SaveBatch", ctx, mock.Anything).Return(errors.New("deadlock")).Once()
+		allocator := new(MockPaymentAllocator)
+
+		report, err := newService(nil, accounts(), txRepo, allocator).Import(ctx, domain.ImportBestEffort, batch)
+
+		require.NoError(t, err)
+		assert.Equal(t, 7, report.Total)
+		assert.Equal(t, 2, report.Created)
+		assert.Equal(t, 3, report.Rejected)
+		assert.Equal(t, 2, report.Skipped)
+		assert.Equal(t, domain.ImportLineSkipped, report.Lines[2].Status)
+		assert.Equal(t, domain.ErrMsgDatabaseError, report.Lines[2].Reason)
+		assert.Equal(t, int64(101), report.Lines[1].TransactionID)
+		txRepo.AssertNumberOfCalls(t, "SaveBatch", 2)
+		allocator.AssertNotCalled(t, "AllocatePayments", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Submit - Empty File", func(t *testing.T) {
+		_, err := newService(new(MockImportJobRepository), nil, nil, nil).Submit(ctx, domain.ImportBestEffort, nil)
+
+		var de *common.DomainError
+		require.ErrorAs(t, err, &de)
+		assert.Equal(t, common.CodeValidation, de.Code)
+		assert.Equal(t, domain.ErrMsgImportFileEmpty, de.Key)
+	})
+
+	t.Run("Submit - Queues Job For Principal", func(t *testing.T) {
+		principal := &domain.Principal{Subject: "client-1"}
+		pctx := domain.ContextWithPrincipal(ctx, principal)
+		jobs := new(MockImportJobRepository)
+		jobs.On("Create", pctx, &domain.ImportJob{
+			Status:    domain.ImportPending,
+			Mode:      domain.ImportBestEffort,
+			Lines:     len(rows),
+			Principal: principal,
+		}, rows).Return(&domain.ImportJob{ID: 7, Status: domain.ImportPending}, nil).Once()
+
+		job, err := newService(jobs, nil, nil, nil).Submit(pctx, domain.ImportBestEffort, rows)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), job.ID)
+		jobs.AssertExpectations(t)
+	})
+
+	t.Run("GetJob - Hidden From Other Principals", func(t *testing.T) {
+		jobs := new(MockImportJobRepository)
+		jobs.On("FindByID", mock.Anything, int64(7)).Return(&domain.ImportJob{ID: 7, Principal: &domain.Principal{Subject: "client-1"}}, nil)
+		svc := newService(jobs, nil, nil, nil)
+
+		_, err := svc.GetJob(domain.ContextWithPrincipal(ctx, &domain.Principal{Subject: "client-2"}), 7)
+		assert.True(t, common.Is(err, common.ErrNotFound))
+
+		job, err := svc.GetJob(domain.ContextWithPrincipal(ctx, &domain.Principal{Subject: "client-1"}), 7)
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), job.ID)
+
+		_, err = svc.GetJob(domain.ContextWithPrincipal(ctx, &domain.Principal{Subject: "ops", Roles: []string{domain.RoleAdmin}}), 7)
+		assert.NoError(t, err)
+	})
+
+	t.Run("GetJob - Not Found", func(t *testing.T) {
+		jobs := new(MockImportJobRepository)
+		jobs.On("FindByID", ctx, int64(7)).Return(nil, common.ErrImportJobNotFound)
+
+		_, err := newService(jobs, nil, nil, nil).GetJob(ctx, 7)
+
+		assert.True(t, common.Is(err, common.ErrNotFound))
+	})
+
+	t.Run("RunPending - Runs Jobs Until None Left", func(t *testing.T) {
+		principal := &domain.Principal{Subject: "client-1"}
+		jobs := new(MockImportJobRepository)
+		jobs.On("Claim", ctx, mock.Anything).Return(&domain.ImportJob{ID: 1, Mode: domain.ImportAllOrNothing, Principal: principal}, rows[:2], nil).Once()
+		jobs.On("Claim", ctx, mock.Anything).Return(&domain.ImportJob{ID: 2, Mode: domain.ImportAllOrNothing}, rows[:2], nil).Once()
+		jobs.On("Claim", ctx, mock.Anything).Return(nil, nil, nil).Once()
+		jobs.On("Finish", mock.Anything, mock.Anything).Return(nil).Twice()
+		owners := new(MockAccountOwnershipRepository)
+		owners.On("IsOwner", mock.Anything, "client-1", int64(1)).Return(true, nil).Once()
+		accRepo := new(MockAccountRepository)
+		accRepo.On("FindByAccountID", mock.Anything, int64(1)).Return(&domain.Account{ID: 1}, nil)
+		txRepo := new(MockTransactionRepository)
+		txRepo.On("SaveBatch", mock.Anything, mock.Anything).Run(assignIDs(1)).Return(nil).Once()
+		txRepo.On("SaveBatch", mock.Anything, mock.Anything).Return(errors.New("db down")).Once()
+		svc := services.NewImportService(jobs, accRepo, txRepo, services.NewAccountAuthorizer(owners), nil, rates, 2, time.Minute)
+
+		ran, err := svc.RunPending(ctx)
+
+		require.NoError(t, err)
+		assert.Len(t, ran, 2)
+		first := jobs.Calls[1].Arguments.Get(1).(*domain.ImportJob)
+		assert.Same(t, first, ran[0])
+		assert.Equal(t, domain.ImportSucceeded, first.Status)
+		assert.Equal(t, 2, first.Report.Created)
+		second := jobs.Calls[3].Arguments.Get(1).(*domain.ImportJob)
+		assert.Equal(t, domain.ImportFailed, second.Status)
+		assert.Equal(t, domain.ErrMsgDatabaseError, second.Error)
+		owners.AssertExpectations(t)
+	})
+
+	t.Run("RunPending - Claim Fails", func(t *testing.T) {
+		jobs := new(MockImportJobRepository)
+		jobs.On("Claim", ctx, mock.Anything).Return(nil, nil, errors.New("db down"))
+
+		ran, err := newService(jobs, nil, nil, nil).RunPending(ctx)
+
+		assert.Empty(t, ran)
+		assert.True(t, common.Is(err, common.ErrInternal))
+	})
+}
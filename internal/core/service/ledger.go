@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+type ledgerService struct {
+	repo       port.LedgerRepository
+	txRepo     port.TransactionRepository
+	authorizer port.AccountAuthorizer
+}
+
+func NewLedgerService(repo port.LedgerRepository, tr port.TransactionRepository, authorizer port.AccountAuthorizer) port.LedgerService {
+	return &ledgerService{repo: repo, txRepo: tr, authorizer: authorizer}
+}
+
+// GetJournalEntry hides transactions of accounts the caller cannot see the
+// same way GetByTransactionID does.
+func (service *ledgerService) GetJournalEntry(ctx context.Context, transactionID int64) (*domain.JournalEntry, error) {
+	tx, err := service.txRepo.FindByTransactionID(ctx, transactionID)
+	if err != nil {
+		if errors.Is(err, common.ErrTransactionNotFound) {
+			return nil, common.NewNotFoundError(domain.ErrMsgTransactionNotFound, err)
+		}
+		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+
+	if err := service.authorizer.Authorize(ctx, tx.AccountID); err != nil {
+		if errors.Is(err, common.ErrAccountNotFound) {
+			return nil, common.NewNotFoundError(domain.ErrMsgTransactionNotFound, common.ErrTransactionNotFound)
+		}
+		return nil, err
+	}
+
+	entry, err := service.repo.FindEntry(ctx, transactionID)
+	if err != nil {
+		if errors.Is(err, common.ErrJournalEntryNotFound) {
+			return nil, common.NewNotFoundError(domain.ErrMsgJournalEntryNotFound, err)
+		}
+		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+	return entry, nil
+}
+
+func (service *ledgerService) TrialBalance(ctx context.Context) (*domain.TrialBalance, error) {
+	balance, err := service.repo.TrialBalance(ctx)
+	if err != nil {
+		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+	if !balance.Balanced() {
+		common.FromContext(ctx).Error("ledger out of balance",
+			common.Float("debits", balance.Debits),
+			common.Float("credits", balance.Credits),
+			common.Int("unbalanced_entries", balance.UnbalancedEntries),
+			common.Int("projection_mismatches", balance.ProjectionMismatches),
+		)
+	}
+	return balance, nil
+}
@@ -0,0 +1,102 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	services "github.com/evythrossell/account-management-api/internal/core/service"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockLedgerRepository struct{ mock.Mock }
+
+func (m *MockLedgerRepository) FindEntry(ctx context.Context, transactionID int64) (*domain.JournalEntry, error) {
+	args := m.Called(ctx, transactionID)
+	entry, _ := args.Get(0).(*domain.JournalEntry)
+	return entry, args.Error(1)
+}
+
+func (m *MockLedgerRepository) TrialBalance(ctx context.Context) (*domain.TrialBalance, error) {
+	args := m.Called(ctx)
+	balance, _ := args.Get(0).(*domain.TrialBalance)
+	return balance, args.Error(1)
+}
+
+func TestLedgerService(t *testing.T) {
+	ctx := context.Background()
+	rule, _ := domain.Purchase.PostingRule()
+	entry := &domain.JournalEntry{TransactionID: 100, Postings: rule.Post(1, 50)}
+
+	t.Run("GetJournalEntry - Success", func(t *testing.T) {
+		repo := new(MockLedgerRepository)
+		txRepo := new(MockTransactionRepository)
+		txRepo.On("FindByTransactionID", ctx, int64(100)).Return(&domain.Transaction{ID: 100, AccountID: 1}, nil)
+		repo.On("FindEntry", ctx, int64(100)).Return(entry, nil)
+
+		res, err := services.NewLedgerService(repo, txRepo, services.NewAccountAuthorizer(nil)).GetJournalEntry(ctx, 100)
+
+		require.NoError(t, err)
+		assert.Equal(t, entry, res)
+	})
+
+	t.Run("GetJournalEntry - Transaction Not Found", func(t *testing.T) {
+		repo := new(MockLedgerRepository)
+		txRepo := new(MockTransactionRepository)
+		txRepo.On("FindByTransactionID", ctx, int64(999)).Return(nil, common.ErrTransactionNotFound)
+
+		_, err := services.NewLedgerService(repo, txRepo, services.NewAccountAuthorizer(nil)).GetJournalEntry(ctx, 999)
+
+		assert.ErrorIs(t, err, common.ErrTransactionNotFound)
+		repo.AssertNotCalled(t, "FindEntry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("GetJournalEntry - Other Customer Gets Not Found", func(t *testing.T) {
+		repo := new(MockLedgerRepository)
+		txRepo := new(MockTransactionRepository)
+		owners := new(MockAccountOwnershipRepository)
+		ctx := customerContext("client-1")
+		txRepo.On("FindByTransactionID", ctx, int64(100)).Return(&domain.Transaction{ID: 100, AccountID: 2}, nil)
+		owners.On("IsOwner", ctx, "client-1", int64(2)).Return(false, nil)
+
+		_, err := services.NewLedgerService(repo, txRepo, services.NewAccountAuthorizer(owners)).GetJournalEntry(ctx, 100)
+
+		assert.ErrorIs(t, err, common.ErrTransactionNotFound)
+		repo.AssertNotCalled(t, "FindEntry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("GetJournalEntry - Entry Not Found", func(t *testing.T) {
+		repo := new(MockLedgerRepository)
+		txRepo := new(MockTransactionRepository)
+		txRepo.On("FindByTransactionID", ctx, int64(100)).Return(&domain.Transaction{ID: 100, AccountID: 1}, nil)
+		repo.On("FindEntry", ctx, int64(100)).Return(nil, common.ErrJournalEntryNotFound)
+
+		_, err := services.NewLedgerService(repo, txRepo, services.NewAccountAuthorizer(nil)).GetJournalEntry(ctx, 100)
+
+		assert.ErrorIs(t, err, common.ErrJournalEntryNotFound)
+		assert.True(t, common.Is(err, common.ErrNotFound))
+	})
+
+	t.Run("TrialBalance - Success", func(t *testing.T) {
+		repo := new(MockLedgerRepository)
+		repo.On("TrialBalance", ctx).Return(&domain.TrialBalance{Debits: 10, Credits: 10}, nil)
+
+		balance, err := services.NewLedgerService(repo, nil, nil).TrialBalance(ctx)
+
+		require.NoError(t, err)
+		assert.True(t, balance.Balanced())
+	})
+
+	t.Run("TrialBalance - Database Error", func(t *testing.T) {
+		repo := new(MockLedgerRepository)
+		repo.On("TrialBalance", ctx).Return(nil, errors.New("connection failed"))
+
+		_, err := services.NewLedgerService(repo, nil, nil).TrialBalance(ctx)
+
+		assert.ErrorContains(t, err, domain.ErrMsgDatabaseError)
+	})
+}
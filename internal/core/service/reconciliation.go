@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+type reconciliationService struct {
+	repo      port.ReconciliationRepository
+	batchSize int
+	now       func() time.Time
+}
+
+// NewReconciliationService scans accounts batchSize at a time.
+func NewReconciliationService(repo port.ReconciliationRepository, batchSize int) port.ReconciliationService {
+	return &reconciliationService{repo: repo, batchSize: batchSize, now: time.Now}
+}
+
+func (service *reconciliationService) Reconcile(ctx context.Context, repair bool) (*domain.ReconciliationReport, error) {
+	log := common.FromContext(ctx)
+	report := &domain.ReconciliationReport{
+		StartedAt:     service.now(),
+		Repair:        repair,
+		Discrepancies: make([]domain.BalanceDiscrepancy, 0),
+	}
+
+	var afterID int64
+	for {
+		checks, err := service.repo.ScanBalances(ctx, afterID, service.batchSize)
+		if err != nil {
+			return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+		}
+		for _, check := range checks {
+			report.Add(check)
+		}
+		if len(checks) < service.batchSize {
+			break
+		}
+		afterID = checks[len(checks)-1].AccountID
+	}
+
+	for i := range report.Discrepancies {
+		d := &report.Discrepancies[i]
+		log.Warn("balance drift detected",
+			common.Int64("account_id", d.AccountID),
+			common.Float("stored_balance", d.Stored),
+			common.Float("ledger_balance", d.Ledger),
+			common.Float("difference", d.Difference),
+		)
+		if repair {
+			service.repairBalance(ctx, d, report)
+		}
+	}
+
+	report.FinishedAt = service.now()
+	if err := service.repo.SaveReport(ctx, report); err != nil {
+		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+
+	log.Info("balances reconciled",
+		common.Int64("report_id", report.ID),
+		common.Int("accounts", report.Accounts),
+		common.Int("discrepancies", len(report.Discrepancies)),
+		common.Int("repaired", report.Repaired),
+	)
+	return report, nil
+}
+
+// repairBalance corrects one drifted balance. A failure is logged and left
+// for the next run, like the other discrepancies that were not repaired.
+func (service *reconciliationService) repairBalance(ctx context.Context, d *domain.BalanceDiscrepancy, report *domain.ReconciliationReport) {
+	log := common.FromContext(ctx)
+	check, repaired, err := service.repo.RepairBalance(ctx, d.AccountID)
+	if err != nil {
+		log.Error("balance repair failed", common.Int64("account_id", d.AccountID), common.Err(err))
+		return
+	}
+	if !repaired {
+		return
+	}
+
+	d.Repaired = true
+	report.Repaired++
+	log.Info("balance repaired",
+		common.Int64("account_id", d.AccountID),
+		common.Float("previous_balance", check.Stored),
+		common.Float("balance", check.Ledger),
+	)
+}
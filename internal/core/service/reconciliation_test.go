@@ -0,0 +1,94 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	services "github.com/evythrossell/account-management-api/internal/core/service"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockReconciliationRepository struct{ mock.Mock }
+
+func (m *MockReconciliationRepository) ScanBalances(ctx context.Context, afterID int64, limit int) ([]domain.BalanceCheck, error) {
+	args := m.Called(ctx, afterID, limit)
+	checks, _ := args.Get(0).([]domain.BalanceCheck)
+	return checks, args.Error(1)
+}
+
+func (m *MockReconciliationRepository) RepairBalance(ctx context.Context, accountID int64) (domain.BalanceCheck, bool, error) {
+	args := m.Called(ctx, accountID)
+	return args.Get(0).(domain.BalanceCheck), args.Bool(1), args.Error(2)
+}
+
+func (m *MockReconciliationRepository) SaveReport(ctx context.Context, report *domain.ReconciliationReport) error {
+	return m.Called(ctx, report).Error(0)
+}
+
+func TestReconciliationService(t *testing.T) {
+	ctx := context.Background()
+	drifted := domain.BalanceCheck{AccountID: 3, Stored: 20, Ledger: -30}
+
+	t.Run("Reconcile - Scans In Batches", func(t *testing.T) {
+		repo := new(MockReconciliationRepository)
+		repo.On("ScanBalances", ctx, int64(0), 2).
+			Return([]domain.BalanceCheck{{AccountID: 1, Stored: 10, Ledger: 10}, {AccountID: 2}}, nil)
+		repo.On("ScanBalances", ctx, int64(2), 2).Return([]domain.BalanceCheck{drifted}, nil)
+		repo.On("SaveReport", ctx, mock.Anything).Return(nil)
+
+		report, err := services.NewReconciliationService(repo, 2).Reconcile(ctx, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.Accounts)
+		require.Len(t, report.Discrepancies, 1)
+		assert.Equal(t, 50.0, report.Discrepancies[0].Difference)
+		assert.Equal(t, 1, report.Unresolved())
+		repo.AssertNotCalled(t, "RepairBalance", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Reconcile - Ignores Sub-Cent Float Noise", func(t *testing.T) {
+		repo := new(MockReconciliationRepository)
+		repo.On("ScanBalances", ctx, int64(0), 10).
+			Return([]domain.BalanceCheck{{AccountID: 1, Stored: 0.3, Ledger: 0.1 + 0.2}}, nil)
+		repo.On("SaveReport", ctx, mock.Anything).Return(nil)
+
+		report, err := services.NewReconciliationService(repo, 10).Reconcile(ctx, false)
+
+		require.NoError(t, err)
+		assert.Empty(t, report.Discrepancies)
+	})
+
+	t.Run("Reconcile - Repairs Drift", func(t *testing.T) {
+		repo := new(MockReconciliationRepository)
+		repo.On("ScanBalances", ctx, int64(0), 10).Return([]domain.BalanceCheck{drifted, {AccountID: 4, Stored: 1}}, nil)
+		repo.On("RepairBalance", ctx, int64(3)).Return(drifted, true, nil)
+		repo.On("RepairBalance", ctx, int64(4)).Return(domain.BalanceCheck{}, false, errors.New("lock timeout"))
+		repo.On("SaveReport", ctx, mock.Anything).Return(nil)
+
+		report, err := services.NewReconciliationService(repo, 10).Reconcile(ctx, true)
+
+		require.NoError(t, err)
+		assert.True(t, report.Repair)
+		assert.Equal(t, 1, report.Repaired)
+		assert.True(t, report.Discrepancies[0].Repaired)
+		assert.False(t, report.Discrepancies[1].Repaired)
+		assert.Equal(t, 1, report.Unresolved())
+	})
+
+	t.Run("Reconcile - Scan Error", func(t *testing.T) {
+		repo := new(MockReconciliationRepository)
+		repo.On("ScanBalances", ctx, int64(0), 10).Return(nil, errors.New("connection reset"))
+
+		_, err := services.NewReconciliationService(repo, 10).Reconcile(ctx, false)
+
+		var de *common.DomainError
+		require.ErrorAs(t, err, &de)
+		assert.Equal(t, domain.ErrMsgDatabaseError, de.Key)
+		repo.AssertNotCalled(t, "SaveReport", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	common "github.com/evythrossell/account-management-api/pkg"
+)
+
+type statementService struct {
+	repo       port.StatementRepository
+	accRepo    port.AccountRepository
+	authorizer port.AccountAuthorizer
+	defaultDay int
+	policy     domain.StatementPolicy
+	now        func() time.Time
+}
+
+// NewStatementService closes statements on each account's billing day, or on
+// defaultDay for accounts without a billing cycle of their own.
+func NewStatementService(
+	repo port.StatementRepository,
+	ar port.AccountRepository,
+	authorizer port.AccountAuthorizer,
+	defaultDay int,
+	policy domain.StatementPolicy,
+) port.StatementService {
+	return &statementService{
+		repo:       repo,
+		accRepo:    ar,
+		authorizer: authorizer,
+		defaultDay: defaultDay,
+		policy:     policy,
+		now:        time.Now,
+	}
+}
+
+func (service *statementService) GetBillingCycle(ctx context.Context, accountID int64) (*domain.BillingCycle, error) {
+	if err := service.checkAccount(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	day, err := service.repo.BillingDay(ctx, accountID)
+	if err != nil {
+		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+	if day == 0 {
+		day = service.defaultDay
+	}
+	return &domain.BillingCycle{AccountID: accountID, BillingDay: day}, nil
+}
+
+func (service *statementService) SetBillingCycle(ctx context.Context, accountID int64, billingDay int) (*domain.BillingCycle, error) {
+	cycle, err := domain.NewBillingCycle(accountID, billingDay)
+	if err != nil {
+		return nil, common.NewValidationError(domain.ErrMsgBillingDayInvalid, err).
+			WithParam("min", domain.MinBillingDay).
+			WithParam("max", domain.MaxBillingDay).
+			WithField("billing_day", domain.ErrMsgBillingDayInvalid)
+	}
+
+	if err := service.checkAccount(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	if err := service.repo.SaveBillingCycle(ctx, cycle); err != nil {
+		if errors.Is(err, common.ErrAccountNotFound) {
+			return nil, common.NewNotFoundError(domain.ErrMsgAccountNotFound, err)
+		}
+		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+
+	common.FromContext(ctx).Info("billing cycle updated",
+		common.Int64("account_id", accountID),
+		common.Int("billing_day", billingDay),
+	)
+
+	return cycle, nil
+}
+
+func (service *statementService) ListStatements(ctx context.Context, accountID int64) ([]*domain.Statement, error) {
+	if err := service.checkAccount(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	statements, err := service.repo.ListByAccount(ctx, accountID)
+	if err != nil {
+		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+
+	now := service.now()
+	for _, s := range statements {
+		s.Status = s.StatusAt(now)
+	}
+	return statements, nil
+}
+
+func (service *statementService) GetStatement(ctx context.Context, accountID, statementID int64) (*domain.Statement, error) {
+	if err := service.checkAccount(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	s, err := service.repo.FindByID(ctx, statementID)
+	if err != nil {
+		if errors.Is(err, common.ErrStatementNotFound) {
+			return nil, common.NewNotFoundError(domain.ErrMsgStatementNotFound, err)
+		}
+		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+	if s.AccountID != accountID {
+		return nil, common.NewNotFoundError(domain.ErrMsgStatementNotFound, common.ErrStatementNotFound)
+	}
+
+	s.Status = s.StatusAt(service.now())
+	return s, nil
+}
+
+// CloseDue catches up on every period that ended by now, so a job that was
+// down for a while closes the months it missed in order. Accounts that never
+// had a transaction get no statement. A period already closed by another
+// instance is skipped.
+func (service *statementService) CloseDue(ctx context.Context, now time.Time) (int, error) {
+	states, err := service.repo.CycleStates(ctx)
+	if err != nil {
+		return 0, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+
+	closed := 0
+	var errs []error
+	for _, state := range states {
+		n, err := service.closeAccount(ctx, state, now)
+		closed += n
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return closed, common.NewInternalError(domain.ErrMsgDatabaseError, errors.Join(errs...))
+	}
+	return closed, nil
+}
+
+func (service *statementService) closeAccount(ctx context.Context, state domain.CycleState, now time.Time) (int, error) {
+	cycle := domain.BillingCycle{AccountID: state.AccountID, BillingDay: state.BillingDay}
+	if cycle.BillingDay == 0 {
+		cycle.BillingDay = service.defaultDay
+	}
+
+	start := state.LastClose
+	var end time.Time
+	switch {
+	case !start.IsZero():
+		end = cycle.NextClose(start)
+	case !state.FirstUnbilled.IsZero():
+		end = cycle.NextClose(state.FirstUnbilled)
+		start = end.AddDate(0, -1, 0)
+	default:
+		return 0, nil
+	}
+
+	closed := 0
+	for !end.After(now) {
+		s, err := service.repo.Close(ctx, state.AccountID, start, end, service.policy)
+		if err != nil && !errors.Is(err, common.ErrStatementAlreadyClosed) {
+			return closed, err
+		}
+		if err == nil {
+			closed++
+			common.FromContext(ctx).Info("statement closed",
+				common.Int64("statement_id", s.ID),
+				common.Int64("account_id", s.AccountID),
+				common.Time("period_end", s.PeriodEnd),
+			)
+		}
+		start, end = end, cycle.NextClose(end)
+	}
+	return closed, nil
+}
+
+func (service *statementService) AllocatePayments(ctx context.Context, accountID int64) error {
+	allocations, err := service.repo.AllocatePayments(ctx, accountID)
+	if err != nil {
+		return common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+
+	for _, a := range allocations {
+		common.FromContext(ctx).Info("payment allocated",
+			common.Int64("statement_id", a.StatementID),
+			common.Int64("transaction_id", a.TransactionID),
+			common.Float("amount", a.Amount),
+		)
+	}
+	return nil
+}
+
+// checkAccount reports accounts the caller may not see and accounts that do
+// not exist the same way.
+func (service *statementService) checkAccount(ctx context.Context, accountID int64) error {
+	if err := service.authorizer.Authorize(ctx, accountID); err != nil {
+		return err
+	}
+
+	if _, err := service.accRepo.FindByAccountID(ctx, accountID); err != nil {
+		if errors.Is(err, common.ErrAccountNotFound) {
+			return common.NewNotFoundError(domain.ErrMsgAccountNotFound, err)
+		}
+		return common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+	return nil
+}
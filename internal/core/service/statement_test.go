@@ -0,0 +1,202 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/core/domain"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	services "github.com/evythrossell/account-management-api/internal/core/service"
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStatementRepository struct{ mock.Mock }
+
+func (m *MockStatementRepository) BillingDay(ctx context.Context, accountID int64) (int, error) {
+	args := m.Called(ctx, accountID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStatementRepository) SaveBillingCycle(ctx context.Context, cycle *domain.BillingCycle) error {
+	return m.Called(ctx, cycle).Error(0)
+}
+
+func (m *MockStatementRepository) CycleStates(ctx context.Context) ([]domain.CycleState, error) {
+	args := m.Called(ctx)
+	states, _ := args.Get(0).([]domain.CycleState)
+	return states, args.Error(1)
+}
+
+func (m *MockStatementRepository) Close(ctx context.Context, accountID int64, start, end time.Time, policy domain.StatementPolicy) (*domain.Statement, error) {
+	args := m.Called(ctx, accountID, start, end, policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Statement), args.Error(1)
+}
+
+func (m *MockStatementRepository) AllocatePayments(ctx context.Context, accountID int64) ([]domain.PaymentAllocation, error) {
+	args := m.Called(ctx, accountID)
+	allocations, _ := args.Get(0).([]domain.PaymentAllocation)
+	return allocations, args.Error(1)
+}
+
+func (m *MockStatementRepository) ListByAccount(ctx context.Context, accountID int64) ([]*domain.Statement, error) {
+	args := m.Called(ctx, accountID)
+	statements, _ := args.Get(0).([]*domain.Statement)
+	return statements, args.Error(1)
+}
+
+func (m *MockStatementRepository) FindByID(ctx context.Context, statementID int64) (*domain.Statement, error) {
+	args := m.Called(ctx, statementID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Statement), args.Error(1)
+}
+
+type MockPaymentAllocator struct{ mock.Mock }
+
+func (m *MockPaymentAllocator) AllocatePayments(ctx context.Context, accountID int64) error {
+	return m.Called(ctx, accountID).Error(0)
+}
+
+func utc(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestStatementService(t *testing.T) {
+	ctx := context.Background()
+	policy := domain.StatementPolicy{DueDays: 10, MinimumPaymentRate: 0.15, MinimumPaymentFloor: 20}
+	newService := func(repo *MockStatementRepository, accRepo *MockAccountRepository) port.StatementService {
+		return services.NewStatementService(repo, accRepo, services.NewAccountAuthorizer(nil), 5, policy)
+	}
+
+	t.Run("GetBillingCycle - Default Day", func(t *testing.T) {
+		repo := new(MockStatementRepository)
+		accRepo := new(MockAccountRepository)
+		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1}, nil)
+		repo.On("BillingDay", ctx, int64(1)).Return(0, nil)
+
+		cycle, err := newService(repo, accRepo).GetBillingCycle(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.BillingCycle{AccountID: 1, BillingDay: 5}, cycle)
+	})
+
+	t.Run("GetBillingCycle - Account Not Found", func(t *testing.T) {
+		accRepo := new(MockAccountRepository)
+		accRepo.On("FindByAccountID", ctx, int64(9)).Return(nil, common.ErrAccountNotFound)
+
+		_, err := newService(new(MockStatementRepository), accRepo).GetBillingCycle(ctx, 9)
+
+		assert.True(t, common.Is(err, common.ErrNotFound))
+	})
+
+	t.Run("SetBillingCycle - Success", func(t *testing.T) {
+		repo := new(MockStatementRepository)
+		accRepo := new(MockAccountRepository)
+		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1}, nil)
+		repo.On("SaveBillingCycle", ctx, &domain.BillingCycle{AccountID: 1, BillingDay: 20}).Return(nil)
+
+		cycle, err := newService(repo, accRepo).SetBillingCycle(ctx, 1, 20)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 20, cycle.BillingDay)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("SetBillingCycle - Invalid Day", func(t *testing.T) {
+		_, err := newService(new(MockStatementRepository), new(MockAccountRepository)).SetBillingCycle(ctx, 1, 31)
+
+		var de *common.DomainError
+		assert.ErrorAs(t, err, &de)
+		assert.Equal(t, common.CodeValidation, de.Code)
+		assert.Equal(t, domain.ErrMsgBillingDayInvalid, de.Key)
+		assert.Equal(t, map[string]any{"min": 1, "max": 28}, de.Params)
+	})
+
+	t.Run("ListStatements - Status Computed", func(t *testing.T) {
+		repo := new(MockStatementRepository)
+		accRepo := new(MockAccountRepository)
+		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1}, nil)
+		repo.On("ListByAccount", ctx, int64(1)).Return([]*domain.Statement{
+			{ID: 2, ClosingBalance: -50, DueDate: time.Now().Add(24 * time.Hour)},
+			{ID: 1, ClosingBalance: -50, DueDate: time.Now().Add(-24 * time.Hour)},
+			{ID: 0, ClosingBalance: 10},
+		}, nil)
+
+		statements, err := newService(repo, accRepo).ListStatements(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatementOpen, statements[0].Status)
+		assert.Equal(t, domain.StatementOverdue, statements[1].Status)
+		assert.Equal(t, domain.StatementPaid, statements[2].Status)
+	})
+
+	t.Run("GetStatement - Other Account", func(t *testing.T) {
+		repo := new(MockStatementRepository)
+		accRepo := new(MockAccountRepository)
+		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1}, nil)
+		repo.On("FindByID", ctx, int64(3)).Return(&domain.Statement{ID: 3, AccountID: 2}, nil)
+
+		_, err := newService(repo, accRepo).GetStatement(ctx, 1, 3)
+
+		assert.True(t, common.Is(err, common.ErrNotFound))
+		assert.ErrorIs(t, err, common.ErrStatementNotFound)
+	})
+
+	t.Run("GetStatement - Database Error", func(t *testing.T) {
+		repo := new(MockStatementRepository)
+		accRepo := new(MockAccountRepository)
+		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1}, nil)
+		repo.On("FindByID", ctx, int64(3)).Return(nil, errors.New("db down"))
+
+		_, err := newService(repo, accRepo).GetStatement(ctx, 1, 3)
+
+		assert.True(t, common.Is(err, common.ErrInternal))
+	})
+
+	t.Run("CloseDue - Catches Up Missed Periods", func(t *testing.T) {
+		repo := new(MockStatementRepository)
+		repo.On("CycleStates", ctx).Return([]domain.CycleState{
+			{AccountID: 1, BillingDay: 10, LastClose: utc(2026, 1, 10)},
+			{AccountID: 2, FirstUnbilled: utc(2026, 3, 2)},
+			{AccountID: 3},
+		}, nil)
+		repo.On("Close", ctx, int64(1), utc(2026, 1, 10), utc(2026, 2, 10), policy).Return(&domain.Statement{ID: 1, AccountID: 1}, nil)
+		repo.On("Close", ctx, int64(1), utc(2026, 2, 10), utc(2026, 3, 10), policy).Return(nil, common.ErrStatementAlreadyClosed)
+		repo.On("Close", ctx, int64(2), utc(2026, 2, 5), utc(2026, 3, 5), policy).Return(&domain.Statement{ID: 2, AccountID: 2}, nil)
+
+		closed, err := newService(repo, nil).CloseDue(ctx, utc(2026, 3, 15))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, closed)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("CloseDue - Reports Failures", func(t *testing.T) {
+		repo := new(MockStatementRepository)
+		repo.On("CycleStates", ctx).Return([]domain.CycleState{{AccountID: 1, BillingDay: 10, LastClose: utc(2026, 1, 10)}}, nil)
+		repo.On("Close", ctx, int64(1), mock.Anything, mock.Anything, policy).Return(nil, errors.New("db down"))
+
+		closed, err := newService(repo, nil).CloseDue(ctx, utc(2026, 3, 15))
+
+		assert.Zero(t, closed)
+		assert.True(t, common.Is(err, common.ErrInternal))
+		repo.AssertNumberOfCalls(t, "Close", 1)
+	})
+
+	t.Run("AllocatePayments - Database Error", func(t *testing.T) {
+		repo := new(MockStatementRepository)
+		repo.On("AllocatePayments", ctx, int64(1)).Return(nil, errors.New("db down"))
+
+		err := newService(repo, nil).AllocatePayments(ctx, 1)
+
+		assert.True(t, common.Is(err, common.ErrInternal))
+	})
+}
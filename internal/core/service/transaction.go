@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/evythrossell/account-management-api/internal/core/domain"
 	"github.com/evythrossell/account-management-api/internal/core/port"
@@ -10,20 +11,33 @@ import (
 )
 
 type transactionService struct {
-	accRepo port.AccountRepository
-	txRepo  port.TransactionRepository
-	opRepo  port.OperationRepository
+	accRepo    port.AccountRepository
+	txRepo     port.TransactionRepository
+	opRepo     port.OperationRepository
+	authorizer port.AccountAuthorizer
+	allocator  port.PaymentAllocator
+	rates      port.FXRateProvider
 }
 
+// NewTransactionService settles closed statements through allocator after
+// each payment; allocator may be nil when statements are not in use.
+// Transactions in another currency than the account's are converted with
+// rates.
 func NewTransactionService(
 	ar port.AccountRepository,
 	tr port.TransactionRepository,
 	or port.OperationRepository,
+	authorizer port.AccountAuthorizer,
+	allocator port.PaymentAllocator,
+	rates port.FXRateProvider,
 ) port.TransactionService {
 	return &transactionService{
-		accRepo: ar,
-		txRepo:  tr,
-		opRepo:  or,
+		accRepo:    ar,
+		txRepo:     tr,
+		opRepo:     or,
+		authorizer: authorizer,
+		allocator:  allocator,
+		rates:      rates,
 	}
 }
 
@@ -32,11 +46,21 @@ func (service *transactionService) CreateTransaction(
 	accountID int64,
 	operationTypeID int16,
 	amount float64,
+	currency domain.Currency,
 ) (*domain.Transaction, error) {
-	_, err := service.accRepo.FindByAccountID(ctx, accountID)
+	if err := service.authorizer.Authorize(ctx, accountID); err != nil {
+		if errors.Is(err, common.ErrAccountNotFound) {
+			return nil, common.NewValidationError(domain.ErrMsgAccountIDDoesNotExist, err).
+				WithField("account_id", domain.ErrMsgAccountIDDoesNotExist)
+		}
+		return nil, err
+	}
+
+	account, err := service.accRepo.FindByAccountID(ctx, accountID)
 	if err != nil {
 		if errors.Is(err, common.ErrAccountNotFound) {
-			return nil, common.NewValidationError(domain.ErrMsgAccountIDDoesNotExist, err)
+			return nil, common.NewValidationError(domain.ErrMsgAccountIDDoesNotExist, err).
+				WithField("account_id", domain.ErrMsgAccountIDDoesNotExist)
 		}
 		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
 	}
@@ -47,21 +71,49 @@ func (service *transactionService) CreateTransaction(
 		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
 	}
 	if !exists {
-		return nil, common.NewValidationError(domain.ErrMsgOperationTypeInvalid, common.ErrInvalidOperation)
+		return nil, common.NewValidationError(domain.ErrMsgOperationTypeInvalid, common.ErrInvalidOperation).
+			WithField("operation_type_id", domain.ErrMsgOperationTypeInvalid)
 	}
 
 	tx, err := domain.NewTransaction(accountID, opType, amount)
 	if err != nil {
 		if errors.Is(err, common.ErrInvalidAmount) {
-			return nil, common.NewValidationError(domain.ErrMsgAmountInvalid, err)
+			return nil, common.NewValidationError(domain.ErrMsgAmountInvalid, err).
+				WithField("amount", domain.ErrMsgAmountInvalid)
 		}
 		if errors.Is(err, common.ErrInvalidOperation) {
-			return nil, common.NewValidationError(domain.ErrMsgOperationTypeInvalid, err)
+			return nil, common.NewValidationError(domain.ErrMsgOperationTypeInvalid, err).
+				WithField("operation_type_id", domain.ErrMsgOperationTypeInvalid)
 		}
 		return nil, common.NewInternalError(domain.ErrMsgCreateTransactionFailed, err)
 	}
+	if err := bookInCurrency(ctx, service.rates, tx, currency, account); err != nil {
+		return nil, err
+	}
+
+	saved, err := service.txRepo.Save(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	common.FromContext(ctx).Info("transaction created",
+		common.Int64("transaction_id", saved.ID),
+		common.Int64("account_id", accountID),
+		common.Int("operation_type_id", int(opType)),
+	)
+
+	// The payment is already recorded; a failed allocation is retried with
+	// the account's next payment.
+	if opType.IsCredit() && service.allocator != nil {
+		if err := service.allocator.AllocatePayments(ctx, accountID); err != nil {
+			common.FromContext(ctx).Warn("payment allocation failed",
+				common.Int64("transaction_id", saved.ID),
+				common.Err(err),
+			)
+		}
+	}
 
-	return service.txRepo.Save(ctx, tx)
+	return saved, nil
 }
 
 func (service *transactionService) GetByTransactionID(ctx context.Context, transactionID int64) (*domain.Transaction, error) {
@@ -73,5 +125,81 @@ func (service *transactionService) GetByTransactionID(ctx context.Context, trans
 		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
 	}
 
+	if err := service.authorizer.Authorize(ctx, tx.AccountID); err != nil {
+		if errors.Is(err, common.ErrAccountNotFound) {
+			return nil, common.NewNotFoundError(domain.ErrMsgTransactionNotFound, common.ErrTransactionNotFound)
+		}
+		return nil, err
+	}
+
 	return tx, nil
 }
+
+// ExportTransactions streams the account's transactions in [from, to) to fn
+// without loading them all into memory. start, when set, gets the account
+// before the first transaction.
+func (service *transactionService) ExportTransactions(
+	ctx context.Context,
+	accountID int64,
+	from, to time.Time,
+	start func(*domain.Account) error,
+	fn func(*domain.Transaction) error,
+) error {
+	account, err := service.findAccount(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if start != nil {
+		if err := start(account); err != nil {
+			return err
+		}
+	}
+
+	exported := 0
+	err = service.txRepo.StreamByAccount(ctx, accountID, from, to, func(tx *domain.Transaction) error {
+		exported++
+		return fn(tx)
+	})
+	if err != nil {
+		return common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+
+	common.FromContext(ctx).Info("transactions exported",
+		common.Int64("account_id", accountID),
+		common.Int("count", exported),
+	)
+	return nil
+}
+
+func (service *transactionService) GetBalances(ctx context.Context, accountID int64) (*domain.AccountBalances, error) {
+	account, err := service.findAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	currencies, err := service.txRepo.SumByCurrency(ctx, accountID)
+	if err != nil {
+		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+	return domain.NewAccountBalances(account, currencies), nil
+}
+
+// findAccount loads an account the caller may see, reporting any other as
+// not found.
+func (service *transactionService) findAccount(ctx context.Context, accountID int64) (*domain.Account, error) {
+	if err := service.authorizer.Authorize(ctx, accountID); err != nil {
+		if errors.Is(err, common.ErrAccountNotFound) {
+			return nil, common.NewNotFoundError(domain.ErrMsgAccountNotFound, err)
+		}
+		return nil, err
+	}
+
+	account, err := service.accRepo.FindByAccountID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, common.ErrAccountNotFound) {
+			return nil, common.NewNotFoundError(domain.ErrMsgAccountNotFound, err)
+		}
+		return nil, common.NewInternalError(domain.ErrMsgDatabaseError, err)
+	}
+	return account, nil
+}
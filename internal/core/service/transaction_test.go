@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/evythrossell/account-management-api/internal/core/domain"
 	services "github.com/evythrossell/account-management-api/internal/core/service"
 	common "github.com/evythrossell/account-management-api/pkg"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type MockTransactionRepository struct{ mock.Mock }
@@ -30,6 +32,52 @@ func (m *MockTransactionRepository) FindByTransactionID(ctx context.Context, id
 	return args.Get(0).(*domain.Transaction), args.Error(1)
 }
 
+func (m *MockTransactionRepository) SaveBatch(ctx context.Context, txs []*domain.Transaction) error {
+	return m.Called(ctx, txs).Error(0)
+}
+
+// StreamByAccount feeds fn the transactions given to Return.
+func (m *MockTransactionRepository) StreamByAccount(
+	ctx context.Context,
+	accountID int64,
+	from, to time.Time,
+	fn func(*domain.Transaction) error,
+) error {
+	args := m.Called(ctx, accountID, from, to)
+	rows, _ := args.Get(0).([]*domain.Transaction)
+	for _, tx := range rows {
+		if err := fn(tx); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumByCurrency(ctx context.Context, accountID int64) ([]domain.CurrencyBalance, error) {
+	args := m.Called(ctx, accountID)
+	balances, _ := args.Get(0).([]domain.CurrencyBalance)
+	return balances, args.Error(1)
+}
+
+// stubRates quotes each currency in units of BRL.
+type stubRates map[domain.Currency]float64
+
+var rates = stubRates{"BRL": 1, "USD": 5, "EUR": 6}
+
+var ratesAsOf = time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+func (r stubRates) Supports(currency domain.Currency) bool {
+	_, ok := r[currency]
+	return ok
+}
+
+func (r stubRates) Rate(_ context.Context, from, to domain.Currency) (domain.FXRate, error) {
+	if !r.Supports(from) || !r.Supports(to) {
+		return domain.FXRate{}, common.ErrUnsupportedCurrency
+	}
+	return domain.FXRate{From: from, To: to, Rate: r[from] / r[to], AsOf: ratesAsOf}, nil
+}
+
 type MockOperationRepository struct{ mock.Mock }
 
 func (m *MockOperationRepository) Exists(ctx context.Context, id int16) (bool, error) {
@@ -44,64 +92,170 @@ func TestTransactionService(t *testing.T) {
 		accRepo := new(MockAccountRepository)
 		txRepo := new(MockTransactionRepository)
 		opRepo := new(MockOperationRepository)
-		svc := services.NewTransactionService(accRepo, txRepo, opRepo)
+		svc := services.NewTransactionService(accRepo, txRepo, opRepo, services.NewAccountAuthorizer(nil), nil, rates)
 
 		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1}, nil)
 		opRepo.On("Exists", ctx, int16(4)).Return(true, nil)
 		txRepo.On("Save", ctx, mock.Anything).Return(&domain.Transaction{ID: 100}, nil)
 
-		res, err := svc.CreateTransaction(ctx, 1, 4, 50.0)
+		res, err := svc.CreateTransaction(ctx, 1, 4, 50.0, "")
 
 		assert.NoError(t, err)
 		assert.Equal(t, int64(100), res.ID)
 	})
 
+	t.Run("CreateTransaction - Converts Into Account Currency", func(t *testing.T) {
+		accRepo := new(MockAccountRepository)
+		txRepo := new(MockTransactionRepository)
+		opRepo := new(MockOperationRepository)
+		svc := services.NewTransactionService(accRepo, txRepo, opRepo, services.NewAccountAuthorizer(nil), nil, rates)
+
+		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1, Currency: "BRL"}, nil)
+		opRepo.On("Exists", ctx, int16(1)).Return(true, nil)
+		var saved *domain.Transaction
+		txRepo.On("Save", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(*domain.Transaction)
+		}).Return(&domain.Transaction{ID: 100}, nil)
+
+		_, err := svc.CreateTransaction(ctx, 1, 1, 20, "usd")
+
+		require.NoError(t, err)
+		assert.Equal(t, -100.0, saved.Amount)
+		assert.Equal(t, domain.Currency("BRL"), saved.Currency)
+		assert.Equal(t, &domain.Conversion{Currency: "USD", Amount: -20, Rate: 5, RateAt: ratesAsOf}, saved.Conversion)
+	})
+
+	t.Run("CreateTransaction - Account Currency Is Not Converted", func(t *testing.T) {
+		accRepo := new(MockAccountRepository)
+		txRepo := new(MockTransactionRepository)
+		opRepo := new(MockOperationRepository)
+		svc := services.NewTransactionService(accRepo, txRepo, opRepo, services.NewAccountAuthorizer(nil), nil, rates)
+
+		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1, Currency: "USD"}, nil)
+		opRepo.On("Exists", ctx, int16(4)).Return(true, nil)
+		txRepo.On("Save", ctx, mock.MatchedBy(func(tx *domain.Transaction) bool {
+			return tx.Currency == "USD" && tx.Amount == 50 && tx.Conversion == nil
+		})).Return(&domain.Transaction{ID: 100}, nil)
+
+		_, err := svc.CreateTransaction(ctx, 1, 4, 50.0, "USD")
+
+		assert.NoError(t, err)
+		txRepo.AssertExpectations(t)
+	})
+
+	t.Run("CreateTransaction - Unsupported Currency", func(t *testing.T) {
+		accRepo := new(MockAccountRepository)
+		txRepo := new(MockTransactionRepository)
+		opRepo := new(MockOperationRepository)
+		svc := services.NewTransactionService(accRepo, txRepo, opRepo, services.NewAccountAuthorizer(nil), nil, rates)
+
+		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1, Currency: "BRL"}, nil)
+		opRepo.On("Exists", ctx, int16(1)).Return(true, nil)
+
+		_, err := svc.CreateTransaction(ctx, 1, 1, 20, "JPY")
+
+		var de *common.DomainError
+		require.ErrorAs(t, err, &de)
+		assert.Equal(t, domain.ErrMsgCurrencyUnsupported, de.Key)
+		assert.Equal(t, map[string]any{"currency": "JPY"}, de.Params)
+		txRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("CreateTransaction - Invalid Currency", func(t *testing.T) {
+		accRepo := new(MockAccountRepository)
+		opRepo := new(MockOperationRepository)
+		svc := services.NewTransactionService(accRepo, nil, opRepo, services.NewAccountAuthorizer(nil), nil, rates)
+
+		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1, Currency: "BRL"}, nil)
+		opRepo.On("Exists", ctx, int16(1)).Return(true, nil)
+
+		_, err := svc.CreateTransaction(ctx, 1, 1, 20, "dollars")
+
+		assert.ErrorIs(t, err, common.ErrInvalidCurrency)
+	})
+
+	t.Run("CreateTransaction - Payment Settles Statements", func(t *testing.T) {
+		accRepo := new(MockAccountRepository)
+		txRepo := new(MockTransactionRepository)
+		opRepo := new(MockOperationRepository)
+		allocator := new(MockPaymentAllocator)
+		svc := services.NewTransactionService(accRepo, txRepo, opRepo, services.NewAccountAuthorizer(nil), allocator, rates)
+
+		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1}, nil)
+		opRepo.On("Exists", ctx, int16(4)).Return(true, nil)
+		txRepo.On("Save", ctx, mock.Anything).Return(&domain.Transaction{ID: 100}, nil)
+		allocator.On("AllocatePayments", ctx, int64(1)).Return(errors.New("db down"))
+
+		res, err := svc.CreateTransaction(ctx, 1, 4, 50.0, "")
+
+		assert.NoError(t, err, "a failed allocation does not fail the payment")
+		assert.Equal(t, int64(100), res.ID)
+		allocator.AssertExpectations(t)
+	})
+
+	t.Run("CreateTransaction - Purchase Skips Allocation", func(t *testing.T) {
+		accRepo := new(MockAccountRepository)
+		txRepo := new(MockTransactionRepository)
+		opRepo := new(MockOperationRepository)
+		allocator := new(MockPaymentAllocator)
+		svc := services.NewTransactionService(accRepo, txRepo, opRepo, services.NewAccountAuthorizer(nil), allocator, rates)
+
+		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1}, nil)
+		opRepo.On("Exists", ctx, int16(1)).Return(true, nil)
+		txRepo.On("Save", ctx, mock.Anything).Return(&domain.Transaction{ID: 100}, nil)
+
+		_, err := svc.CreateTransaction(ctx, 1, 1, 50.0, "")
+
+		assert.NoError(t, err)
+		allocator.AssertNotCalled(t, "AllocatePayments", mock.Anything, mock.Anything)
+	})
+
 	t.Run("CreateTransaction - Account Not Found", func(t *testing.T) {
 		accRepo := new(MockAccountRepository)
-		svc := services.NewTransactionService(accRepo, nil, nil)
+		svc := services.NewTransactionService(accRepo, nil, nil, services.NewAccountAuthorizer(nil), nil, rates)
 
 		accRepo.On("FindByAccountID", ctx, int64(1)).Return(nil, common.ErrAccountNotFound)
 
-		_, err := svc.CreateTransaction(ctx, 1, 4, 50.0)
+		_, err := svc.CreateTransaction(ctx, 1, 4, 50.0, "")
 
 		assert.ErrorIs(t, err, common.ErrAccountNotFound)
 	})
 
 	t.Run("CreateTransaction - Account Error (other than not found)", func(t *testing.T) {
 		accRepo := new(MockAccountRepository)
-		svc := services.NewTransactionService(accRepo, nil, nil)
+		svc := services.NewTransactionService(accRepo, nil, nil, services.NewAccountAuthorizer(nil), nil, rates)
 
 		accRepo.On("FindByAccountID", ctx, int64(1)).Return(nil, errors.New("db connection error"))
 
-		_, err := svc.CreateTransaction(ctx, 1, 4, 50.0)
+		_, err := svc.CreateTransaction(ctx, 1, 4, 50.0, "")
 
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "database error")
+		assert.Contains(t, err.Error(), domain.ErrMsgDatabaseError)
 	})
 
 	t.Run("CreateTransaction - OpRepo Error", func(t *testing.T) {
 		accRepo := new(MockAccountRepository)
 		opRepo := new(MockOperationRepository)
-		svc := services.NewTransactionService(accRepo, nil, opRepo)
+		svc := services.NewTransactionService(accRepo, nil, opRepo, services.NewAccountAuthorizer(nil), nil, rates)
 
 		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{}, nil)
 		opRepo.On("Exists", ctx, mock.Anything).Return(false, errors.New("db error"))
 
-		_, err := svc.CreateTransaction(ctx, 1, 4, 50.0)
+		_, err := svc.CreateTransaction(ctx, 1, 4, 50.0, "")
 
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "database error")
+		assert.Contains(t, err.Error(), domain.ErrMsgDatabaseError)
 	})
 
 	t.Run("CreateTransaction - Invalid Operation Type", func(t *testing.T) {
 		accRepo := new(MockAccountRepository)
 		opRepo := new(MockOperationRepository)
-		svc := services.NewTransactionService(accRepo, nil, opRepo)
+		svc := services.NewTransactionService(accRepo, nil, opRepo, services.NewAccountAuthorizer(nil), nil, rates)
 
 		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{}, nil)
 		opRepo.On("Exists", ctx, mock.Anything).Return(false, nil)
 
-		_, err := svc.CreateTransaction(ctx, 1, 99, 50.0)
+		_, err := svc.CreateTransaction(ctx, 1, 99, 50.0, "")
 
 		assert.ErrorIs(t, err, common.ErrInvalidOperation)
 	})
@@ -109,12 +263,12 @@ func TestTransactionService(t *testing.T) {
 	t.Run("CreateTransaction - Domain Validation Error", func(t *testing.T) {
 		accRepo := new(MockAccountRepository)
 		opRepo := new(MockOperationRepository)
-		svc := services.NewTransactionService(accRepo, nil, opRepo)
+		svc := services.NewTransactionService(accRepo, nil, opRepo, services.NewAccountAuthorizer(nil), nil, rates)
 
 		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{}, nil)
 		opRepo.On("Exists", ctx, mock.Anything).Return(true, nil)
 
-		_, err := svc.CreateTransaction(ctx, 1, 4, -10.0)
+		_, err := svc.CreateTransaction(ctx, 1, 4, -10.0, "")
 
 		assert.ErrorIs(t, err, common.ErrInvalidAmount)
 	})
@@ -123,13 +277,13 @@ func TestTransactionService(t *testing.T) {
 		accRepo := new(MockAccountRepository)
 		opRepo := new(MockOperationRepository)
 		txRepo := new(MockTransactionRepository)
-		svc := services.NewTransactionService(accRepo, txRepo, opRepo)
+		svc := services.NewTransactionService(accRepo, txRepo, opRepo, services.NewAccountAuthorizer(nil), nil, rates)
 
 		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{}, nil)
 		opRepo.On("Exists", ctx, mock.Anything).Return(true, nil)
 		txRepo.On("Save", ctx, mock.Anything).Return(nil, errors.New("save error"))
 
-		_, err := svc.CreateTransaction(ctx, 1, 4, 50.0)
+		_, err := svc.CreateTransaction(ctx, 1, 4, 50.0, "")
 
 		assert.EqualError(t, err, "save error")
 	})
@@ -138,13 +292,13 @@ func TestTransactionService(t *testing.T) {
 		accRepo := new(MockAccountRepository)
 		opRepo := new(MockOperationRepository)
 		txRepo := new(MockTransactionRepository)
-		svc := services.NewTransactionService(accRepo, txRepo, opRepo)
+		svc := services.NewTransactionService(accRepo, txRepo, opRepo, services.NewAccountAuthorizer(nil), nil, rates)
 
 		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1}, nil)
 		opRepo.On("Exists", ctx, int16(1)).Return(true, nil) // Payment is 1
 		txRepo.On("Save", ctx, mock.Anything).Return(&domain.Transaction{ID: 50}, nil)
 
-		res, err := svc.CreateTransaction(ctx, 1, 1, 25.0)
+		res, err := svc.CreateTransaction(ctx, 1, 1, 25.0, "")
 
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
@@ -153,12 +307,12 @@ func TestTransactionService(t *testing.T) {
 	t.Run("CreateTransaction - Domain Error (generic)", func(t *testing.T) {
 		accRepo := new(MockAccountRepository)
 		opRepo := new(MockOperationRepository)
-		svc := services.NewTransactionService(accRepo, nil, opRepo)
+		svc := services.NewTransactionService(accRepo, nil, opRepo, services.NewAccountAuthorizer(nil), nil, rates)
 
 		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{}, nil)
 		opRepo.On("Exists", ctx, mock.Anything).Return(true, nil)
 
-		_, err := svc.CreateTransaction(ctx, 1, 99, 50.0)
+		_, err := svc.CreateTransaction(ctx, 1, 99, 50.0, "")
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, common.ErrInvalidOperation)
@@ -168,13 +322,13 @@ func TestTransactionService(t *testing.T) {
 		accRepo := new(MockAccountRepository)
 		opRepo := new(MockOperationRepository)
 		txRepo := new(MockTransactionRepository)
-		svc := services.NewTransactionService(accRepo, txRepo, opRepo)
+		svc := services.NewTransactionService(accRepo, txRepo, opRepo, services.NewAccountAuthorizer(nil), nil, rates)
 
 		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1}, nil)
 		opRepo.On("Exists", ctx, int16(3)).Return(true, nil) // Withdrawal is 3
 		txRepo.On("Save", ctx, mock.Anything).Return(&domain.Transaction{ID: 51}, nil)
 
-		res, err := svc.CreateTransaction(ctx, 1, 3, 15.0)
+		res, err := svc.CreateTransaction(ctx, 1, 3, 15.0, "")
 
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
@@ -182,7 +336,7 @@ func TestTransactionService(t *testing.T) {
 
 	t.Run("GetByTransactionID - Success", func(t *testing.T) {
 		txRepo := new(MockTransactionRepository)
-		svc := services.NewTransactionService(nil, txRepo, nil)
+		svc := services.NewTransactionService(nil, txRepo, nil, services.NewAccountAuthorizer(nil), nil, rates)
 
 		txRepo.On("FindByTransactionID", ctx, int64(100)).Return(&domain.Transaction{ID: 100}, nil)
 
@@ -194,7 +348,7 @@ func TestTransactionService(t *testing.T) {
 
 	t.Run("GetByTransactionID - Not Found", func(t *testing.T) {
 		txRepo := new(MockTransactionRepository)
-		svc := services.NewTransactionService(nil, txRepo, nil)
+		svc := services.NewTransactionService(nil, txRepo, nil, services.NewAccountAuthorizer(nil), nil, rates)
 
 		txRepo.On("FindByTransactionID", ctx, int64(999)).Return(nil, common.ErrTransactionNotFound)
 
@@ -207,7 +361,7 @@ func TestTransactionService(t *testing.T) {
 
 	t.Run("GetByTransactionID - Database Error", func(t *testing.T) {
 		txRepo := new(MockTransactionRepository)
-		svc := services.NewTransactionService(nil, txRepo, nil)
+		svc := services.NewTransactionService(nil, txRepo, nil, services.NewAccountAuthorizer(nil), nil, rates)
 
 		txRepo.On("FindByTransactionID", ctx, int64(100)).Return(nil, errors.New("connection failed"))
 
@@ -215,12 +369,12 @@ func TestTransactionService(t *testing.T) {
 
 		assert.Error(t, err)
 		assert.Nil(t, res)
-		assert.Contains(t, err.Error(), "database error")
+		assert.Contains(t, err.Error(), domain.ErrMsgDatabaseError)
 	})
 
 	t.Run("GetByTransactionID - Generic Error", func(t *testing.T) {
 		txRepo := new(MockTransactionRepository)
-		svc := services.NewTransactionService(nil, txRepo, nil)
+		svc := services.NewTransactionService(nil, txRepo, nil, services.NewAccountAuthorizer(nil), nil, rates)
 
 		txRepo.On("FindByTransactionID", ctx, int64(100)).Return(nil, errors.New("not found"))
 
@@ -232,26 +386,26 @@ func TestTransactionService(t *testing.T) {
 	t.Run("CreateTransaction - OpRepo Exists Error", func(t *testing.T) {
 		accRepo := new(MockAccountRepository)
 		opRepo := new(MockOperationRepository)
-		svc := services.NewTransactionService(accRepo, nil, opRepo)
+		svc := services.NewTransactionService(accRepo, nil, opRepo, services.NewAccountAuthorizer(nil), nil, rates)
 
 		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{}, nil)
 		opRepo.On("Exists", ctx, mock.Anything).Return(false, errors.New("database error"))
 
-		_, err := svc.CreateTransaction(ctx, 1, 4, 50.0)
+		_, err := svc.CreateTransaction(ctx, 1, 4, 50.0, "")
 
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "database error")
+		assert.Contains(t, err.Error(), domain.ErrMsgDatabaseError)
 	})
 
 	t.Run("CreateTransaction - Zero Amount", func(t *testing.T) {
 		accRepo := new(MockAccountRepository)
 		opRepo := new(MockOperationRepository)
-		svc := services.NewTransactionService(accRepo, nil, opRepo)
+		svc := services.NewTransactionService(accRepo, nil, opRepo, services.NewAccountAuthorizer(nil), nil, rates)
 
 		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{}, nil)
 		opRepo.On("Exists", ctx, mock.Anything).Return(true, nil)
 
-		_, err := svc.CreateTransaction(ctx, 1, 4, 0)
+		_, err := svc.CreateTransaction(ctx, 1, 4, 0, "")
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, common.ErrInvalidAmount)
@@ -260,14 +414,175 @@ func TestTransactionService(t *testing.T) {
 	t.Run("CreateTransaction - Negative Amount", func(t *testing.T) {
 		accRepo := new(MockAccountRepository)
 		opRepo := new(MockOperationRepository)
-		svc := services.NewTransactionService(accRepo, nil, opRepo)
+		svc := services.NewTransactionService(accRepo, nil, opRepo, services.NewAccountAuthorizer(nil), nil, rates)
 
 		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{}, nil)
 		opRepo.On("Exists", ctx, mock.Anything).Return(true, nil)
 
-		_, err := svc.CreateTransaction(ctx, 1, 4, -50.0)
+		_, err := svc.CreateTransaction(ctx, 1, 4, -50.0, "")
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, common.ErrInvalidAmount)
 	})
+
+	t.Run("ExportTransactions - Success", func(t *testing.T) {
+		accRepo := new(MockAccountRepository)
+		txRepo := new(MockTransactionRepository)
+		svc := services.NewTransactionService(accRepo, txRepo, nil, services.NewAccountAuthorizer(nil), nil, rates)
+		from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(0, 1, 0)
+
+		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1}, nil)
+		txRepo.On("StreamByAccount", ctx, int64(1), from, to).Return([]*domain.Transaction{{ID: 10}, {ID: 11}}, nil)
+
+		var ids []int64
+		err := svc.ExportTransactions(ctx, 1, from, to, nil, func(tx *domain.Transaction) error {
+			ids = append(ids, tx.ID)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{10, 11}, ids)
+	})
+
+	t.Run("ExportTransactions - Account Not Found", func(t *testing.T) {
+		accRepo := new(MockAccountRepository)
+		txRepo := new(MockTransactionRepository)
+		svc := services.NewTransactionService(accRepo, txRepo, nil, services.NewAccountAuthorizer(nil), nil, rates)
+
+		accRepo.On("FindByAccountID", ctx, int64(9)).Return(nil, common.ErrAccountNotFound)
+
+		err := svc.ExportTransactions(ctx, 9, time.Time{}, time.Time{}, nil, nil)
+
+		assert.True(t, common.Is(err, common.ErrNotFound))
+		txRepo.AssertNotCalled(t, "StreamByAccount", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ExportTransactions - Stream Error", func(t *testing.T) {
+		accRepo := new(MockAccountRepository)
+		txRepo := new(MockTransactionRepository)
+		svc := services.NewTransactionService(accRepo, txRepo, nil, services.NewAccountAuthorizer(nil), nil, rates)
+
+		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1}, nil)
+		txRepo.On("StreamByAccount", ctx, int64(1), mock.Anything, mock.Anything).Return(nil, errors.New("connection reset"))
+
+		err := svc.ExportTransactions(ctx, 1, time.Time{}, time.Time{}, nil, func(*domain.Transaction) error { return nil })
+
+		assert.True(t, common.Is(err, common.ErrInternal))
+	})
+}
+
+func TestTransactionServiceBalances(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("GetBalances - Success", func(t *testing.T) {
+		accRepo := new(MockAccountRepository)
+		txRepo := new(MockTransactionRepository)
+		svc := services.NewTransactionService(accRepo, txRepo, nil, services.NewAccountAuthorizer(nil), nil, rates)
+
+		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1, Currency: "BRL"}, nil)
+		txRepo.On("SumByCurrency", ctx, int64(1)).Return([]domain.CurrencyBalance{
+			{Currency: "BRL", Amount: -50, Booked: -50},
+			{Currency: "USD", Amount: 20, Booked: 100},
+		}, nil)
+
+		balances, err := svc.GetBalances(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.Currency("BRL"), balances.Currency)
+		assert.Equal(t, 50.0, balances.Balance)
+		assert.Len(t, balances.Currencies, 2)
+	})
+
+	t.Run("GetBalances - Account Not Found", func(t *testing.T) {
+		accRepo := new(MockAccountRepository)
+		txRepo := new(MockTransactionRepository)
+		svc := services.NewTransactionService(accRepo, txRepo, nil, services.NewAccountAuthorizer(nil), nil, rates)
+
+		accRepo.On("FindByAccountID", ctx, int64(9)).Return(nil, common.ErrAccountNotFound)
+
+		_, err := svc.GetBalances(ctx, 9)
+
+		assert.True(t, common.Is(err, common.ErrNotFound))
+		txRepo.AssertNotCalled(t, "SumByCurrency", mock.Anything, mock.Anything)
+	})
+
+	t.Run("GetBalances - Database Error", func(t *testing.T) {
+		accRepo := new(MockAccountRepository)
+		txRepo := new(MockTransactionRepository)
+		svc := services.NewTransactionService(accRepo, txRepo, nil, services.NewAccountAuthorizer(nil), nil, rates)
+
+		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1}, nil)
+		txRepo.On("SumByCurrency", ctx, int64(1)).Return(nil, errors.New("db down"))
+
+		_, err := svc.GetBalances(ctx, 1)
+
+		assert.True(t, common.Is(err, common.ErrInternal))
+	})
+}
+
+func TestTransactionServiceOwnership(t *testing.T) {
+	t.Run("CreateTransaction - Foreign account looks nonexistent", func(t *testing.T) {
+		accRepo := new(MockAccountRepository)
+		owners := new(MockAccountOwnershipRepository)
+		svc := services.NewTransactionService(accRepo, nil, nil, services.NewAccountAuthorizer(owners), nil, rates)
+		ctx := customerContext("client-1")
+
+		owners.On("IsOwner", ctx, "client-1", int64(2)).Return(false, nil)
+
+		_, err := svc.CreateTransaction(ctx, 2, 4, 50.0, "")
+
+		assert.ErrorIs(t, err, common.ErrAccountNotFound)
+		assert.True(t, common.Is(err, common.ErrValidation))
+		accRepo.AssertNotCalled(t, "FindByAccountID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("CreateTransaction - Owner can post", func(t *testing.T) {
+		accRepo := new(MockAccountRepository)
+		txRepo := new(MockTransactionRepository)
+		opRepo := new(MockOperationRepository)
+		owners := new(MockAccountOwnershipRepository)
+		svc := services.NewTransactionService(accRepo, txRepo, opRepo, services.NewAccountAuthorizer(owners), nil, rates)
+		ctx := customerContext("client-1")
+
+		owners.On("IsOwner", ctx, "client-1", int64(1)).Return(true, nil)
+		accRepo.On("FindByAccountID", ctx, int64(1)).Return(&domain.Account{ID: 1}, nil)
+		opRepo.On("Exists", ctx, int16(4)).Return(true, nil)
+		txRepo.On("Save", ctx, mock.Anything).Return(&domain.Transaction{ID: 7}, nil)
+
+		res, err := svc.CreateTransaction(ctx, 1, 4, 50.0, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), res.ID)
+	})
+
+	t.Run("GetByTransactionID - Foreign transaction is not found", func(t *testing.T) {
+		txRepo := new(MockTransactionRepository)
+		owners := new(MockAccountOwnershipRepository)
+		svc := services.NewTransactionService(nil, txRepo, nil, services.NewAccountAuthorizer(owners), nil, rates)
+		ctx := customerContext("client-1")
+
+		txRepo.On("FindByTransactionID", ctx, int64(100)).Return(&domain.Transaction{ID: 100, AccountID: 2}, nil)
+		owners.On("IsOwner", ctx, "client-1", int64(2)).Return(false, nil)
+
+		res, err := svc.GetByTransactionID(ctx, 100)
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, common.ErrTransactionNotFound)
+		assert.True(t, common.Is(err, common.ErrNotFound))
+	})
+
+	t.Run("GetByTransactionID - Authorization error", func(t *testing.T) {
+		txRepo := new(MockTransactionRepository)
+		owners := new(MockAccountOwnershipRepository)
+		svc := services.NewTransactionService(nil, txRepo, nil, services.NewAccountAuthorizer(owners), nil, rates)
+		ctx := customerContext("client-1")
+
+		txRepo.On("FindByTransactionID", ctx, int64(100)).Return(&domain.Transaction{ID: 100, AccountID: 2}, nil)
+		owners.On("IsOwner", ctx, "client-1", int64(2)).Return(false, errors.New("connection failed"))
+
+		_, err := svc.GetByTransactionID(ctx, 100)
+
+		assert.True(t, common.Is(err, common.ErrInternal))
+	})
 }
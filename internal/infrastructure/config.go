@@ -1,37 +1,288 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
-	"github.com/joho/godotenv"
+	logger "github.com/evythrossell/account-management-api/pkg"
+)
+
+const (
+	EnvDevelopment = "development"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
 )
 
 type Config struct {
-	ServerPort  string
-	DatabaseURL string
-	DBHost      string
-	DBPort      string
-	DBUser      string
-	DBPassword  string
-	DBName      string
-	Environment string
+	ServerPort     string
+	DatabaseURL    string
+	DBHost         string
+	DBPort         string
+	DBUser         string
+	DBPassword     string
+	DBName         string
+	DBSSLMode      string
+	Environment    string
+	HTTP           HTTPConfig
+	TLS            TLSConfig
+	Auth           AuthConfig
+	RateLimit      RateLimitConfig
+	Statements     StatementConfig
+	Imports        ImportConfig
+	Reconciliation ReconciliationConfig
+	Currency       CurrencyConfig
+	Log            LogConfig
+	Tracing        TracingConfig
+	Health         HealthConfig
+	Shutdown       ShutdownConfig
+}
+
+// HTTPConfig holds the server limits. RequestTimeout bounds the handling
+// of each API request and must leave room to write the response within
+// WriteTimeout. Transaction exports stream for longer, so ExportTimeout
+// replaces both on that route.
+type HTTPConfig struct {
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	RequestTimeout    time.Duration
+	ExportTimeout     time.Duration
+	MaxHeaderBytes    int64
+	MaxBodyBytes      int64
+}
+
+// TLSConfig enables HTTPS when a certificate and key are set. ClientAuth is
+// "none", "optional" or "require"; it defaults to "require" when a client CA
+// bundle is configured.
+type TLSConfig struct {
+	CertFile             string
+	KeyFile              string
+	ClientCAFile         string
+	ClientAuth           string
+	ClientIdentitiesFile string
+	ReloadInterval       time.Duration
+}
+
+func (t TLSConfig) Enabled() bool {
+	return t.CertFile != "" || t.KeyFile != ""
+}
+
+// ShutdownConfig: after readiness flips, the server keeps serving for
+// DrainPeriod so load balancers can stop routing to it; Timeout bounds the
+// whole shutdown, drain included.
+type ShutdownConfig struct {
+	DrainPeriod time.Duration
+	Timeout     time.Duration
+}
+
+// HealthConfig bounds each readiness check and how long a readiness result
+// is reused.
+type HealthConfig struct {
+	CheckTimeout time.Duration
+	CacheTTL     time.Duration
+}
+
+// TracingConfig selects where spans are exported: "none", "stdout" or
+// "otlp". The OTLP endpoint comes from the standard OTEL_EXPORTER_OTLP_*
+// variables.
+type TracingConfig struct {
+	Exporter    string
+	ServiceName string
+	SampleRatio float64
+}
+
+type LogConfig struct {
+	Level     logger.Level
+	Format    logger.Format
+	AccessLog AccessLogConfig
+}
+
+// AccessLogConfig controls the per-request log line. Successful responses on
+// SampledRoutes are logged with probability SuccessSampleRate.
+type AccessLogConfig struct {
+	SkipPaths         []string
+	SampledRoutes     []string
+	SuccessSampleRate float64
 }
 
-func Load() (*Config, error) {
-	_ = godotenv.Load()
+type AuthConfig struct {
+	JWKSURL      string
+	JWKSFile     string
+	JWKSCacheTTL time.Duration
+	Issuer       string
+	Audience     string
+	Leeway       time.Duration
+}
+
+// Enabled reports whether bearer token validation is configured. Without a
+// key source the API keeps accepting unauthenticated requests.
+func (a AuthConfig) Enabled() bool {
+	return a.JWKSURL != "" || a.JWKSFile != ""
+}
+
+// RateLimitRule is a token bucket of Burst requests refilled at RPS requests
+// per second. A zero rule disables the limit.
+type RateLimitRule struct {
+	RPS   float64
+	Burst int
+}
+
+type RateLimitConfig struct {
+	Accounts      RateLimitRule
+	Transactions  RateLimitRule
+	AccountWrites RateLimitRule
+}
+
+// StatementConfig sets the default billing day and the payment terms of
+// closed statements. The closing job runs every CloseInterval; zero disables
+// it, for instance on all but one replica.
+type StatementConfig struct {
+	BillingDay          int
+	DueDays             int
+	MinimumPaymentRate  float64
+	MinimumPaymentFloor float64
+	CloseInterval       time.Duration
+}
+
+// ImportConfig tunes batch imports. The worker picks up queued jobs every
+// PollInterval; zero disables it on this replica.
+type ImportConfig struct {
+	ChunkSize    int
+	JobTimeout   time.Duration
+	PollInterval time.Duration
+}
+
+// ReconciliationConfig schedules balance reconciliation every Interval;
+// zero disables it on this replica. Repair lets scheduled runs correct
+// drifted balances instead of only reporting them.
+type ReconciliationConfig struct {
+	Interval  time.Duration
+	BatchSize int
+	Repair    bool
+}
+
+// CurrencyConfig sets the currency of accounts created without one and the
+// exchange rate table used to convert transactions into an account's
+// currency.
+type CurrencyConfig struct {
+	Default   string
+	RatesFile string
+}
+
+// Load builds the configuration from, in increasing precedence: built-in
+// defaults, an optional YAML or JSON file, environment variables and flags.
+// Every invalid or missing value is reported in the returned error.
+func Load(opts ...LoadOption) (*Config, error) {
+	values, err := Resolve(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return values.Config()
+}
+
+// Config parses and validates the merged values.
+func (v *Values) Config() (*Config, error) {
+	p := &parser{values: v}
 
 	cfg := &Config{
-		ServerPort: getEnv("PORT", "8080"),
-		DBHost:     getEnv("POSTGRES_HOST", "localhost"),
-		DBPort:     getEnv("POSTGRES_PORT", "5432"),
-		DBUser:     getEnv("POSTGRES_USER", ""),
-		DBPassword: getEnv("POSTGRES_PASSWORD", ""),
-		DBName:     getEnv("POSTGRES_DB", ""),
+		ServerPort:  p.string("server.port"),
+		Environment: p.string("server.environment"),
+		HTTP: HTTPConfig{
+			ReadTimeout:       p.positiveDuration("server.read_timeout"),
+			ReadHeaderTimeout: p.positiveDuration("server.read_header_timeout"),
+			WriteTimeout:      p.positiveDuration("server.write_timeout"),
+			IdleTimeout:       p.positiveDuration("server.idle_timeout"),
+			RequestTimeout:    p.positiveDuration("server.request_timeout"),
+			ExportTimeout:     p.positiveDuration("server.export_timeout"),
+			MaxHeaderBytes:    p.size("server.max_header_bytes"),
+			MaxBodyBytes:      p.size("server.max_body_bytes"),
+		},
+		TLS: TLSConfig{
+			CertFile:             p.string("server.tls.cert_file"),
+			KeyFile:              p.string("server.tls.key_file"),
+			ClientCAFile:         p.string("server.tls.client_ca_file"),
+			ClientAuth:           p.string("server.tls.client_auth"),
+			ClientIdentitiesFile: p.string("server.tls.client_identities_file"),
+			ReloadInterval:       p.duration("server.tls.reload_interval"),
+		},
+		DBHost:     p.string("database.host"),
+		DBPort:     p.string("database.port"),
+		DBUser:     p.string("database.user"),
+		DBPassword: p.string("database.password"),
+		DBName:     p.string("database.name"),
+		DBSSLMode:  p.string("database.sslmode"),
+		Log: LogConfig{
+			Level:  p.logLevel("logging.level"),
+			Format: p.logFormat("logging.format"),
+			AccessLog: AccessLogConfig{
+				SkipPaths:         p.list("logging.access_log.skip_paths"),
+				SampledRoutes:     p.list("logging.access_log.sampled_routes"),
+				SuccessSampleRate: p.sampleRate("logging.access_log.success_sample_rate"),
+			},
+		},
+		Tracing: TracingConfig{
+			Exporter:    strings.ToLower(p.string("tracing.exporter")),
+			ServiceName: p.string("tracing.service_name"),
+			SampleRatio: p.sampleRate("tracing.sample_ratio"),
+		},
+		Health: HealthConfig{
+			CheckTimeout: p.duration("health.check_timeout"),
+			CacheTTL:     p.duration("health.cache_ttl"),
+		},
+		Shutdown: ShutdownConfig{
+			DrainPeriod: p.duration("shutdown.drain_period"),
+			Timeout:     p.duration("shutdown.timeout"),
+		},
+		Auth: AuthConfig{
+			JWKSURL:      p.string("auth.jwks_url"),
+			JWKSFile:     p.string("auth.jwks_file"),
+			JWKSCacheTTL: p.duration("auth.jwks_cache_ttl"),
+			Issuer:       p.string("auth.issuer"),
+			Audience:     p.string("auth.audience"),
+			Leeway:       p.duration("auth.leeway"),
+		},
+		RateLimit: RateLimitConfig{
+			Accounts:      p.rateLimitRule("limits.accounts"),
+			Transactions:  p.rateLimitRule("limits.transactions"),
+			AccountWrites: p.rateLimitRule("limits.account_writes"),
+		},
+		Statements: StatementConfig{
+			BillingDay:          p.intRange("statements.billing_day", 1, 28),
+			DueDays:             p.intRange("statements.due_days", 0, 60),
+			MinimumPaymentRate:  p.sampleRate("statements.minimum_payment_rate"),
+			MinimumPaymentFloor: p.amount("statements.minimum_payment_floor"),
+			CloseInterval:       p.nonNegativeDuration("statements.close_interval"),
+		},
+		Imports: ImportConfig{
+			ChunkSize:    p.intRange("imports.chunk_size", 1, 10000),
+			JobTimeout:   p.positiveDuration("imports.job_timeout"),
+			PollInterval: p.nonNegativeDuration("imports.poll_interval"),
+		},
+		Reconciliation: ReconciliationConfig{
+			Interval:  p.nonNegativeDuration("reconciliation.interval"),
+			BatchSize: p.intRange("reconciliation.batch_size", 1, 10000),
+			Repair:    p.bool("reconciliation.repair"),
+		},
+		Currency: CurrencyConfig{
+			Default:   p.currency("currency.default"),
+			RatesFile: p.string("currency.rates_file"),
+		},
 	}
 
-	if err := cfg.validate(); err != nil {
-		return nil, err
+	if cfg.TLS.ClientAuth == "" {
+		cfg.TLS.ClientAuth = "none"
+		if cfg.TLS.ClientCAFile != "" {
+			cfg.TLS.ClientAuth = "require"
+		}
+	}
+
+	p.errs = append(p.errs, cfg.validate(p)...)
+	if len(p.errs) > 0 {
+		return nil, errors.Join(p.errs...)
 	}
 
 	cfg.DatabaseURL = buildDatabaseURL(cfg)
@@ -39,31 +290,101 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
-func (c *Config) validate() error {
-	requiredVars := map[string]string{
-		"POSTGRES_USER":     c.DBUser,
-		"POSTGRES_PASSWORD": c.DBPassword,
-		"POSTGRES_HOST":     c.DBHost,
-		"POSTGRES_DB":       c.DBName,
+// validate reports cross-field and required-value problems. Values that
+// failed to parse were already reported by p.
+func (c *Config) validate(p *parser) []error {
+	var errs []error
+
+	for _, key := range []string{"database.user", "database.password", "database.host", "database.name"} {
+		if p.values.get(key).raw == "" {
+			errs = append(errs, p.missing(key))
+		}
+	}
+
+	switch c.Environment {
+	case EnvDevelopment, EnvStaging, EnvProduction:
+	default:
+		errs = append(errs, p.invalid("server.environment", nil))
+	}
+
+	switch c.DBSSLMode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+	default:
+		errs = append(errs, p.invalid("database.sslmode", nil))
+	}
+
+	if c.HTTP.RequestTimeout > 0 && c.HTTP.RequestTimeout >= c.HTTP.WriteTimeout {
+		errs = append(errs, errors.New("HTTP_REQUEST_TIMEOUT must be shorter than HTTP_WRITE_TIMEOUT"))
 	}
+	errs = append(errs, c.TLS.validate(p)...)
 
-	for key, value := range requiredVars {
-		if value == "" {
-			return fmt.Errorf("missing required environment variable: %s", key)
+	switch c.Tracing.Exporter {
+	case "none", "stdout", "otlp":
+	default:
+		errs = append(errs, p.invalid("tracing.exporter", nil))
+	}
+
+	if c.Shutdown.DrainPeriod >= c.Shutdown.Timeout {
+		errs = append(errs, errors.New("SHUTDOWN_DRAIN_PERIOD must be shorter than SHUTDOWN_TIMEOUT"))
+	}
+
+	return append(errs, c.Auth.validate()...)
+}
+
+func (t TLSConfig) validate(p *parser) []error {
+	var errs []error
+	if t.CertFile == "" && t.KeyFile != "" {
+		errs = append(errs, p.missing("server.tls.cert_file"))
+	}
+	if t.KeyFile == "" && t.CertFile != "" {
+		errs = append(errs, p.missing("server.tls.key_file"))
+	}
+
+	switch t.ClientAuth {
+	case "none":
+	case "optional", "require":
+		if t.ClientCAFile == "" {
+			errs = append(errs, errors.New("TLS_CLIENT_AUTH requires TLS_CLIENT_CA_FILE"))
 		}
+	default:
+		errs = append(errs, p.invalid("server.tls.client_auth", nil))
 	}
 
-	return nil
+	if !t.Enabled() && (t.ClientCAFile != "" || t.ClientIdentitiesFile != "") {
+		errs = append(errs, errors.New("client certificate settings require TLS_CERT_FILE and TLS_KEY_FILE"))
+	}
+	if t.ClientIdentitiesFile != "" && t.ClientAuth == "none" {
+		errs = append(errs, errors.New("TLS_CLIENT_IDENTITIES_FILE requires client certificate verification"))
+	}
+	return errs
+}
+
+func (a AuthConfig) validate() []error {
+	if !a.Enabled() {
+		return nil
+	}
+	var errs []error
+	if a.JWKSURL != "" && a.JWKSFile != "" {
+		errs = append(errs, errors.New("only one of AUTH_JWKS_URL or AUTH_JWKS_FILE may be set"))
+	}
+	if a.Issuer == "" {
+		errs = append(errs, fmt.Errorf("missing required environment variable: %s", "AUTH_JWT_ISSUER"))
+	}
+	if a.Audience == "" {
+		errs = append(errs, fmt.Errorf("missing required environment variable: %s", "AUTH_JWT_AUDIENCE"))
+	}
+	return errs
 }
 
 func buildDatabaseURL(c *Config) string {
 	return fmt.Sprintf(
-		"postgres://%s:%s@%s:%s/%s?sslmode=disable",
+		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
 		c.DBUser,
 		c.DBPassword,
 		c.DBHost,
 		c.DBPort,
 		c.DBName,
+		c.DBSSLMode,
 	)
 }
 
@@ -1,11 +1,18 @@
 package config_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/evythrossell/account-management-api/internal/infrastructure"
+	logger "github.com/evythrossell/account-management-api/pkg"
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLoad(t *testing.T) {
@@ -62,4 +69,747 @@ func TestLoad(t *testing.T) {
 		assert.Equal(t, "5432", cfg.DBPort)
 		assert.Equal(t, "8080", cfg.ServerPort)
 	})
+
+	t.Run("Success - Auth configuration", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+		os.Setenv("AUTH_JWKS_URL", "https://gateway.example.com/.well-known/jwks.json")
+		os.Setenv("AUTH_JWT_ISSUER", "https://gateway.example.com")
+		os.Setenv("AUTH_JWT_AUDIENCE", "account-management-api")
+		os.Setenv("AUTH_JWKS_CACHE_TTL", "5m")
+
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		assert.NoError(t, err)
+		assert.True(t, cfg.Auth.Enabled())
+		assert.Equal(t, "https://gateway.example.com", cfg.Auth.Issuer)
+		assert.Equal(t, 5*time.Minute, cfg.Auth.JWKSCacheTTL)
+		assert.Equal(t, 30*time.Second, cfg.Auth.Leeway)
+	})
+
+	t.Run("Success - Auth disabled by default", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		assert.NoError(t, err)
+		assert.False(t, cfg.Auth.Enabled())
+	})
+
+	t.Run("Error - Auth enabled without issuer", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+		os.Setenv("AUTH_JWKS_FILE", "/etc/jwks.json")
+		os.Setenv("AUTH_JWT_AUDIENCE", "account-management-api")
+
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		assert.Error(t, err)
+		assert.Nil(t, cfg)
+		assert.Contains(t, err.Error(), "AUTH_JWT_ISSUER")
+	})
+
+	t.Run("Error - Both JWKS sources configured", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+		os.Setenv("AUTH_JWKS_FILE", "/etc/jwks.json")
+		os.Setenv("AUTH_JWKS_URL", "https://gateway.example.com/.well-known/jwks.json")
+		os.Setenv("AUTH_JWT_ISSUER", "https://gateway.example.com")
+		os.Setenv("AUTH_JWT_AUDIENCE", "account-management-api")
+
+		defer os.Clearenv()
+
+		_, err := config.Load()
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Error - Invalid duration", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+		os.Setenv("AUTH_JWKS_CACHE_TTL", "ten minutes")
+
+		defer os.Clearenv()
+
+		_, err := config.Load()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "AUTH_JWKS_CACHE_TTL")
+	})
+
+	t.Run("Success - Rate limit rules", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+		os.Setenv("RATE_LIMIT_TRANSACTIONS_RPS", "2.5")
+		os.Setenv("RATE_LIMIT_TRANSACTIONS_BURST", "10")
+		os.Setenv("RATE_LIMIT_ACCOUNT_WRITES_RPS", "0.5")
+
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		assert.NoError(t, err)
+		assert.Equal(t, config.RateLimitRule{RPS: 2.5, Burst: 10}, cfg.RateLimit.Transactions)
+		assert.Equal(t, config.RateLimitRule{RPS: 0.5, Burst: 1}, cfg.RateLimit.AccountWrites)
+		assert.Equal(t, config.RateLimitRule{}, cfg.RateLimit.Accounts)
+	})
+
+	t.Run("Error - Invalid rate limit", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+		os.Setenv("RATE_LIMIT_ACCOUNTS_BURST", "-1")
+
+		defer os.Clearenv()
+
+		_, err := config.Load()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "RATE_LIMIT_ACCOUNTS_BURST")
+	})
+
+	t.Run("Success - Log configuration", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+		os.Setenv("LOG_LEVEL", "debug")
+		os.Setenv("LOG_FORMAT", "TEXT")
+
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		assert.NoError(t, err)
+		assert.Equal(t, logger.DebugLevel, cfg.Log.Level)
+		assert.Equal(t, logger.TextFormat, cfg.Log.Format)
+	})
+
+	t.Run("Success - Log defaults", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		assert.NoError(t, err)
+		assert.Equal(t, logger.InfoLevel, cfg.Log.Level)
+		assert.Equal(t, logger.JSONFormat, cfg.Log.Format)
+	})
+
+	t.Run("Error - Invalid log level", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+		os.Setenv("LOG_LEVEL", "verbose")
+
+		defer os.Clearenv()
+
+		_, err := config.Load()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "LOG_LEVEL")
+	})
+
+	t.Run("Success - Access log configuration", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+		os.Setenv("ACCESS_LOG_SAMPLED_ROUTES", "/v1/accounts/:accountId, /v1/transactions/:transactionId")
+		os.Setenv("ACCESS_LOG_SUCCESS_SAMPLE_RATE", "0.1")
+
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/health", "/metrics", "/swagger"}, cfg.Log.AccessLog.SkipPaths)
+		assert.Equal(t, []string{"/v1/accounts/:accountId", "/v1/transactions/:transactionId"}, cfg.Log.AccessLog.SampledRoutes)
+		assert.Equal(t, 0.1, cfg.Log.AccessLog.SuccessSampleRate)
+	})
+
+	t.Run("Error - Sample rate out of range", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+		os.Setenv("ACCESS_LOG_SUCCESS_SAMPLE_RATE", "2")
+
+		defer os.Clearenv()
+
+		_, err := config.Load()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "ACCESS_LOG_SUCCESS_SAMPLE_RATE")
+	})
+
+	t.Run("Success - Tracing configuration", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+		os.Setenv("OTEL_TRACES_EXPORTER", "OTLP")
+		os.Setenv("OTEL_TRACES_SAMPLE_RATIO", "0.25")
+
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		assert.NoError(t, err)
+		assert.Equal(t, config.TracingConfig{
+			Exporter:    "otlp",
+			ServiceName: "account-management-api",
+			SampleRatio: 0.25,
+		}, cfg.Tracing)
+	})
+
+	t.Run("Error - Unknown trace exporter", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+		os.Setenv("OTEL_TRACES_EXPORTER", "jaeger")
+
+		defer os.Clearenv()
+
+		_, err := config.Load()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "OTEL_TRACES_EXPORTER")
+	})
+
+	t.Run("Success - Health configuration", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+		os.Setenv("HEALTH_CHECK_TIMEOUT", "500ms")
+
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 500*time.Millisecond, cfg.Health.CheckTimeout)
+		assert.Equal(t, 2*time.Second, cfg.Health.CacheTTL)
+	})
+
+	t.Run("Success - Shutdown configuration", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+		os.Setenv("SHUTDOWN_DRAIN_PERIOD", "0s")
+
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), cfg.Shutdown.DrainPeriod)
+		assert.Equal(t, 30*time.Second, cfg.Shutdown.Timeout)
+	})
+
+	t.Run("Error - Drain period longer than shutdown timeout", func(t *testing.T) {
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+		os.Setenv("SHUTDOWN_DRAIN_PERIOD", "10s")
+		os.Setenv("SHUTDOWN_TIMEOUT", "5s")
+
+		defer os.Clearenv()
+
+		_, err := config.Load()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "SHUTDOWN_DRAIN_PERIOD")
+	})
+}
+
+func TestLoadStatements(t *testing.T) {
+	setDB := func() {
+		os.Clearenv()
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+	}
+
+	t.Run("Success - Statement defaults", func(t *testing.T) {
+		setDB()
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, config.StatementConfig{
+			BillingDay:          1,
+			DueDays:             10,
+			MinimumPaymentRate:  0.15,
+			MinimumPaymentFloor: 20,
+			CloseInterval:       time.Hour,
+		}, cfg.Statements)
+	})
+
+	t.Run("Success - Close job disabled", func(t *testing.T) {
+		setDB()
+		os.Setenv("STATEMENT_BILLING_DAY", "28")
+		os.Setenv("STATEMENT_CLOSE_INTERVAL", "0")
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, 28, cfg.Statements.BillingDay)
+		assert.Zero(t, cfg.Statements.CloseInterval)
+	})
+
+	t.Run("Error - Invalid statement settings", func(t *testing.T) {
+		setDB()
+		os.Setenv("STATEMENT_BILLING_DAY", "31")
+		os.Setenv("STATEMENT_MINIMUM_PAYMENT_RATE", "1.5")
+		os.Setenv("STATEMENT_MINIMUM_PAYMENT_FLOOR", "-1")
+		os.Setenv("STATEMENT_CLOSE_INTERVAL", "-1h")
+		defer os.Clearenv()
+
+		_, err := config.Load()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "STATEMENT_BILLING_DAY")
+		assert.Contains(t, err.Error(), "must be between 1 and 28")
+		assert.Contains(t, err.Error(), "STATEMENT_MINIMUM_PAYMENT_RATE")
+		assert.Contains(t, err.Error(), "STATEMENT_MINIMUM_PAYMENT_FLOOR")
+		assert.Contains(t, err.Error(), "STATEMENT_CLOSE_INTERVAL")
+	})
+}
+
+func TestLoadImports(t *testing.T) {
+	setDB := func() {
+		os.Clearenv()
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+	}
+
+	t.Run("Success - Import defaults", func(t *testing.T) {
+		setDB()
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, config.ImportConfig{
+			ChunkSize:    500,
+			JobTimeout:   10 * time.Minute,
+			PollInterval: 2 * time.Second,
+		}, cfg.Imports)
+	})
+
+	t.Run("Error - Invalid import settings", func(t *testing.T) {
+		setDB()
+		os.Setenv("IMPORT_CHUNK_SIZE", "0")
+		os.Setenv("IMPORT_JOB_TIMEOUT", "0")
+		defer os.Clearenv()
+
+		_, err := config.Load()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "IMPORT_CHUNK_SIZE")
+		assert.Contains(t, err.Error(), "IMPORT_JOB_TIMEOUT")
+	})
+}
+
+func TestLoadReconciliation(t *testing.T) {
+	setDB := func() {
+		os.Clearenv()
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+	}
+
+	t.Run("Success - Reconciliation defaults", func(t *testing.T) {
+		setDB()
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, config.ReconciliationConfig{
+			Interval:  24 * time.Hour,
+			BatchSize: 500,
+		}, cfg.Reconciliation)
+	})
+
+	t.Run("Success - Repair enabled", func(t *testing.T) {
+		setDB()
+		os.Setenv("RECONCILIATION_REPAIR", "true")
+		os.Setenv("RECONCILIATION_INTERVAL", "0")
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.True(t, cfg.Reconciliation.Repair)
+		assert.Zero(t, cfg.Reconciliation.Interval)
+	})
+
+	t.Run("Error - Invalid reconciliation settings", func(t *testing.T) {
+		setDB()
+		os.Setenv("RECONCILIATION_REPAIR", "sometimes")
+		os.Setenv("RECONCILIATION_BATCH_SIZE", "0")
+		defer os.Clearenv()
+
+		_, err := config.Load()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "RECONCILIATION_REPAIR")
+		assert.Contains(t, err.Error(), "must be true or false")
+		assert.Contains(t, err.Error(), "RECONCILIATION_BATCH_SIZE")
+	})
+}
+
+func TestLoadCurrency(t *testing.T) {
+	setDB := func() {
+		os.Clearenv()
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+	}
+
+	t.Run("Success - Currency defaults", func(t *testing.T) {
+		setDB()
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, config.CurrencyConfig{Default: "BRL"}, cfg.Currency)
+	})
+
+	t.Run("Success - Currency overrides", func(t *testing.T) {
+		setDB()
+		os.Setenv("DEFAULT_CURRENCY", "usd")
+		os.Setenv("FX_RATES_FILE", "/etc/fx/rates.json")
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, config.CurrencyConfig{Default: "USD", RatesFile: "/etc/fx/rates.json"}, cfg.Currency)
+	})
+
+	t.Run("Error - Invalid default currency", func(t *testing.T) {
+		setDB()
+		os.Setenv("DEFAULT_CURRENCY", "real")
+		defer os.Clearenv()
+
+		_, err := config.Load()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "DEFAULT_CURRENCY")
+		assert.Contains(t, err.Error(), "ISO 4217")
+	})
+}
+
+func TestLoadHTTP(t *testing.T) {
+	setDB := func() {
+		os.Clearenv()
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+	}
+
+	t.Run("Success - Server limit defaults", func(t *testing.T) {
+		setDB()
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, config.HTTPConfig{
+			ReadTimeout:       5 * time.Second,
+			ReadHeaderTimeout: 2 * time.Second,
+			WriteTimeout:      10 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			RequestTimeout:    8 * time.Second,
+			ExportTimeout:     10 * time.Minute,
+			MaxHeaderBytes:    1 << 20,
+			MaxBodyBytes:      1 << 20,
+		}, cfg.HTTP)
+	})
+
+	t.Run("Success - Server limit overrides", func(t *testing.T) {
+		setDB()
+		defer os.Clearenv()
+		os.Setenv("HTTP_WRITE_TIMEOUT", "30s")
+		os.Setenv("HTTP_REQUEST_TIMEOUT", "25s")
+		os.Setenv("HTTP_EXPORT_TIMEOUT", "1h")
+		os.Setenv("HTTP_MAX_BODY_BYTES", "4096")
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, 25*time.Second, cfg.HTTP.RequestTimeout)
+		assert.Equal(t, time.Hour, cfg.HTTP.ExportTimeout)
+		assert.Equal(t, int64(4096), cfg.HTTP.MaxBodyBytes)
+	})
+
+	t.Run("Error - Invalid limits", func(t *testing.T) {
+		setDB()
+		defer os.Clearenv()
+		os.Setenv("HTTP_IDLE_TIMEOUT", "0s")
+		os.Setenv("HTTP_MAX_BODY_BYTES", "-1")
+		os.Setenv("HTTP_MAX_HEADER_BYTES", "1MB")
+
+		_, err := config.Load()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "HTTP_IDLE_TIMEOUT")
+		assert.Contains(t, err.Error(), "HTTP_MAX_BODY_BYTES")
+		assert.Contains(t, err.Error(), "HTTP_MAX_HEADER_BYTES")
+	})
+
+	t.Run("Error - Request timeout not shorter than write timeout", func(t *testing.T) {
+		setDB()
+		defer os.Clearenv()
+		os.Setenv("HTTP_REQUEST_TIMEOUT", "10s")
+
+		_, err := config.Load()
+
+		assert.ErrorContains(t, err, "HTTP_REQUEST_TIMEOUT must be shorter than HTTP_WRITE_TIMEOUT")
+	})
+}
+
+func TestLoadTLS(t *testing.T) {
+	setDB := func() {
+		os.Clearenv()
+		os.Setenv("POSTGRES_USER", "user")
+		os.Setenv("POSTGRES_PASSWORD", "pass")
+		os.Setenv("POSTGRES_DB", "db")
+	}
+
+	t.Run("Success - TLS disabled by default", func(t *testing.T) {
+		setDB()
+		defer os.Clearenv()
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.False(t, cfg.TLS.Enabled())
+		assert.Equal(t, "none", cfg.TLS.ClientAuth)
+	})
+
+	t.Run("Success - Client CA implies required client certificates", func(t *testing.T) {
+		setDB()
+		defer os.Clearenv()
+		os.Setenv("TLS_CERT_FILE", "/etc/tls/tls.crt")
+		os.Setenv("TLS_KEY_FILE", "/etc/tls/tls.key")
+		os.Setenv("TLS_CLIENT_CA_FILE", "/etc/tls/ca.crt")
+		os.Setenv("TLS_RELOAD_INTERVAL", "1m")
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.True(t, cfg.TLS.Enabled())
+		assert.Equal(t, "require", cfg.TLS.ClientAuth)
+		assert.Equal(t, time.Minute, cfg.TLS.ReloadInterval)
+	})
+
+	t.Run("Error - Key without certificate", func(t *testing.T) {
+		setDB()
+		defer os.Clearenv()
+		os.Setenv("TLS_KEY_FILE", "/etc/tls/tls.key")
+
+		_, err := config.Load()
+
+		assert.ErrorContains(t, err, "missing required environment variable: TLS_CERT_FILE")
+	})
+
+	t.Run("Error - Client verification without CA", func(t *testing.T) {
+		setDB()
+		defer os.Clearenv()
+		os.Setenv("TLS_CERT_FILE", "/etc/tls/tls.crt")
+		os.Setenv("TLS_KEY_FILE", "/etc/tls/tls.key")
+		os.Setenv("TLS_CLIENT_AUTH", "optional")
+
+		_, err := config.Load()
+
+		assert.ErrorContains(t, err, "TLS_CLIENT_CA_FILE")
+	})
+
+	t.Run("Error - Client identities without verification", func(t *testing.T) {
+		setDB()
+		defer os.Clearenv()
+		os.Setenv("TLS_CLIENT_IDENTITIES_FILE", "/etc/tls/identities.json")
+
+		_, err := config.Load()
+
+		assert.ErrorContains(t, err, "TLS_CLIENT_IDENTITIES_FILE requires client certificate verification")
+	})
+}
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadLayers(t *testing.T) {
+	yamlFile := `
+server:
+  port: 7070
+  environment: staging
+database:
+  user: file-user
+  password: file-pass
+  name: file-db
+logging:
+  level: debug
+  access_log:
+    skip_paths: [/health, /ready]
+limits:
+  accounts:
+    rps: 2.5
+`
+
+	t.Run("Success - YAML file", func(t *testing.T) {
+		os.Clearenv()
+		defer os.Clearenv()
+
+		cfg, err := config.Load(config.WithFile(writeConfigFile(t, "config.yaml", yamlFile)))
+
+		require.NoError(t, err)
+		assert.Equal(t, "7070", cfg.ServerPort)
+		assert.Equal(t, config.EnvStaging, cfg.Environment)
+		assert.Equal(t, "file-user", cfg.DBUser)
+		assert.Equal(t, logger.DebugLevel, cfg.Log.Level)
+		assert.Equal(t, []string{"/health", "/ready"}, cfg.Log.AccessLog.SkipPaths)
+		assert.Equal(t, config.RateLimitRule{RPS: 2.5, Burst: 3}, cfg.RateLimit.Accounts)
+		assert.Equal(t, 30*time.Second, cfg.Shutdown.Timeout)
+	})
+
+	t.Run("Success - JSON file from CONFIG_FILE", func(t *testing.T) {
+		os.Clearenv()
+		defer os.Clearenv()
+		os.Setenv("CONFIG_FILE", writeConfigFile(t, "config.json",
+			`{"database": {"user": "u", "password": "p", "name": "db", "sslmode": "require"}}`))
+
+		cfg, err := config.Load()
+
+		require.NoError(t, err)
+		assert.Contains(t, cfg.DatabaseURL, "sslmode=require")
+	})
+
+	t.Run("Success - Env overrides file, flags override env", func(t *testing.T) {
+		os.Clearenv()
+		defer os.Clearenv()
+		os.Setenv("POSTGRES_USER", "env-user")
+		os.Setenv("PORT", "6060")
+
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		config.RegisterFlags(fs)
+		require.NoError(t, fs.Parse([]string{
+			"--config", writeConfigFile(t, "config.yml", yamlFile),
+			"--server-port", "5050",
+		}))
+
+		cfg, err := config.Load(config.WithFlags(fs))
+
+		require.NoError(t, err)
+		assert.Equal(t, "env-user", cfg.DBUser)
+		assert.Equal(t, "file-db", cfg.DBName)
+		assert.Equal(t, "5050", cfg.ServerPort)
+	})
+
+	t.Run("Error - Reports every problem at once", func(t *testing.T) {
+		os.Clearenv()
+		defer os.Clearenv()
+		os.Setenv("LOG_LEVEL", "loud")
+		os.Setenv("HEALTH_CACHE_TTL", "soon")
+		os.Setenv("APP_ENV", "prod")
+
+		_, err := config.Load()
+
+		require.Error(t, err)
+		for _, want := range []string{"LOG_LEVEL", "HEALTH_CACHE_TTL", "APP_ENV", "POSTGRES_USER", "POSTGRES_PASSWORD", "POSTGRES_DB"} {
+			assert.Contains(t, err.Error(), want)
+		}
+	})
+
+	t.Run("Error - Names the file key", func(t *testing.T) {
+		os.Clearenv()
+		defer os.Clearenv()
+
+		_, err := config.Load(config.WithFile(writeConfigFile(t, "config.yaml", yamlFile+"shutdown:\n  timeout: 1s\n")))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SHUTDOWN_DRAIN_PERIOD must be shorter than SHUTDOWN_TIMEOUT")
+
+		_, err = config.Load(config.WithFile(writeConfigFile(t, "config.yaml", "health:\n  cache_ttl: soon\n")))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "config key health.cache_ttl (HEALTH_CACHE_TTL)")
+	})
+
+	t.Run("Error - Unknown file key", func(t *testing.T) {
+		os.Clearenv()
+		defer os.Clearenv()
+
+		_, err := config.Load(config.WithFile(writeConfigFile(t, "config.yaml", "database:\n  usr: typo\n")))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "database.usr")
+	})
+
+	t.Run("Error - Unsupported file extension", func(t *testing.T) {
+		os.Clearenv()
+		defer os.Clearenv()
+
+		_, err := config.Load(config.WithFile(writeConfigFile(t, "config.toml", "")))
+
+		assert.ErrorContains(t, err, "unsupported config file extension")
+	})
+}
+
+func TestValuesPrint(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+	os.Setenv("POSTGRES_PASSWORD", "s3cr3t-value")
+	os.Setenv("POSTGRES_USER", "user")
+
+	values, err := config.Resolve()
+	require.NoError(t, err)
+
+	t.Run("Print - YAML", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, values.Print(&out, "yaml"))
+
+		assert.NotContains(t, out.String(), "s3cr3t-value")
+		assert.Contains(t, out.String(), "password: '[REDACTED]' # env POSTGRES_PASSWORD")
+		assert.Contains(t, out.String(), "user: user # env POSTGRES_USER")
+		assert.Contains(t, out.String(), "host: localhost # default")
+	})
+
+	t.Run("Print - JSON", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, values.Print(&out, "json"))
+
+		var doc map[string]map[string]any
+		require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+		assert.Equal(t, "[REDACTED]", doc["database"]["password"])
+		assert.Equal(t, "8080", doc["server"]["port"])
+	})
+
+	t.Run("Print - Unknown format", func(t *testing.T) {
+		assert.Error(t, values.Print(&bytes.Buffer{}, "toml"))
+	})
 }
@@ -0,0 +1,73 @@
+package container
+
+import (
+	"context"
+	"time"
+
+	logger "github.com/evythrossell/account-management-api/pkg"
+)
+
+// ImportWorker is the background module that runs queued import jobs once
+// at start and then every interval. A zero interval disables it, leaving
+// the jobs to other replicas.
+type ImportWorker struct {
+	interval time.Duration
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func NewImportWorker(interval time.Duration) *ImportWorker {
+	return &ImportWorker{interval: interval}
+}
+
+func (w *ImportWorker) Name() string {
+	return "import worker"
+}
+
+func (w *ImportWorker) Start(ctx context.Context, c *Container) error {
+	if w.interval <= 0 {
+		return nil
+	}
+
+	log := c.Logger().With(logger.String("module", w.Name()))
+	runCtx, cancel := context.WithCancel(logger.WithLogger(context.Background(), log))
+	w.cancel = cancel
+	w.done = make(chan struct{})
+
+	go func() {
+		defer close(w.done)
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			ran, err := c.ImportService().RunPending(runCtx)
+			if err != nil && runCtx.Err() == nil {
+				log.Error("running import jobs failed", logger.Err(err))
+			}
+			if len(ran) > 0 {
+				log.Info("import jobs ran", logger.Int("count", len(ran)))
+			}
+
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop cancels the job in progress, which is recorded as failed, and waits
+// for the worker to exit, bounded by ctx.
+func (w *ImportWorker) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,60 @@
+package container
+
+import (
+	"context"
+	"sync"
+
+	"github.com/evythrossell/account-management-api/internal/core/port"
+)
+
+// Module is a subsystem with its own lifecycle, such as a storage driver, a
+// transport or a background worker. Modules start in registration order,
+// after the storage module, and stop in reverse order.
+type Module interface {
+	Name() string
+	Start(ctx context.Context, c *Container) error
+	Stop(ctx context.Context) error
+}
+
+// Storage is the module that backs the repositories. Swapping it replaces
+// the whole persistence layer at once.
+type Storage interface {
+	Module
+	AccountRepository() port.AccountRepository
+	TransactionRepository() port.TransactionRepository
+	OperationRepository() port.OperationRepository
+	AccountOwnershipRepository() port.AccountOwnershipRepository
+	StatementRepository() port.StatementRepository
+	ImportJobRepository() port.ImportJobRepository
+	APIKeyRepository() port.APIKeyRepository
+	ReconciliationRepository() port.ReconciliationRepository
+	LedgerRepository() port.LedgerRepository
+}
+
+// Migrator is implemented by storage modules that can create and upgrade
+// their own schema.
+type Migrator interface {
+	Migrate(ctx context.Context) error
+	SchemaVersion(ctx context.Context) (current, expected int, err error)
+}
+
+// component is built on first use unless an override was supplied.
+type component[T any] struct {
+	once       sync.Once
+	value      T
+	overridden bool
+}
+
+func (c *component[T]) get(build func() T) T {
+	c.once.Do(func() {
+		if !c.overridden {
+			c.value = build()
+		}
+	})
+	return c.value
+}
+
+func (c *component[T]) override(value T) {
+	c.value = value
+	c.overridden = true
+}
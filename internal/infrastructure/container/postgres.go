@@ -0,0 +1,106 @@
+package container
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	dbadapter "github.com/evythrossell/account-management-api/internal/adapter/storage/postgres"
+	"github.com/evythrossell/account-management-api/internal/core/port"
+	_ "github.com/lib/pq"
+)
+
+// PostgresStorage is the default storage module. The pool is created on
+// first use; Start verifies the connection and registers the readiness
+// checks and pool metrics.
+type PostgresStorage struct {
+	url    string
+	dbName string
+
+	once    sync.Once
+	db      *sql.DB
+	openErr error
+}
+
+func NewPostgresStorage(url, dbName string) *PostgresStorage {
+	return &PostgresStorage{url: url, dbName: dbName}
+}
+
+func (s *PostgresStorage) Name() string {
+	return "postgres"
+}
+
+func (s *PostgresStorage) DB() *sql.DB {
+	s.once.Do(func() {
+		s.db, s.openErr = sql.Open("postgres", s.url)
+	})
+	return s.db
+}
+
+func (s *PostgresStorage) Start(ctx context.Context, c *Container) error {
+	db := s.DB()
+	if s.openErr != nil {
+		return s.openErr
+	}
+	if err := db.PingContext(ctx); err != nil {
+		return err
+	}
+
+	timeout := c.Config().Health.CheckTimeout
+	c.HealthService().Register("database", timeout, db.PingContext)
+	c.HealthService().Register("migrations", timeout, dbadapter.NewSchemaChecker(db).Check)
+	c.Metrics().RegisterDB(db, s.dbName)
+	return nil
+}
+
+func (s *PostgresStorage) Stop(ctx context.Context) error {
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+func (s *PostgresStorage) Migrate(ctx context.Context) error {
+	return dbadapter.NewSchemaChecker(s.DB()).Migrate(ctx)
+}
+
+func (s *PostgresStorage) SchemaVersion(ctx context.Context) (int, int, error) {
+	current, err := dbadapter.NewSchemaChecker(s.DB()).Version(ctx)
+	return current, dbadapter.SchemaVersion, err
+}
+
+func (s *PostgresStorage) AccountRepository() port.AccountRepository {
+	return dbadapter.NewPostgresAccountRepository(s.DB())
+}
+
+func (s *PostgresStorage) TransactionRepository() port.TransactionRepository {
+	return dbadapter.NewPostgresTransactionRepository(s.DB())
+}
+
+func (s *PostgresStorage) OperationRepository() port.OperationRepository {
+	return dbadapter.NewPostgresOperationRepository(s.DB())
+}
+
+func (s *PostgresStorage) AccountOwnershipRepository() port.AccountOwnershipRepository {
+	return dbadapter.NewPostgresAccountOwnershipRepository(s.DB())
+}
+
+func (s *PostgresStorage) StatementRepository() port.StatementRepository {
+	return dbadapter.NewPostgresStatementRepository(s.DB())
+}
+
+func (s *PostgresStorage) ImportJobRepository() port.ImportJobRepository {
+	return dbadapter.NewPostgresImportJobRepository(s.DB())
+}
+
+func (s *PostgresStorage) APIKeyRepository() port.APIKeyRepository {
+	return dbadapter.NewPostgresAPIKeyRepository(s.DB())
+}
+
+func (s *PostgresStorage) ReconciliationRepository() port.ReconciliationRepository {
+	return dbadapter.NewPostgresReconciliationRepository(s.DB())
+}
+
+func (s *PostgresStorage) LedgerRepository() port.LedgerRepository {
+	return dbadapter.NewPostgresLedgerRepository(s.DB())
+}
@@ -0,0 +1,71 @@
+package container
+
+import (
+	"context"
+	"time"
+
+	logger "github.com/evythrossell/account-management-api/pkg"
+)
+
+// BalanceReconciler is the background module that reconciles balances every
+// interval. Unlike the other jobs it waits a full interval before the first
+// run, so restarts do not each trigger a scan of every account. A zero
+// interval disables it.
+type BalanceReconciler struct {
+	interval time.Duration
+	repair   bool
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func NewBalanceReconciler(interval time.Duration, repair bool) *BalanceReconciler {
+	return &BalanceReconciler{interval: interval, repair: repair}
+}
+
+func (r *BalanceReconciler) Name() string {
+	return "balance reconciler"
+}
+
+func (r *BalanceReconciler) Start(ctx context.Context, c *Container) error {
+	if r.interval <= 0 {
+		return nil
+	}
+
+	log := c.Logger().With(logger.String("module", r.Name()))
+	runCtx, cancel := context.WithCancel(logger.WithLogger(context.Background(), log))
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	go func() {
+		defer close(r.done)
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if _, err := c.ReconciliationService().Reconcile(runCtx, r.repair); err != nil && runCtx.Err() == nil {
+				log.Error("balance reconciliation failed", logger.Err(err))
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop waits for a run in progress to notice the cancellation, bounded by
+// ctx.
+func (r *BalanceReconciler) Stop(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
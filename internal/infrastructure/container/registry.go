@@ -1,33 +1,152 @@
 package container
 
 import (
-	"database/sql"
+	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"time"
 
+	"github.com/evythrossell/account-management-api/internal/adapter/auth"
+	"github.com/evythrossell/account-management-api/internal/adapter/fx"
 	"github.com/evythrossell/account-management-api/internal/adapter/http/handler"
-	dbadapter "github.com/evythrossell/account-management-api/internal/adapter/storage/postgres"
+	"github.com/evythrossell/account-management-api/internal/adapter/metrics"
+	"github.com/evythrossell/account-management-api/internal/adapter/tracing"
+	"github.com/evythrossell/account-management-api/internal/core/domain"
 	"github.com/evythrossell/account-management-api/internal/core/port"
 	service "github.com/evythrossell/account-management-api/internal/core/service"
 	config "github.com/evythrossell/account-management-api/internal/infrastructure"
 	logger "github.com/evythrossell/account-management-api/pkg"
-	_ "github.com/lib/pq"
 )
 
+// Container wires the application. Components are built lazily on first
+// access, so tests only construct what they touch and can replace any
+// repository or service through an Option.
 type Container struct {
-	logger                logger.Logger
-	db                    *sql.DB
-	accountRepository     port.AccountRepository
-	transactionRepository port.TransactionRepository
-	operationRepository   port.OperationRepository
-	accountService        port.AccountService
-	transactionService    port.TransactionService
-	healthService         port.HealthService
-	accountHandler        *handler.AccountHandler
-	healthHandler         *handler.HealthHandler
-	transactionHandler    *handler.TransactionHandler
-}
-
-func New(cfg *config.Config, logger logger.Logger) (*Container, error) {
+	cfg     *config.Config
+	logger  logger.Logger
+	storage Storage
+	modules []Module
+	started []Module
+
+	metrics               component[*metrics.Metrics]
+	accountRepository     component[port.AccountRepository]
+	transactionRepository component[port.TransactionRepository]
+	operationRepository   component[port.OperationRepository]
+	ownershipRepository   component[port.AccountOwnershipRepository]
+	statementRepository   component[port.StatementRepository]
+	importJobRepository   component[port.ImportJobRepository]
+	apiKeyRepository      component[port.APIKeyRepository]
+	reconciliationRepo    component[port.ReconciliationRepository]
+	ledgerRepository      component[port.LedgerRepository]
+	accountAuthorizer     component[port.AccountAuthorizer]
+	fxRates               component[port.FXRateProvider]
+	accountService        component[port.AccountService]
+	transactionService    component[port.TransactionService]
+	statementService      component[port.StatementService]
+	importService         component[port.ImportService]
+	apiKeyService         component[port.APIKeyService]
+	reconciliationService component[port.ReconciliationService]
+	ledgerService         component[port.LedgerService]
+	healthService         component[port.HealthService]
+	tokenVerifier         component[port.TokenVerifier]
+	accountHandler        component[*handler.AccountHandler]
+	healthHandler         component[*handler.HealthHandler]
+	transactionHandler    component[*handler.TransactionHandler]
+	statementHandler      component[*handler.StatementHandler]
+	importHandler         component[*handler.ImportHandler]
+	ledgerHandler         component[*handler.LedgerHandler]
+}
+
+type Option func(*Container)
+
+// WithStorage replaces the Postgres storage module.
+func WithStorage(storage Storage) Option {
+	return func(c *Container) { c.storage = storage }
+}
+
+// WithModule adds a module started after storage.
+func WithModule(m Module) Option {
+	return func(c *Container) { c.modules = append(c.modules, m) }
+}
+
+func WithAccountRepository(r port.AccountRepository) Option {
+	return func(c *Container) { c.accountRepository.override(r) }
+}
+
+func WithTransactionRepository(r port.TransactionRepository) Option {
+	return func(c *Container) { c.transactionRepository.override(r) }
+}
+
+func WithOperationRepository(r port.OperationRepository) Option {
+	return func(c *Container) { c.operationRepository.override(r) }
+}
+
+func WithAccountOwnershipRepository(r port.AccountOwnershipRepository) Option {
+	return func(c *Container) { c.ownershipRepository.override(r) }
+}
+
+func WithStatementRepository(r port.StatementRepository) Option {
+	return func(c *Container) { c.statementRepository.override(r) }
+}
+
+func WithImportJobRepository(r port.ImportJobRepository) Option {
+	return func(c *Container) { c.importJobRepository.override(r) }
+}
+
+func WithAPIKeyRepository(r port.APIKeyRepository) Option {
+	return func(c *Container) { c.apiKeyRepository.override(r) }
+}
+
+func WithReconciliationRepository(r port.ReconciliationRepository) Option {
+	return func(c *Container) { c.reconciliationRepo.override(r) }
+}
+
+func WithLedgerRepository(r port.LedgerRepository) Option {
+	return func(c *Container) { c.ledgerRepository.override(r) }
+}
+
+func WithFXRates(r port.FXRateProvider) Option {
+	return func(c *Container) { c.fxRates.override(r) }
+}
+
+func WithAccountService(s port.AccountService) Option {
+	return func(c *Container) { c.accountService.override(s) }
+}
+
+func WithTransactionService(s port.TransactionService) Option {
+	return func(c *Container) { c.transactionService.override(s) }
+}
+
+func WithStatementService(s port.StatementService) Option {
+	return func(c *Container) { c.statementService.override(s) }
+}
+
+func WithImportService(s port.ImportService) Option {
+	return func(c *Container) { c.importService.override(s) }
+}
+
+func WithAPIKeyService(s port.APIKeyService) Option {
+	return func(c *Container) { c.apiKeyService.override(s) }
+}
+
+func WithReconciliationService(s port.ReconciliationService) Option {
+	return func(c *Container) { c.reconciliationService.override(s) }
+}
+
+func WithLedgerService(s port.LedgerService) Option {
+	return func(c *Container) { c.ledgerService.override(s) }
+}
+
+func WithHealthService(s port.HealthService) Option {
+	return func(c *Container) { c.healthService.override(s) }
+}
+
+func WithTokenVerifier(v port.TokenVerifier) Option {
+	return func(c *Container) { c.tokenVerifier.override(v) }
+}
+
+func New(cfg *config.Config, logger logger.Logger, opts ...Option) (*Container, error) {
 	if cfg == nil {
 		return nil, errors.New("config cannot be nil")
 	}
@@ -37,90 +156,302 @@ func New(cfg *config.Config, logger logger.Logger) (*Container, error) {
 	}
 
 	c := &Container{
+		cfg:    cfg,
 		logger: logger,
 	}
-
-	db, err := sql.Open("postgres", cfg.DatabaseURL)
-	if err != nil {
-		return nil, err
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.storage == nil {
+		c.storage = NewPostgresStorage(cfg.DatabaseURL, cfg.DBName)
 	}
 
-	if err := db.Ping(); err != nil {
-		return nil, err
+	// A bad rate table should stop startup rather than fail the first
+	// transaction in another currency.
+	if !c.fxRates.overridden {
+		rates, err := newFXRates(cfg.Currency)
+		if err != nil {
+			return nil, err
+		}
+		c.fxRates.override(rates)
 	}
+	if currency := domain.Currency(cfg.Currency.Default); !c.FXRates().Supports(currency) {
+		return nil, fmt.Errorf("default currency %s is not in the fx rates", currency)
+	}
+
+	return c, nil
+}
 
-	c.db = db
-	c.logger.Info("database initialized")
+// Start starts the storage module and then every other module. If one fails,
+// the modules already started are stopped before returning.
+func (c *Container) Start(ctx context.Context) error {
+	for _, m := range append([]Module{c.storage}, c.modules...) {
+		if err := m.Start(ctx, c); err != nil {
+			return errors.Join(fmt.Errorf("start %s: %w", m.Name(), err), c.Stop(ctx))
+		}
+		c.started = append(c.started, m)
+		c.logger.Info("module started", logger.String("module", m.Name()))
+	}
+	return nil
+}
 
-	c.accountRepository = dbadapter.NewPostgresAccountRepository(db)
-	c.transactionRepository = dbadapter.NewPostgresTransactionRepository(db)
-	c.operationRepository = dbadapter.NewPostgresOperationRepository(db)
-	c.logger.Info("repositories initialized")
+// Stop stops the started modules in reverse order, attempting every one.
+func (c *Container) Stop(ctx context.Context) error {
+	return c.stop(ctx, 0)
+}
 
-	c.accountService = service.NewAccountService(c.accountRepository)
-	c.transactionService = service.NewTransactionService(
-		c.accountRepository,
-		c.transactionRepository,
-		c.operationRepository,
-	)
-	c.healthService = service.NewHealthService(c.DB())
-	c.logger.Info("services initialized")
+// StopModules stops every started module but storage, which Stop closes
+// later. It lets workers finish before telemetry is flushed.
+func (c *Container) StopModules(ctx context.Context) error {
+	return c.stop(ctx, min(1, len(c.started)))
+}
 
-	c.accountHandler = handler.NewAccountHandler(c.accountService)
-	c.transactionHandler = handler.NewTransactionHandler(c.transactionService)
-	c.healthHandler = handler.NewHealthHandler(c.healthService)
-	c.logger.Info("handlers initialized")
+// stop stops the modules started after the first keep ones.
+func (c *Container) stop(ctx context.Context, keep int) error {
+	var errs []error
+	for i := len(c.started) - 1; i >= keep; i-- {
+		m := c.started[i]
+		if err := m.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stop %s: %w", m.Name(), err))
+			continue
+		}
+		c.logger.Info("module stopped", logger.String("module", m.Name()))
+	}
+	c.started = c.started[:keep]
+	return errors.Join(errs...)
+}
 
-	return c, nil
+func newTokenVerifier(cfg config.AuthConfig) port.TokenVerifier {
+	var keys auth.KeyProvider
+	if cfg.JWKSFile != "" {
+		keys = auth.NewJWKSFileProvider(cfg.JWKSFile, cfg.JWKSCacheTTL)
+	} else {
+		keys = auth.NewJWKSURLProvider(cfg.JWKSURL, &http.Client{Timeout: 5 * time.Second}, cfg.JWKSCacheTTL)
+	}
+	return auth.NewJWTVerifier(keys, cfg.Issuer, cfg.Audience, cfg.Leeway)
 }
 
-func (c *Container) Close() error {
-	if c.db != nil {
-		return c.db.Close()
+// newFXRates loads the configured rate table. Without one, only the default
+// currency is supported.
+func newFXRates(cfg config.CurrencyConfig) (port.FXRateProvider, error) {
+	if cfg.RatesFile == "" {
+		return fx.NewStaticRates(domain.Currency(cfg.Default), time.Time{}, nil), nil
 	}
-	return nil
+	return fx.LoadFile(cfg.RatesFile)
+}
+
+func (c *Container) Config() *config.Config {
+	return c.cfg
 }
 
 func (c *Container) Logger() logger.Logger {
 	return c.logger
 }
 
-func (c *Container) DB() *sql.DB {
-	return c.db
+func (c *Container) Storage() Storage {
+	return c.storage
+}
+
+func (c *Container) Metrics() *metrics.Metrics {
+	return c.metrics.get(metrics.New)
 }
 
 func (c *Container) AccountRepository() port.AccountRepository {
-	return c.accountRepository
+	return c.accountRepository.get(func() port.AccountRepository {
+		return metrics.NewAccountRepository(tracing.NewAccountRepository(c.storage.AccountRepository()), c.Metrics())
+	})
 }
 
 func (c *Container) TransactionRepository() port.TransactionRepository {
-	return c.transactionRepository
+	return c.transactionRepository.get(func() port.TransactionRepository {
+		return metrics.NewTransactionRepository(tracing.NewTransactionRepository(c.storage.TransactionRepository()), c.Metrics())
+	})
 }
 
 func (c *Container) OperationRepository() port.OperationRepository {
-	return c.operationRepository
+	return c.operationRepository.get(func() port.OperationRepository {
+		return metrics.NewOperationRepository(tracing.NewOperationRepository(c.storage.OperationRepository()), c.Metrics())
+	})
+}
+
+func (c *Container) AccountOwnershipRepository() port.AccountOwnershipRepository {
+	return c.ownershipRepository.get(func() port.AccountOwnershipRepository {
+		return tracing.NewAccountOwnershipRepository(c.storage.AccountOwnershipRepository())
+	})
+}
+
+func (c *Container) StatementRepository() port.StatementRepository {
+	return c.statementRepository.get(func() port.StatementRepository {
+		return tracing.NewStatementRepository(c.storage.StatementRepository())
+	})
+}
+
+func (c *Container) ImportJobRepository() port.ImportJobRepository {
+	return c.importJobRepository.get(func() port.ImportJobRepository {
+		return tracing.NewImportJobRepository(c.storage.ImportJobRepository())
+	})
+}
+
+func (c *Container) APIKeyRepository() port.APIKeyRepository {
+	return c.apiKeyRepository.get(func() port.APIKeyRepository {
+		return tracing.NewAPIKeyRepository(c.storage.APIKeyRepository())
+	})
+}
+
+func (c *Container) ReconciliationRepository() port.ReconciliationRepository {
+	return c.reconciliationRepo.get(func() port.ReconciliationRepository {
+		return tracing.NewReconciliationRepository(c.storage.ReconciliationRepository())
+	})
+}
+
+func (c *Container) LedgerRepository() port.LedgerRepository {
+	return c.ledgerRepository.get(func() port.LedgerRepository {
+		return tracing.NewLedgerRepository(c.storage.LedgerRepository())
+	})
+}
+
+func (c *Container) AccountAuthorizer() port.AccountAuthorizer {
+	return c.accountAuthorizer.get(func() port.AccountAuthorizer {
+		return service.NewAccountAuthorizer(c.AccountOwnershipRepository())
+	})
+}
+
+// FXRates is loaded by New, so a bad rate table fails at startup.
+func (c *Container) FXRates() port.FXRateProvider {
+	return c.fxRates.get(func() port.FXRateProvider { return nil })
 }
 
 func (c *Container) HealthService() port.HealthService {
-	return c.healthService
+	return c.healthService.get(func() port.HealthService {
+		return service.NewHealthService(c.cfg.Health.CacheTTL)
+	})
 }
 
 func (c *Container) AccountService() port.AccountService {
-	return c.accountService
+	return c.accountService.get(func() port.AccountService {
+		return tracing.NewAccountService(service.NewAccountService(
+			c.AccountRepository(),
+			c.AccountAuthorizer(),
+			c.FXRates(),
+			domain.Currency(c.cfg.Currency.Default),
+		))
+	})
 }
 
 func (c *Container) TransactionService() port.TransactionService {
-	return c.transactionService
+	return c.transactionService.get(func() port.TransactionService {
+		return metrics.NewTransactionService(tracing.NewTransactionService(service.NewTransactionService(
+			c.AccountRepository(),
+			c.TransactionRepository(),
+			c.OperationRepository(),
+			c.AccountAuthorizer(),
+			c.StatementService(),
+			c.FXRates(),
+		)), c.Metrics())
+	})
+}
+
+func (c *Container) StatementService() port.StatementService {
+	return c.statementService.get(func() port.StatementService {
+		cfg := c.cfg.Statements
+		return service.NewStatementService(
+			c.StatementRepository(),
+			c.AccountRepository(),
+			c.AccountAuthorizer(),
+			cfg.BillingDay,
+			domain.StatementPolicy{
+				DueDays:             cfg.DueDays,
+				MinimumPaymentRate:  cfg.MinimumPaymentRate,
+				MinimumPaymentFloor: cfg.MinimumPaymentFloor,
+			},
+		)
+	})
+}
+
+func (c *Container) ImportService() port.ImportService {
+	return c.importService.get(func() port.ImportService {
+		return metrics.NewImportService(service.NewImportService(
+			c.ImportJobRepository(),
+			c.AccountRepository(),
+			c.TransactionRepository(),
+			c.AccountAuthorizer(),
+			c.StatementService(),
+			c.FXRates(),
+			c.cfg.Imports.ChunkSize,
+			c.cfg.Imports.JobTimeout,
+		), c.Metrics())
+	})
+}
+
+func (c *Container) APIKeyService() port.APIKeyService {
+	return c.apiKeyService.get(func() port.APIKeyService {
+		return service.NewAPIKeyService(c.APIKeyRepository())
+	})
+}
+
+func (c *Container) ReconciliationService() port.ReconciliationService {
+	return c.reconciliationService.get(func() port.ReconciliationService {
+		return metrics.NewReconciliationService(service.NewReconciliationService(
+			c.ReconciliationRepository(),
+			c.cfg.Reconciliation.BatchSize,
+		), c.Metrics())
+	})
+}
+
+func (c *Container) LedgerService() port.LedgerService {
+	return c.ledgerService.get(func() port.LedgerService {
+		return service.NewLedgerService(c.LedgerRepository(), c.TransactionRepository(), c.AccountAuthorizer())
+	})
+}
+
+// Migrator returns the storage's schema migrator, if it has one.
+func (c *Container) Migrator() (Migrator, bool) {
+	m, ok := c.storage.(Migrator)
+	return m, ok
+}
+
+// TokenVerifier returns nil when authentication is not configured.
+func (c *Container) TokenVerifier() port.TokenVerifier {
+	return c.tokenVerifier.get(func() port.TokenVerifier {
+		if !c.cfg.Auth.Enabled() {
+			return nil
+		}
+		return newTokenVerifier(c.cfg.Auth)
+	})
 }
 
 func (c *Container) AccountHandler() *handler.AccountHandler {
-	return c.accountHandler
+	return c.accountHandler.get(func() *handler.AccountHandler {
+		return handler.NewAccountHandler(c.AccountService())
+	})
 }
 
 func (c *Container) HealthHandler() *handler.HealthHandler {
-	return c.healthHandler
+	return c.healthHandler.get(func() *handler.HealthHandler {
+		return handler.NewHealthHandler(c.HealthService())
+	})
 }
 
 func (c *Container) TransactionHandler() *handler.TransactionHandler {
-	return c.transactionHandler
+	return c.transactionHandler.get(func() *handler.TransactionHandler {
+		return handler.NewTransactionHandler(c.TransactionService())
+	})
+}
+
+func (c *Container) StatementHandler() *handler.StatementHandler {
+	return c.statementHandler.get(func() *handler.StatementHandler {
+		return handler.NewStatementHandler(c.StatementService())
+	})
+}
+
+func (c *Container) ImportHandler() *handler.ImportHandler {
+	return c.importHandler.get(func() *handler.ImportHandler {
+		return handler.NewImportHandler(c.ImportService())
+	})
+}
+
+func (c *Container) LedgerHandler() *handler.LedgerHandler {
+	return c.ledgerHandler.get(func() *handler.LedgerHandler {
+		return handler.NewLedgerHandler(c.LedgerService())
+	})
 }
@@ -0,0 +1,72 @@
+package container
+
+import (
+	"context"
+	"time"
+
+	logger "github.com/evythrossell/account-management-api/pkg"
+)
+
+// StatementCloser is the background module that closes due statements once
+// at start and then every interval. A zero interval disables it.
+type StatementCloser struct {
+	interval time.Duration
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func NewStatementCloser(interval time.Duration) *StatementCloser {
+	return &StatementCloser{interval: interval}
+}
+
+func (s *StatementCloser) Name() string {
+	return "statement closer"
+}
+
+func (s *StatementCloser) Start(ctx context.Context, c *Container) error {
+	if s.interval <= 0 {
+		return nil
+	}
+
+	log := c.Logger().With(logger.String("module", s.Name()))
+	runCtx, cancel := context.WithCancel(logger.WithLogger(context.Background(), log))
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			closed, err := c.StatementService().CloseDue(runCtx, time.Now())
+			if err != nil && runCtx.Err() == nil {
+				log.Error("closing statements failed", logger.Err(err))
+			}
+			if closed > 0 {
+				log.Info("statements closed", logger.Int("count", closed))
+			}
+
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop waits for a run in progress to notice the cancellation, bounded by
+// ctx.
+func (s *StatementCloser) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
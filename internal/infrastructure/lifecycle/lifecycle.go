@@ -0,0 +1,138 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	logger "github.com/evythrossell/account-management-api/pkg"
+)
+
+// Phase orders shutdown steps. Steps run phase by phase and, within a phase,
+// in the order they were added.
+type Phase int
+
+const (
+	// PhaseReadiness makes readiness probes fail so no new traffic is routed.
+	PhaseReadiness Phase = iota
+	// PhaseDrain waits for load balancers to notice the readiness flip.
+	PhaseDrain
+	// PhaseHTTP stops accepting connections and waits for in-flight requests.
+	PhaseHTTP
+	// PhaseWorkers stops background workers.
+	PhaseWorkers
+	// PhaseFlush flushes buffered telemetry and outgoing messages.
+	PhaseFlush
+	// PhaseClose releases connections such as the database pool.
+	PhaseClose
+)
+
+type StopFunc func(ctx context.Context) error
+
+type step struct {
+	phase Phase
+	name  string
+	stop  StopFunc
+}
+
+type Config struct {
+	DrainPeriod     time.Duration
+	ShutdownTimeout time.Duration
+}
+
+type Manager struct {
+	cfg    Config
+	logger logger.Logger
+
+	mu    sync.Mutex
+	steps []step
+	once  sync.Once
+	err   error
+}
+
+func New(cfg Config, log logger.Logger) *Manager {
+	return &Manager{cfg: cfg, logger: log}
+}
+
+// Add registers a shutdown step. Steps added during shutdown are ignored.
+func (m *Manager) Add(phase Phase, name string, stop StopFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.steps = append(m.steps, step{phase: phase, name: name, stop: stop})
+}
+
+// Run calls serve in the background and shuts down when ctx is cancelled
+// (e.g. by SIGTERM) or serve returns. It returns serve's error, if any,
+// joined with the shutdown errors.
+func (m *Manager) Run(ctx context.Context, serve func() error) error {
+	served := make(chan error, 1)
+	go func() { served <- serve() }()
+
+	var serveErr error
+	select {
+	case <-ctx.Done():
+		m.logger.Info("shutdown requested")
+	case serveErr = <-served:
+		if serveErr != nil {
+			m.logger.Error("server stopped unexpectedly", logger.Err(serveErr))
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), m.cfg.ShutdownTimeout)
+	defer cancel()
+
+	return errors.Join(serveErr, m.Shutdown(shutdownCtx))
+}
+
+// Shutdown runs every step once, even when earlier steps fail or the
+// deadline passes, so the database is closed no matter what. Later calls
+// return the first result.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.once.Do(func() {
+		m.mu.Lock()
+		steps := make([]step, len(m.steps))
+		copy(steps, m.steps)
+		m.mu.Unlock()
+
+		sort.SliceStable(steps, func(i, j int) bool { return steps[i].phase < steps[j].phase })
+
+		var errs []error
+		for _, s := range steps {
+			start := time.Now()
+			if err := s.stop(ctx); err != nil {
+				m.logger.Error("shutdown step failed", logger.String("step", s.name), logger.Err(err))
+				errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+				continue
+			}
+			m.logger.Info("shutdown step completed",
+				logger.String("step", s.name),
+				logger.Duration("elapsed", time.Since(start)),
+			)
+		}
+		m.err = errors.Join(errs...)
+	})
+	return m.err
+}
+
+// Drain returns a step that waits for the configured drain period, cut short
+// by the shutdown deadline.
+func (m *Manager) Drain() StopFunc {
+	return func(ctx context.Context) error {
+		if m.cfg.DrainPeriod <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(m.cfg.DrainPeriod)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
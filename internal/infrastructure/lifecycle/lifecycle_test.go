@@ -0,0 +1,160 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/evythrossell/account-management-api/internal/infrastructure/lifecycle"
+	logger "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_Shutdown(t *testing.T) {
+	t.Run("should run steps by phase and keep going after failures", func(t *testing.T) {
+		m := lifecycle.New(lifecycle.Config{ShutdownTimeout: time.Second}, logger.NewNoOpLogger())
+
+		var order []string
+		record := func(name string, err error) lifecycle.StopFunc {
+			return func(context.Context) error {
+				order = append(order, name)
+				return err
+			}
+		}
+
+		m.Add(lifecycle.PhaseClose, "database", record("database", nil))
+		m.Add(lifecycle.PhaseHTTP, "http server", record("http server", errors.New("deadline exceeded")))
+		m.Add(lifecycle.PhaseFlush, "tracing", record("tracing", nil))
+		m.Add(lifecycle.PhaseReadiness, "readiness", record("readiness", nil))
+		m.Add(lifecycle.PhaseFlush, "outbox", record("outbox", nil))
+
+		err := m.Shutdown(context.Background())
+
+		assert.Equal(t, []string{"readiness", "http server", "tracing", "outbox", "database"}, order)
+		assert.ErrorContains(t, err, "http server: deadline exceeded")
+	})
+
+	t.Run("should only run once", func(t *testing.T) {
+		m := lifecycle.New(lifecycle.Config{ShutdownTimeout: time.Second}, logger.NewNoOpLogger())
+		calls := 0
+		m.Add(lifecycle.PhaseClose, "database", func(context.Context) error {
+			calls++
+			return nil
+		})
+
+		assert.NoError(t, m.Shutdown(context.Background()))
+		assert.NoError(t, m.Shutdown(context.Background()))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should cut the drain short at the deadline", func(t *testing.T) {
+		m := lifecycle.New(lifecycle.Config{DrainPeriod: time.Minute}, logger.NewNoOpLogger())
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := m.Drain()(ctx)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
+func TestManager_Run(t *testing.T) {
+	t.Run("should shut down when serve fails", func(t *testing.T) {
+		m := lifecycle.New(lifecycle.Config{ShutdownTimeout: time.Second}, logger.NewNoOpLogger())
+		closed := false
+		m.Add(lifecycle.PhaseClose, "database", func(context.Context) error {
+			closed = true
+			return nil
+		})
+
+		err := m.Run(context.Background(), func() error { return errors.New("address already in use") })
+
+		assert.ErrorContains(t, err, "address already in use")
+		assert.True(t, closed)
+	})
+
+	t.Run("should finish in-flight requests and refuse new ones", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := "http://" + listener.Addr().String()
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/slow" {
+				close(started)
+				<-release
+			}
+			w.WriteHeader(http.StatusOK)
+		})}
+
+		var (
+			mu    sync.Mutex
+			steps []string
+		)
+		mark := func(name string) {
+			mu.Lock()
+			defer mu.Unlock()
+			steps = append(steps, name)
+		}
+
+		m := lifecycle.New(lifecycle.Config{ShutdownTimeout: 5 * time.Second}, logger.NewNoOpLogger())
+		m.Add(lifecycle.PhaseHTTP, "http server", srv.Shutdown)
+		m.Add(lifecycle.PhaseClose, "database", func(context.Context) error {
+			mark("database")
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- m.Run(ctx, func() error {
+				if err := srv.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+					return err
+				}
+				return nil
+			})
+		}()
+
+		inFlight := make(chan *http.Response, 1)
+		go func() {
+			resp, err := http.Get(addr + "/slow")
+			if err == nil {
+				inFlight <- resp
+			}
+			close(inFlight)
+		}()
+		<-started
+
+		cancel()
+
+		require.Eventually(t, func() bool {
+			client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}, Timeout: 100 * time.Millisecond}
+			_, err := client.Get(addr + "/fast")
+			return err != nil
+		}, 2*time.Second, 10*time.Millisecond, "new connections should be refused")
+
+		mu.Lock()
+		assert.Empty(t, steps, "database must stay open while requests are in flight")
+		mu.Unlock()
+
+		close(release)
+
+		resp, ok := <-inFlight
+		require.True(t, ok, "in-flight request should complete")
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.NoError(t, <-done)
+		assert.Equal(t, []string{"database"}, steps)
+	})
+}
@@ -0,0 +1,207 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	logger "github.com/evythrossell/account-management-api/pkg"
+)
+
+// parser converts raw values and collects every failure instead of
+// stopping at the first one.
+type parser struct {
+	values *Values
+	errs   []error
+}
+
+// describe names a setting the way the user supplied it, always including
+// the environment variable so errors can be grepped regardless of layer.
+func (p *parser) describe(key string) string {
+	s := lookupSetting(key)
+	switch p.values.get(key).source {
+	case SourceFile:
+		return fmt.Sprintf("config key %s (%s) in %s", s.key, s.env, p.values.file)
+	case SourceFlag:
+		return fmt.Sprintf("flag --%s (%s)", s.flag(), s.env)
+	default:
+		return "environment variable " + s.env
+	}
+}
+
+func (p *parser) invalid(key string, err error) error {
+	raw := p.values.get(key).raw
+	if lookupSetting(key).secret {
+		raw = redacted
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %q: %w", p.describe(key), raw, err)
+	}
+	return fmt.Errorf("invalid value for %s: %q", p.describe(key), raw)
+}
+
+func (p *parser) missing(key string) error {
+	s := lookupSetting(key)
+	return fmt.Errorf("missing required environment variable: %s (or config key %s, flag --%s)", s.env, s.key, s.flag())
+}
+
+func (p *parser) fail(key string, err error) {
+	p.errs = append(p.errs, p.invalid(key, err))
+}
+
+func (p *parser) string(key string) string {
+	return p.values.get(key).raw
+}
+
+// list reads a comma-separated list, dropping empty items.
+func (p *parser) list(key string) []string {
+	var list []string
+	for _, item := range strings.Split(p.string(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (p *parser) duration(key string) time.Duration {
+	d, err := time.ParseDuration(p.string(key))
+	if err != nil {
+		p.fail(key, err)
+	}
+	return d
+}
+
+func (p *parser) positiveDuration(key string) time.Duration {
+	d, err := time.ParseDuration(p.string(key))
+	if err == nil && d <= 0 {
+		err = errors.New("must be positive")
+	}
+	if err != nil {
+		p.fail(key, err)
+	}
+	return d
+}
+
+func (p *parser) nonNegativeDuration(key string) time.Duration {
+	d, err := time.ParseDuration(p.string(key))
+	if err == nil && d < 0 {
+		err = errors.New("must not be negative")
+	}
+	if err != nil {
+		p.fail(key, err)
+	}
+	return d
+}
+
+func (p *parser) bool(key string) bool {
+	b, err := strconv.ParseBool(p.string(key))
+	if err != nil {
+		p.fail(key, errors.New("must be true or false"))
+	}
+	return b
+}
+
+// intRange reads an integer between min and max, inclusive.
+func (p *parser) intRange(key string, min, max int) int {
+	n, err := strconv.Atoi(p.string(key))
+	if err != nil || n < min || n > max {
+		p.fail(key, fmt.Errorf("must be between %d and %d", min, max))
+		return 0
+	}
+	return n
+}
+
+// amount reads a non-negative amount of money.
+func (p *parser) amount(key string) float64 {
+	n, err := strconv.ParseFloat(p.string(key), 64)
+	if err != nil || n < 0 || math.IsInf(n, 0) || math.IsNaN(n) {
+		p.fail(key, nil)
+		return 0
+	}
+	return n
+}
+
+// currency reads a three-letter ISO 4217 code, in upper case.
+func (p *parser) currency(key string) string {
+	code := strings.ToUpper(strings.TrimSpace(p.string(key)))
+	if len(code) != 3 || strings.Trim(code, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		p.fail(key, errors.New("must be a three-letter ISO 4217 code"))
+		return ""
+	}
+	return code
+}
+
+// size reads a positive byte count.
+func (p *parser) size(key string) int64 {
+	n, err := strconv.ParseInt(p.string(key), 10, 64)
+	if err != nil || n <= 0 {
+		p.fail(key, nil)
+		return 0
+	}
+	return n
+}
+
+func (p *parser) sampleRate(key string) float64 {
+	rate, err := strconv.ParseFloat(p.string(key), 64)
+	if err != nil || rate < 0 || rate > 1 {
+		p.fail(key, nil)
+		return 0
+	}
+	return rate
+}
+
+func (p *parser) logLevel(key string) logger.Level {
+	level, err := logger.ParseLevel(p.string(key))
+	if err != nil {
+		p.fail(key, err)
+	}
+	return level
+}
+
+func (p *parser) logFormat(key string) logger.Format {
+	format, err := logger.ParseFormat(p.string(key))
+	if err != nil {
+		p.fail(key, err)
+	}
+	return format
+}
+
+// rateLimitRule reads <prefix>.rps and <prefix>.burst. Burst defaults to the
+// rate rounded up so a rule can be configured with the rate alone.
+func (p *parser) rateLimitRule(prefix string) RateLimitRule {
+	var rule RateLimitRule
+
+	if value := p.string(prefix + ".rps"); value != "" {
+		rps, err := strconv.ParseFloat(value, 64)
+		if err != nil || rps < 0 {
+			p.fail(prefix+".rps", nil)
+		} else {
+			rule.RPS = rps
+			rule.Burst = int(math.Ceil(rps))
+		}
+	}
+
+	if value := p.string(prefix + ".burst"); value != "" {
+		burst, err := strconv.Atoi(value)
+		if err != nil || burst < 0 {
+			p.fail(prefix+".burst", nil)
+		} else {
+			rule.Burst = burst
+		}
+	}
+
+	return rule
+}
+
+func lookupSetting(key string) setting {
+	for _, s := range settings {
+		if s.key == key {
+			return s
+		}
+	}
+	panic("config: unknown setting " + key)
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "[REDACTED]"
+
+// Print writes the effective configuration, nested by section, with secrets
+// redacted. YAML output notes the layer each value came from.
+func (v *Values) Print(w io.Writer, format string) error {
+	switch format {
+	case "yaml", "":
+		return v.printYAML(w)
+	case "json":
+		return v.printJSON(w)
+	default:
+		return fmt.Errorf("unsupported output format %q: use yaml or json", format)
+	}
+}
+
+func (v *Values) display(s setting) string {
+	raw := v.get(s.key).raw
+	if s.secret && raw != "" {
+		return redacted
+	}
+	return raw
+}
+
+func (v *Values) printYAML(w io.Writer) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	if v.file != "" {
+		root.HeadComment = "config file: " + v.file
+	}
+	for _, s := range settings {
+		parent := root
+		parts := strings.Split(s.key, ".")
+		for _, part := range parts[:len(parts)-1] {
+			parent = childMapping(parent, part)
+		}
+
+		comment := string(v.get(s.key).source)
+		switch v.get(s.key).source {
+		case SourceEnv:
+			comment += " " + s.env
+		case SourceFlag:
+			comment += " --" + s.flag()
+		}
+		parent.Content = append(parent.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: parts[len(parts)-1]},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v.display(s), LineComment: comment},
+		)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func childMapping(parent *yaml.Node, key string) *yaml.Node {
+	for i := 0; i < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == key {
+			return parent.Content[i+1]
+		}
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+	return child
+}
+
+func (v *Values) printJSON(w io.Writer) error {
+	root := map[string]any{}
+	for _, s := range settings {
+		parent := root
+		parts := strings.Split(s.key, ".")
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := parent[part].(map[string]any)
+			if !ok {
+				child = map[string]any{}
+				parent[part] = child
+			}
+			parent = child
+		}
+		parent[parts[len(parts)-1]] = v.display(s)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(root)
+}
@@ -0,0 +1,302 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// Source is the layer a setting's value came from. Later layers win:
+// defaults, then the config file, then environment variables, then flags.
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// ConfigFileEnv names the environment variable holding the config file path.
+const ConfigFileEnv = "CONFIG_FILE"
+
+// setting is a single configuration value. key is its dotted path in the
+// config file; the flag name is derived from it.
+type setting struct {
+	key    string
+	env    string
+	def    string
+	usage  string
+	secret bool
+}
+
+func (s setting) flag() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(s.key)
+}
+
+var settings = []setting{
+	{key: "server.port", env: "PORT", def: "8080", usage: "HTTP listen port"},
+	{key: "server.environment", env: "APP_ENV", def: "development", usage: "deployment environment: development, staging or production"},
+	{key: "server.read_timeout", env: "HTTP_READ_TIMEOUT", def: "5s", usage: "maximum time to read a request, body included"},
+	{key: "server.read_header_timeout", env: "HTTP_READ_HEADER_TIMEOUT", def: "2s", usage: "maximum time to read request headers"},
+	{key: "server.write_timeout", env: "HTTP_WRITE_TIMEOUT", def: "10s", usage: "maximum time to write a response"},
+	{key: "server.idle_timeout", env: "HTTP_IDLE_TIMEOUT", def: "60s", usage: "how long idle keep-alive connections stay open"},
+	{key: "server.request_timeout", env: "HTTP_REQUEST_TIMEOUT", def: "8s", usage: "deadline for handling an API request; must be shorter than the write timeout"},
+	{key: "server.export_timeout", env: "HTTP_EXPORT_TIMEOUT", def: "10m", usage: "deadline for streaming a transaction export; replaces the request and write timeouts on that route"},
+	{key: "server.max_header_bytes", env: "HTTP_MAX_HEADER_BYTES", def: "1048576", usage: "maximum size of request headers in bytes"},
+	{key: "server.max_body_bytes", env: "HTTP_MAX_BODY_BYTES", def: "1048576", usage: "maximum size of API request bodies in bytes"},
+	{key: "server.tls.cert_file", env: "TLS_CERT_FILE", usage: "PEM certificate; enables HTTPS together with the key"},
+	{key: "server.tls.key_file", env: "TLS_KEY_FILE", usage: "PEM private key for the certificate"},
+	{key: "server.tls.client_ca_file", env: "TLS_CLIENT_CA_FILE", usage: "PEM CA bundle used to verify client certificates"},
+	{key: "server.tls.client_auth", env: "TLS_CLIENT_AUTH", usage: "client certificate policy: none, optional or require (require when a client CA is set)"},
+	{key: "server.tls.client_identities_file", env: "TLS_CLIENT_IDENTITIES_FILE", usage: "JSON file mapping client certificate subjects to principals"},
+	{key: "server.tls.reload_interval", env: "TLS_RELOAD_INTERVAL", def: "30s", usage: "how often certificate files are checked for changes"},
+	{key: "database.host", env: "POSTGRES_HOST", def: "localhost", usage: "PostgreSQL host"},
+	{key: "database.port", env: "POSTGRES_PORT", def: "5432", usage: "PostgreSQL port"},
+	{key: "database.user", env: "POSTGRES_USER", usage: "PostgreSQL user"},
+	{key: "database.password", env: "POSTGRES_PASSWORD", usage: "PostgreSQL password", secret: true},
+	{key: "database.name", env: "POSTGRES_DB", usage: "PostgreSQL database name"},
+	{key: "database.sslmode", env: "POSTGRES_SSLMODE", def: "disable", usage: "PostgreSQL sslmode"},
+	{key: "logging.level", env: "LOG_LEVEL", def: "info", usage: "minimum log level"},
+	{key: "logging.format", env: "LOG_FORMAT", def: "json", usage: "log format: json or text"},
+	{key: "logging.access_log.skip_paths", env: "ACCESS_LOG_SKIP_PATHS", def: "/health,/metrics,/swagger", usage: "comma-separated path prefixes without access logs"},
+	{key: "logging.access_log.sampled_routes", env: "ACCESS_LOG_SAMPLED_ROUTES", usage: "comma-separated routes whose successes are sampled"},
+	{key: "logging.access_log.success_sample_rate", env: "ACCESS_LOG_SUCCESS_SAMPLE_RATE", def: "1", usage: "share of sampled successes that are logged"},
+	{key: "tracing.exporter", env: "OTEL_TRACES_EXPORTER", def: "none", usage: "span exporter: none, stdout or otlp"},
+	{key: "tracing.service_name", env: "OTEL_SERVICE_NAME", def: "account-management-api", usage: "service.name resource attribute"},
+	{key: "tracing.sample_ratio", env: "OTEL_TRACES_SAMPLE_RATIO", def: "1", usage: "share of new traces that are sampled"},
+	{key: "health.check_timeout", env: "HEALTH_CHECK_TIMEOUT", def: "2s", usage: "timeout for each readiness check"},
+	{key: "health.cache_ttl", env: "HEALTH_CACHE_TTL", def: "2s", usage: "how long a readiness result is reused"},
+	{key: "shutdown.drain_period", env: "SHUTDOWN_DRAIN_PERIOD", def: "5s", usage: "time to keep serving after readiness flips"},
+	{key: "shutdown.timeout", env: "SHUTDOWN_TIMEOUT", def: "30s", usage: "upper bound for the whole shutdown"},
+	{key: "auth.jwks_url", env: "AUTH_JWKS_URL", usage: "JWKS endpoint used to verify bearer tokens"},
+	{key: "auth.jwks_file", env: "AUTH_JWKS_FILE", usage: "local JWKS file used to verify bearer tokens"},
+	{key: "auth.jwks_cache_ttl", env: "AUTH_JWKS_CACHE_TTL", def: "10m", usage: "how long fetched keys are cached"},
+	{key: "auth.issuer", env: "AUTH_JWT_ISSUER", usage: "expected token issuer"},
+	{key: "auth.audience", env: "AUTH_JWT_AUDIENCE", usage: "expected token audience"},
+	{key: "auth.leeway", env: "AUTH_JWT_LEEWAY", def: "30s", usage: "clock skew tolerated on token times"},
+	{key: "limits.accounts.rps", env: "RATE_LIMIT_ACCOUNTS_RPS", usage: "requests per second per client on /v1/accounts"},
+	{key: "limits.accounts.burst", env: "RATE_LIMIT_ACCOUNTS_BURST", usage: "burst per client on /v1/accounts"},
+	{key: "limits.transactions.rps", env: "RATE_LIMIT_TRANSACTIONS_RPS", usage: "requests per second per client on /v1/transactions"},
+	{key: "limits.transactions.burst", env: "RATE_LIMIT_TRANSACTIONS_BURST", usage: "burst per client on /v1/transactions"},
+	{key: "limits.account_writes.rps", env: "RATE_LIMIT_ACCOUNT_WRITES_RPS", usage: "transaction writes per second per account"},
+	{key: "limits.account_writes.burst", env: "RATE_LIMIT_ACCOUNT_WRITES_BURST", usage: "transaction write burst per account"},
+	{key: "statements.billing_day", env: "STATEMENT_BILLING_DAY", def: "1", usage: "day of the month statements close for accounts without their own billing day (1-28)"},
+	{key: "statements.due_days", env: "STATEMENT_DUE_DAYS", def: "10", usage: "days between a statement closing and its due date"},
+	{key: "statements.minimum_payment_rate", env: "STATEMENT_MINIMUM_PAYMENT_RATE", def: "0.15", usage: "share of the amount due asked as minimum payment"},
+	{key: "statements.minimum_payment_floor", env: "STATEMENT_MINIMUM_PAYMENT_FLOOR", def: "20", usage: "smallest minimum payment, capped at the amount due"},
+	{key: "statements.close_interval", env: "STATEMENT_CLOSE_INTERVAL", def: "1h", usage: "how often due statements are closed; 0 disables the job"},
+	{key: "imports.chunk_size", env: "IMPORT_CHUNK_SIZE", def: "500", usage: "transactions saved per chunk by best-effort imports"},
+	{key: "imports.job_timeout", env: "IMPORT_JOB_TIMEOUT", def: "10m", usage: "time a background import job may run"},
+	{key: "reconciliation.interval", env: "RECONCILIATION_INTERVAL", def: "24h", usage: "how often balances are reconciled with the transactions; 0 disables the job"},
+	{key: "reconciliation.batch_size", env: "RECONCILIATION_BATCH_SIZE", def: "500", usage: "accounts compared per query during reconciliation"},
+	{key: "reconciliation.repair", env: "RECONCILIATION_REPAIR", def: "false", usage: "whether scheduled reconciliation corrects drifted balances"},
+	{key: "imports.poll_interval", env: "IMPORT_POLL_INTERVAL", def: "2s", usage: "how often queued import jobs are picked up; 0 disables the worker"},
+	{key: "currency.default", env: "DEFAULT_CURRENCY", def: "BRL", usage: "ISO 4217 currency of accounts created without one"},
+	{key: "currency.rates_file", env: "FX_RATES_FILE", usage: "JSON exchange rate table; without it only the default currency is accepted"},
+}
+
+type value struct {
+	raw    string
+	source Source
+}
+
+// Values is the merged, unparsed configuration. It is what `config print`
+// shows and what Load turns into a Config.
+type Values struct {
+	file   string
+	values map[string]value
+}
+
+// File returns the config file that was read, if any.
+func (v *Values) File() string {
+	return v.file
+}
+
+func (v *Values) get(key string) value {
+	val, ok := v.values[key]
+	if !ok {
+		panic("config: unknown setting " + key)
+	}
+	return val
+}
+
+type LoadOption func(*loader)
+
+type loader struct {
+	file  string
+	flags *pflag.FlagSet
+}
+
+// WithFile reads the given config file. CONFIG_FILE and the --config flag
+// take precedence over it.
+func WithFile(path string) LoadOption {
+	return func(l *loader) { l.file = path }
+}
+
+// WithFlags applies flags registered with RegisterFlags that were set on
+// the command line.
+func WithFlags(fs *pflag.FlagSet) LoadOption {
+	return func(l *loader) { l.flags = fs }
+}
+
+// RegisterFlags adds a flag per setting, plus --config, to fs.
+func RegisterFlags(fs *pflag.FlagSet) {
+	fs.String("config", "", "path to a YAML or JSON config file (env "+ConfigFileEnv+")")
+	for _, s := range settings {
+		fs.String(s.flag(), "", fmt.Sprintf("%s (env %s)", s.usage, s.env))
+	}
+}
+
+// Resolve merges every layer without parsing or validating the result. It
+// only fails when the config file cannot be used.
+func Resolve(opts ...LoadOption) (*Values, error) {
+	_ = godotenv.Load()
+
+	l := &loader{}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	v := &Values{values: make(map[string]value, len(settings))}
+	for _, s := range settings {
+		v.values[s.key] = value{raw: s.def, source: SourceDefault}
+	}
+
+	v.file = l.file
+	if path := getEnv(ConfigFileEnv, ""); path != "" {
+		v.file = path
+	}
+	if l.flags != nil {
+		if f := l.flags.Lookup("config"); f != nil && f.Changed {
+			v.file = f.Value.String()
+		}
+	}
+	if v.file != "" {
+		fileValues, err := readFile(v.file)
+		if err != nil {
+			return nil, err
+		}
+		for key, raw := range fileValues {
+			v.values[key] = value{raw: raw, source: SourceFile}
+		}
+	}
+
+	for _, s := range settings {
+		if raw := getEnv(s.env, ""); raw != "" {
+			v.values[s.key] = value{raw: raw, source: SourceEnv}
+		}
+	}
+
+	if l.flags != nil {
+		for _, s := range settings {
+			if f := l.flags.Lookup(s.flag()); f != nil && f.Changed {
+				v.values[s.key] = value{raw: f.Value.String(), source: SourceFlag}
+			}
+		}
+	}
+
+	return v, nil
+}
+
+// readFile decodes a YAML or JSON file into setting keys. Unknown keys are
+// reported together so a typo is not silently ignored.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	var doc map[string]any
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(data, &doc)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &doc)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q: use .yaml, .yml or .json", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	flat := make(map[string]string)
+	if err := flatten("", doc, flat); err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	known := make(map[string]bool, len(settings))
+	for _, s := range settings {
+		known[s.key] = true
+	}
+	var unknown []string
+	for key := range flat {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown keys in config file %s: %s", path, strings.Join(unknown, ", "))
+	}
+
+	return flat, nil
+}
+
+func flatten(prefix string, node map[string]any, out map[string]string) error {
+	for k, v := range node {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if child, ok := v.(map[string]any); ok {
+			if err := flatten(key, child, out); err != nil {
+				return err
+			}
+			continue
+		}
+		raw, err := scalar(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		out[key] = raw
+	}
+	return nil
+}
+
+// scalar renders a decoded value the way it would be written in an
+// environment variable; lists become comma-separated.
+func scalar(v any) (string, error) {
+	switch val := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return val, nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case int:
+		return strconv.Itoa(val), nil
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), nil
+	case []any:
+		items := make([]string, 0, len(val))
+		for _, item := range val {
+			s, err := scalar(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", v)
+	}
+}
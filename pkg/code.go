@@ -0,0 +1,76 @@
+package pkg
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Code is a stable error code that clients can switch on.
+type Code string
+
+const (
+	CodeValidation      Code = "VALIDATION_ERROR"
+	CodeInvalidBody     Code = "INVALID_BODY"
+	CodeInvalidID       Code = "INVALID_ID"
+	CodeUnauthorized    Code = "UNAUTHORIZED"
+	CodeNotFound        Code = "NOT_FOUND_ERROR"
+	CodeConflict        Code = "CONFLICT_ERROR"
+	CodePayloadTooLarge Code = "PAYLOAD_TOO_LARGE"
+	CodeRateLimited     Code = "RATE_LIMITED"
+	CodeInternal        Code = "INTERNAL_ERROR"
+	CodeTimeout         Code = "TIMEOUT"
+)
+
+// CodeInfo describes how a code is reported over each transport and whether
+// repeating the same request may succeed.
+type CodeInfo struct {
+	Code       Code
+	HTTPStatus int
+	GRPCStatus codes.Code
+	Retryable  bool
+}
+
+// registry lists every code the API reports. ERRORS.md documents each one.
+var registry = []CodeInfo{
+	{Code: CodeValidation, HTTPStatus: http.StatusBadRequest, GRPCStatus: codes.InvalidArgument},
+	{Code: CodeInvalidBody, HTTPStatus: http.StatusBadRequest, GRPCStatus: codes.InvalidArgument},
+	{Code: CodeInvalidID, HTTPStatus: http.StatusBadRequest, GRPCStatus: codes.InvalidArgument},
+	{Code: CodeUnauthorized, HTTPStatus: http.StatusUnauthorized, GRPCStatus: codes.Unauthenticated},
+	{Code: CodeNotFound, HTTPStatus: http.StatusNotFound, GRPCStatus: codes.NotFound},
+	{Code: CodeConflict, HTTPStatus: http.StatusConflict, GRPCStatus: codes.AlreadyExists},
+	{Code: CodePayloadTooLarge, HTTPStatus: http.StatusRequestEntityTooLarge, GRPCStatus: codes.ResourceExhausted},
+	{Code: CodeRateLimited, HTTPStatus: http.StatusTooManyRequests, GRPCStatus: codes.ResourceExhausted, Retryable: true},
+	{Code: CodeInternal, HTTPStatus: http.StatusInternalServerError, GRPCStatus: codes.Internal, Retryable: true},
+	{Code: CodeTimeout, HTTPStatus: http.StatusGatewayTimeout, GRPCStatus: codes.DeadlineExceeded, Retryable: true},
+}
+
+var registryByCode = func() map[Code]CodeInfo {
+	byCode := make(map[Code]CodeInfo, len(registry))
+	for _, info := range registry {
+		byCode[info.Code] = info
+	}
+	return byCode
+}()
+
+// Codes returns every registered code.
+func Codes() []CodeInfo {
+	return append([]CodeInfo(nil), registry...)
+}
+
+// LookupCode returns the registry entry for code.
+func LookupCode(code Code) (CodeInfo, bool) {
+	info, ok := registryByCode[code]
+	return info, ok
+}
+
+// Info returns the registry entry for code. Unregistered codes are reported
+// like CodeInternal.
+func (code Code) Info() CodeInfo {
+	if info, ok := LookupCode(code); ok {
+		return info
+	}
+	info := registryByCode[CodeInternal]
+	info.Code = code
+	return info
+}
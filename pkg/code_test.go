@@ -0,0 +1,114 @@
+package pkg_test
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+
+	common "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// declaredCodes returns the value of every Code constant in code.go, so a
+// code added without a registry entry fails the tests below.
+func declaredCodes(t *testing.T) []common.Code {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), "code.go", nil, 0)
+	require.NoError(t, err)
+
+	var declared []common.Code
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if ident, ok := vs.Type.(*ast.Ident); !ok || ident.Name != "Code" {
+				continue
+			}
+			for _, value := range vs.Values {
+				lit := value.(*ast.BasicLit)
+				code, err := strconv.Unquote(lit.Value)
+				require.NoError(t, err)
+				declared = append(declared, common.Code(code))
+			}
+		}
+	}
+	require.NotEmpty(t, declared)
+	return declared
+}
+
+func TestCodeRegistry(t *testing.T) {
+	t.Run("should map every declared code", func(t *testing.T) {
+		declared := declaredCodes(t)
+		assert.Len(t, common.Codes(), len(declared))
+
+		for _, code := range declared {
+			info, ok := common.LookupCode(code)
+			if !assert.True(t, ok, "%s has no registry entry", code) {
+				continue
+			}
+			assert.GreaterOrEqual(t, info.HTTPStatus, http.StatusBadRequest, code)
+			assert.NotEmpty(t, http.StatusText(info.HTTPStatus), code)
+			assert.NotEqual(t, codes.OK, info.GRPCStatus, code)
+		}
+	})
+
+	t.Run("should document every code in the error catalog", func(t *testing.T) {
+		catalog, err := os.ReadFile("../ERRORS.md")
+		require.NoError(t, err)
+
+		documented := map[common.Code]bool{}
+		for _, m := range regexp.MustCompile("(?m)^### `([A-Z_]+)`$").FindAllStringSubmatch(string(catalog), -1) {
+			documented[common.Code(m[1])] = true
+		}
+		for _, info := range common.Codes() {
+			assert.True(t, documented[info.Code], "%s is missing from ERRORS.md", info.Code)
+			delete(documented, info.Code)
+		}
+		assert.Empty(t, documented, "ERRORS.md documents unregistered codes")
+	})
+
+	t.Run("should report unknown codes as internal errors", func(t *testing.T) {
+		info := common.Code("NO_SUCH_CODE").Info()
+
+		assert.Equal(t, http.StatusInternalServerError, info.HTTPStatus)
+		assert.Equal(t, codes.Internal, info.GRPCStatus)
+	})
+}
+
+func TestDomainErrorStatus(t *testing.T) {
+	cases := []struct {
+		err       *common.DomainError
+		http      int
+		grpc      codes.Code
+		retryable bool
+	}{
+		{common.NewValidationError("", nil), http.StatusBadRequest, codes.InvalidArgument, false},
+		{common.NewInvalidBodyError("", nil), http.StatusBadRequest, codes.InvalidArgument, false},
+		{common.NewInvalidIDError("", nil), http.StatusBadRequest, codes.InvalidArgument, false},
+		{common.NewUnauthorizedError("", nil), http.StatusUnauthorized, codes.Unauthenticated, false},
+		{common.NewNotFoundError("", nil), http.StatusNotFound, codes.NotFound, false},
+		{common.NewConflictError("", nil), http.StatusConflict, codes.AlreadyExists, false},
+		{common.NewPayloadTooLargeError("", nil), http.StatusRequestEntityTooLarge, codes.ResourceExhausted, false},
+		{common.NewRateLimitError("", nil), http.StatusTooManyRequests, codes.ResourceExhausted, true},
+		{common.NewInternalError("", errors.New("boom")), http.StatusInternalServerError, codes.Internal, true},
+		{common.NewTimeoutError("", nil), http.StatusGatewayTimeout, codes.DeadlineExceeded, true},
+	}
+	for _, tc := range cases {
+		t.Run(string(tc.err.Code), func(t *testing.T) {
+			assert.Equal(t, tc.http, tc.err.HTTPStatusCode())
+			assert.Equal(t, tc.grpc, tc.err.GRPCStatusCode())
+			assert.Equal(t, tc.retryable, tc.err.Retryable())
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package pkg
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type requestIDKey struct{}
+
+type loggerKey struct{}
+
+var defaultLogger atomic.Value
+
+func init() {
+	defaultLogger.Store(loggerHolder{NewNoOpLogger()})
+}
+
+// loggerHolder keeps atomic.Value happy when loggers of different concrete
+// types are stored over time.
+type loggerHolder struct {
+	Logger
+}
+
+// SetDefault sets the logger returned by FromContext when the context does
+// not carry one, e.g. for work started outside an HTTP request.
+func SetDefault(l Logger) {
+	if l == nil {
+		l = NewNoOpLogger()
+	}
+	defaultLogger.Store(loggerHolder{l})
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// WithLogger stores a logger in the context. The request ID already present
+// in the context is attached to every line it emits.
+func WithLogger(ctx context.Context, l Logger) context.Context {
+	if id := RequestIDFromContext(ctx); id != "" {
+		l = l.With(String("request_id", id))
+	}
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// WithFields binds a child of the context logger carrying fields, so later
+// lines include them without every caller repeating them.
+func WithFields(ctx context.Context, fields ...Field) context.Context {
+	return context.WithValue(ctx, loggerKey{}, FromContext(ctx).With(fields...))
+}
+
+func FromContext(ctx context.Context) Logger {
+	if l, ok := ctx.Value(loggerKey{}).(Logger); ok && l != nil {
+		return l
+	}
+	return defaultLogger.Load().(loggerHolder).Logger
+}
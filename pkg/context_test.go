@@ -0,0 +1,107 @@
+package pkg_test
+
+import (
+	"context"
+	"testing"
+
+	logger "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+type entry struct {
+	level  string
+	msg    string
+	fields []logger.Field
+}
+
+type recordingLogger struct {
+	root    *recordingLogger
+	fields  []logger.Field
+	entries []entry
+}
+
+func (r *recordingLogger) record(level, msg string, fields []logger.Field) {
+	root := r
+	if r.root != nil {
+		root = r.root
+	}
+	all := append(append([]logger.Field{}, r.fields...), fields...)
+	root.entries = append(root.entries, entry{level: level, msg: msg, fields: all})
+}
+
+func (r *recordingLogger) With(fields ...logger.Field) logger.Logger {
+	root := r
+	if r.root != nil {
+		root = r.root
+	}
+	return &recordingLogger{root: root, fields: append(append([]logger.Field{}, r.fields...), fields...)}
+}
+
+func (r *recordingLogger) Debug(msg string, fields ...logger.Field) { r.record("debug", msg, fields) }
+func (r *recordingLogger) Info(msg string, fields ...logger.Field)  { r.record("info", msg, fields) }
+func (r *recordingLogger) Warn(msg string, fields ...logger.Field)  { r.record("warn", msg, fields) }
+func (r *recordingLogger) Error(msg string, fields ...logger.Field) { r.record("error", msg, fields) }
+func (r *recordingLogger) Fatal(msg string, fields ...logger.Field) { r.record("fatal", msg, fields) }
+
+func TestRequestIDContext(t *testing.T) {
+	ctx := logger.WithRequestID(context.Background(), "req-1")
+
+	assert.Equal(t, "req-1", logger.RequestIDFromContext(ctx))
+	assert.Empty(t, logger.RequestIDFromContext(context.Background()))
+}
+
+func TestFromContext(t *testing.T) {
+	t.Run("should tag lines with the request ID", func(t *testing.T) {
+		base := &recordingLogger{}
+		ctx := logger.WithRequestID(context.Background(), "req-1")
+		ctx = logger.WithLogger(ctx, base)
+
+		logger.FromContext(ctx).Info("transaction created", logger.Int64("transaction_id", 10))
+		logger.FromContext(ctx).Error("request failed")
+
+		assert.Len(t, base.entries, 2)
+		assert.Equal(t, "info", base.entries[0].level)
+		assert.Equal(t, []logger.Field{
+			logger.String("request_id", "req-1"),
+			logger.Int64("transaction_id", 10),
+		}, base.entries[0].fields)
+		assert.Equal(t, []logger.Field{logger.String("request_id", "req-1")}, base.entries[1].fields)
+	})
+
+	t.Run("should return the stored logger untouched without request ID", func(t *testing.T) {
+		base := &recordingLogger{}
+		ctx := logger.WithLogger(context.Background(), base)
+
+		assert.Same(t, base, logger.FromContext(ctx))
+	})
+
+	t.Run("should add fields to the context logger", func(t *testing.T) {
+		base := &recordingLogger{}
+		ctx := logger.WithRequestID(context.Background(), "req-1")
+		ctx = logger.WithLogger(ctx, base)
+		ctx = logger.WithFields(ctx, logger.String("trace_id", "abc"))
+
+		logger.FromContext(ctx).Info("traced")
+
+		assert.Equal(t, []logger.Field{
+			logger.String("request_id", "req-1"),
+			logger.String("trace_id", "abc"),
+		}, base.entries[0].fields)
+	})
+
+	t.Run("should fall back to the default logger", func(t *testing.T) {
+		base := &recordingLogger{}
+		logger.SetDefault(base)
+		defer logger.SetDefault(nil)
+
+		logger.FromContext(context.Background()).Warn("background job")
+
+		assert.Len(t, base.entries, 1)
+	})
+
+	t.Run("should default to a no-op logger", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			logger.FromContext(context.Background()).Info("dropped")
+		})
+	})
+}
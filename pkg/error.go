@@ -3,7 +3,8 @@ package pkg
 import (
 	"errors"
 	"fmt"
-	"net/http"
+
+	"google.golang.org/grpc/codes"
 )
 
 var (
@@ -12,99 +13,215 @@ var (
 
 	ErrInvalidDocument      = errors.New("invalid document format")
 	ErrAccountAlreadyExists = errors.New("account with this document already exists")
+	ErrInvalidAccountBatch  = errors.New("account batch size out of range")
 
 	ErrInvalidAmount    = errors.New("amount must be greater than zero")
 	ErrInvalidOperation = errors.New("invalid operation type for transaction")
+
+	ErrStatementNotFound      = errors.New("statement not found")
+	ErrStatementAlreadyClosed = errors.New("statement period already closed")
+	ErrInvalidBillingDay      = errors.New("billing day out of range")
+	ErrInvalidExportFormat    = errors.New("unsupported export format")
+	ErrInvalidPeriod          = errors.New("invalid period")
+	ErrInvalidImportMode      = errors.New("unsupported import mode")
+	ErrInvalidImportFile      = errors.New("invalid import file")
+	ErrImportJobNotFound      = errors.New("import job not found")
+
+	ErrMissingToken = errors.New("missing bearer token")
+	ErrInvalidToken = errors.New("invalid or expired token")
+
+	ErrUnbalancedEntry      = errors.New("journal entry debits and credits differ")
+	ErrJournalEntryNotFound = errors.New("journal entry not found")
+
+	ErrInvalidCurrency     = errors.New("currency must be a three-letter ISO 4217 code")
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+
+	ErrInvalidAPIKey     = errors.New("invalid or revoked api key")
+	ErrAPIKeyNotFound    = errors.New("api key not found")
+	ErrInvalidAPIKeyName = errors.New("api key name is required")
 )
 
+// FieldError points at the request field that failed validation, using the
+// field's path in the JSON body or the name of the path parameter. Reason is
+// a message key until the error is rendered for a locale.
+type FieldError struct {
+	Field  string         `json:"field"`
+	Reason string         `json:"reason"`
+	Params map[string]any `json:"-"`
+}
+
+// DomainError carries a message key and its parameters rather than rendered
+// text, so the HTTP layer can answer in the client's language.
 type DomainError struct {
-	Code    string
-	Message string
-	Err     error
+	Code   Code
+	Key    string
+	Params map[string]any
+	Err    error
+	Fields []FieldError
 }
 
 func (e *DomainError) Error() string {
+	msg := string(e.Code)
+	if e.Key != "" {
+		msg += ": " + e.Key
+	}
 	if e.Err != nil {
-		return fmt.Sprintf("%s: %s (%v)", e.Code, e.Message, e.Err)
+		return fmt.Sprintf("%s (%v)", msg, e.Err)
 	}
-	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+	return msg
 }
 
 func (e *DomainError) Unwrap() error {
 	return e.Err
 }
 
-func (e *DomainError) HTTPStatusCode() int {
-	switch e.Code {
-	case "VALIDATION_ERROR":
-		return http.StatusBadRequest
-	case "CONFLICT_ERROR":
-		return http.StatusConflict
-	case "NOT_FOUND_ERROR":
-		return http.StatusNotFound
-	default:
-		return http.StatusInternalServerError
-	}
+// WithField records a field-level reason. Use it on errors built by the New*
+// constructors, never on the shared sentinels.
+func (e *DomainError) WithField(field, reason string) *DomainError {
+	e.Fields = append(e.Fields, FieldError{Field: field, Reason: reason})
+	return e
 }
 
-func (e *DomainError) PublicMessage() string {
-	if e.Message != "" {
-		return e.Message
+// WithParam sets a value for the {name} placeholder of the message.
+func (e *DomainError) WithParam(name string, value any) *DomainError {
+	if e.Params == nil {
+		e.Params = make(map[string]any)
 	}
-	return "An unexpected error occurred"
+	e.Params[name] = value
+	return e
+}
+
+func (e *DomainError) HTTPStatusCode() int {
+	return e.Code.Info().HTTPStatus
+}
+
+func (e *DomainError) GRPCStatusCode() codes.Code {
+	return e.Code.Info().GRPCStatus
+}
+
+func (e *DomainError) Retryable() bool {
+	return e.Code.Info().Retryable
 }
 
 var (
 	ErrValidation = &DomainError{
-		Code:    "VALIDATION_ERROR",
-		Message: "Invalid input provided",
+		Code: CodeValidation,
+	}
+
+	ErrInvalidBody = &DomainError{
+		Code: CodeInvalidBody,
+	}
+
+	ErrInvalidID = &DomainError{
+		Code: CodeInvalidID,
 	}
 
 	ErrConflict = &DomainError{
-		Code:    "CONFLICT_ERROR",
-		Message: "Resource conflict",
+		Code: CodeConflict,
 	}
 
 	ErrNotFound = &DomainError{
-		Code:    "NOT_FOUND_ERROR",
-		Message: "Resource not found",
+		Code: CodeNotFound,
+	}
+
+	ErrUnauthorized = &DomainError{
+		Code: CodeUnauthorized,
+	}
+
+	ErrRateLimited = &DomainError{
+		Code: CodeRateLimited,
+	}
+
+	ErrPayloadTooLarge = &DomainError{
+		Code: CodePayloadTooLarge,
+	}
+
+	ErrTimeout = &DomainError{
+		Code: CodeTimeout,
 	}
 
 	ErrInternal = &DomainError{
-		Code:    "INTERNAL_ERROR",
-		Message: "Internal server error",
+		Code: CodeInternal,
 	}
 )
 
-func NewValidationError(msg string, err error) *DomainError {
+func NewValidationError(key string, err error) *DomainError {
+	return &DomainError{
+		Code: ErrValidation.Code,
+		Key:  key,
+		Err:  err,
+	}
+}
+
+func NewInvalidBodyError(key string, err error) *DomainError {
+	return &DomainError{
+		Code: ErrInvalidBody.Code,
+		Key:  key,
+		Err:  err,
+	}
+}
+
+func NewInvalidIDError(key string, err error) *DomainError {
+	return &DomainError{
+		Code: ErrInvalidID.Code,
+		Key:  key,
+		Err:  err,
+	}
+}
+
+func NewConflictError(key string, err error) *DomainError {
+	return &DomainError{
+		Code: ErrConflict.Code,
+		Key:  key,
+		Err:  err,
+	}
+}
+
+func NewNotFoundError(key string, err error) *DomainError {
+	return &DomainError{
+		Code: ErrNotFound.Code,
+		Key:  key,
+		Err:  err,
+	}
+}
+
+func NewUnauthorizedError(key string, err error) *DomainError {
+	return &DomainError{
+		Code: ErrUnauthorized.Code,
+		Key:  key,
+		Err:  err,
+	}
+}
+
+func NewRateLimitError(key string, err error) *DomainError {
 	return &DomainError{
-		Code:    ErrValidation.Code,
-		Message: msg,
-		Err:     err,
+		Code: ErrRateLimited.Code,
+		Key:  key,
+		Err:  err,
 	}
 }
 
-func NewConflictError(msg string, err error) *DomainError {
+func NewPayloadTooLargeError(key string, err error) *DomainError {
 	return &DomainError{
-		Code:    ErrConflict.Code,
-		Message: msg,
-		Err:     err,
+		Code: ErrPayloadTooLarge.Code,
+		Key:  key,
+		Err:  err,
 	}
 }
 
-func NewNotFoundError(msg string, err error) *DomainError {
+func NewTimeoutError(key string, err error) *DomainError {
 	return &DomainError{
-		Code:    ErrNotFound.Code,
-		Message: msg,
-		Err:     err,
+		Code: ErrTimeout.Code,
+		Key:  key,
+		Err:  err,
 	}
 }
 
-func NewInternalError(msg string, err error) *DomainError {
+func NewInternalError(key string, err error) *DomainError {
 	return &DomainError{
-		Code:    ErrInternal.Code,
-		Message: msg,
-		Err:     err,
+		Code: ErrInternal.Code,
+		Key:  key,
+		Err:  err,
 	}
 }
 
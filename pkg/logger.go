@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 )
 
 type Level string
@@ -15,17 +17,73 @@ const (
 	ErrorLevel Level = "ERROR"
 )
 
+// ParseLevel accepts the level names case-insensitively, as found in
+// LOG_LEVEL.
+func ParseLevel(s string) (Level, error) {
+	switch level := Level(strings.ToUpper(strings.TrimSpace(s))); level {
+	case DebugLevel, InfoLevel, WarnLevel, ErrorLevel:
+		return level, nil
+	case "WARNING":
+		return WarnLevel, nil
+	default:
+		return "", fmt.Errorf("unknown log level %q", s)
+	}
+}
+
+func (l Level) rank() int {
+	switch l {
+	case DebugLevel:
+		return 0
+	case InfoLevel:
+		return 1
+	case WarnLevel:
+		return 2
+	case ErrorLevel:
+		return 3
+	default:
+		return 1
+	}
+}
+
 type Logger interface {
 	Debug(msg string, fields ...Field)
 	Info(msg string, fields ...Field)
 	Warn(msg string, fields ...Field)
 	Error(msg string, fields ...Field)
 	Fatal(msg string, fields ...Field)
+	With(fields ...Field) Logger
 }
 
 type Field struct {
-	Key   string
-	Value interface{}
+	Key       string
+	Value     interface{}
+	Sensitive bool
+}
+
+// sensitiveKeys are masked even when the caller forgot to use Sensitive.
+var sensitiveKeys = map[string]bool{
+	"document_number": true,
+	"password":        true,
+	"authorization":   true,
+	"token":           true,
+	"secret":          true,
+}
+
+// LogValue returns the value to be written, masking sensitive fields so only
+// the last characters remain visible.
+func (f Field) LogValue() interface{} {
+	if !f.Sensitive && !sensitiveKeys[strings.ToLower(f.Key)] {
+		return f.Value
+	}
+	return mask(fmt.Sprint(f.Value))
+}
+
+func mask(s string) string {
+	const visible = 4
+	if len(s) <= visible*2 {
+		return strings.Repeat("*", len(s))
+	}
+	return strings.Repeat("*", len(s)-visible) + s[len(s)-visible:]
 }
 
 func String(key, value string) Field {
@@ -36,6 +94,36 @@ func Int(key string, value int) Field {
 	return Field{Key: key, Value: value}
 }
 
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Float(key string, value float64) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Bool(key string, value bool) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Sensitive marks a value that must never be written in clear text, such as
+// a customer document number.
+func Sensitive(key string, value interface{}) Field {
+	return Field{Key: key, Value: value, Sensitive: true}
+}
+
 func Err(err error) Field {
 	return Field{Key: "error", Value: err}
 }
@@ -43,6 +131,7 @@ func Err(err error) Field {
 type SimpleLogger struct {
 	logger *log.Logger
 	level  Level
+	fields []Field
 }
 
 func NewSimpleLogger(level Level) *SimpleLogger {
@@ -81,13 +170,22 @@ func (l *SimpleLogger) Fatal(msg string, fields ...Field) {
 	os.Exit(1)
 }
 
+func (l *SimpleLogger) With(fields ...Field) Logger {
+	return &SimpleLogger{
+		logger: l.logger,
+		level:  l.level,
+		fields: appendFields(l.fields, fields),
+	}
+}
+
 func (l *SimpleLogger) log(level Level, msg string, fields ...Field) {
 	logMsg := fmt.Sprintf("[%s] %s", level, msg)
 
-	if len(fields) > 0 {
+	all := appendFields(l.fields, fields)
+	if len(all) > 0 {
 		logMsg += " |"
-		for _, f := range fields {
-			logMsg += fmt.Sprintf(" %s=%v", f.Key, f.Value)
+		for _, f := range all {
+			logMsg += fmt.Sprintf(" %s=%v", f.Key, f.LogValue())
 		}
 	}
 
@@ -95,14 +193,18 @@ func (l *SimpleLogger) log(level Level, msg string, fields ...Field) {
 }
 
 func (l *SimpleLogger) shouldLog(level Level) bool {
-	levels := map[Level]int{
-		DebugLevel: 0,
-		InfoLevel:  1,
-		WarnLevel:  2,
-		ErrorLevel: 3,
-	}
+	return level.rank() >= l.level.rank()
+}
 
-	return levels[level] >= levels[l.level]
+// appendFields never aliases the parent's backing array, so sibling child
+// loggers cannot overwrite each other's fields.
+func appendFields(base, extra []Field) []Field {
+	if len(extra) == 0 {
+		return base
+	}
+	all := make([]Field, 0, len(base)+len(extra))
+	all = append(all, base...)
+	return append(all, extra...)
 }
 
 type NoOpLogger struct{}
@@ -112,6 +214,7 @@ func (l *NoOpLogger) Info(msg string, fields ...Field)  {}
 func (l *NoOpLogger) Warn(msg string, fields ...Field)  {}
 func (l *NoOpLogger) Error(msg string, fields ...Field) {}
 func (l *NoOpLogger) Fatal(msg string, fields ...Field) {}
+func (l *NoOpLogger) With(fields ...Field) Logger       { return l }
 
 func NewNoOpLogger() Logger {
 	return &NoOpLogger{}
@@ -0,0 +1,121 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+type Format string
+
+const (
+	JSONFormat Format = "json"
+	TextFormat Format = "text"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch format := Format(strings.ToLower(strings.TrimSpace(s))); format {
+	case JSONFormat, TextFormat:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown log format %q", s)
+	}
+}
+
+// SlogLogger implements Logger on top of log/slog, writing one JSON (or
+// logfmt-style text) record per line.
+type SlogLogger struct {
+	logger *slog.Logger
+	exit   func(code int)
+}
+
+func NewSlogLogger(w io.Writer, level Level, format Format) *SlogLogger {
+	opts := &slog.HandlerOptions{Level: level.slogLevel()}
+
+	var h slog.Handler
+	if format == TextFormat {
+		h = slog.NewTextHandler(w, opts)
+	} else {
+		h = slog.NewJSONHandler(w, opts)
+	}
+
+	return &SlogLogger{logger: slog.New(h), exit: os.Exit}
+}
+
+func (l Level) slogLevel() slog.Level {
+	switch l {
+	case DebugLevel:
+		return slog.LevelDebug
+	case WarnLevel:
+		return slog.LevelWarn
+	case ErrorLevel:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func (l *SlogLogger) Debug(msg string, fields ...Field) { l.log(slog.LevelDebug, msg, fields) }
+func (l *SlogLogger) Info(msg string, fields ...Field)  { l.log(slog.LevelInfo, msg, fields) }
+func (l *SlogLogger) Warn(msg string, fields ...Field)  { l.log(slog.LevelWarn, msg, fields) }
+func (l *SlogLogger) Error(msg string, fields ...Field) { l.log(slog.LevelError, msg, fields) }
+
+func (l *SlogLogger) Fatal(msg string, fields ...Field) {
+	l.log(slog.LevelError, msg, fields)
+	l.exit(1)
+}
+
+func (l *SlogLogger) With(fields ...Field) Logger {
+	return &SlogLogger{logger: l.logger.With(attrsToArgs(toAttrs(fields))...), exit: l.exit}
+}
+
+func (l *SlogLogger) log(level slog.Level, msg string, fields []Field) {
+	ctx := context.Background()
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+	l.logger.LogAttrs(ctx, level, msg, toAttrs(fields)...)
+}
+
+func toAttrs(fields []Field) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, f := range fields {
+		attrs = append(attrs, toAttr(f))
+	}
+	return attrs
+}
+
+func toAttr(f Field) slog.Attr {
+	switch v := f.LogValue().(type) {
+	case string:
+		return slog.String(f.Key, v)
+	case int:
+		return slog.Int(f.Key, v)
+	case int64:
+		return slog.Int64(f.Key, v)
+	case float64:
+		return slog.Float64(f.Key, v)
+	case bool:
+		return slog.Bool(f.Key, v)
+	case time.Duration:
+		return slog.Duration(f.Key, v)
+	case time.Time:
+		return slog.Time(f.Key, v)
+	case error:
+		return slog.String(f.Key, v.Error())
+	default:
+		return slog.Any(f.Key, v)
+	}
+}
+
+func attrsToArgs(attrs []slog.Attr) []any {
+	args := make([]any, len(attrs))
+	for i, a := range attrs {
+		args[i] = a
+	}
+	return args
+}
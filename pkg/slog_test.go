@@ -0,0 +1,131 @@
+package pkg_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	logger "github.com/evythrossell/account-management-api/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	for _, raw := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if raw == "" {
+			continue
+		}
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(raw), &line))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestSlogLogger(t *testing.T) {
+	t.Run("Info - Writes JSON with typed fields", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.NewSlogLogger(&buf, logger.InfoLevel, logger.JSONFormat)
+
+		log.Info("transaction created",
+			logger.Int64("transaction_id", 10),
+			logger.Float("amount", 12.5),
+			logger.Bool("credit", true),
+			logger.Duration("elapsed", 1500*time.Millisecond),
+			logger.Err(errors.New("boom")),
+		)
+
+		lines := decodeLines(t, &buf)
+		require.Len(t, lines, 1)
+		assert.Equal(t, "INFO", lines[0]["level"])
+		assert.Equal(t, "transaction created", lines[0]["msg"])
+		assert.Equal(t, float64(10), lines[0]["transaction_id"])
+		assert.Equal(t, 12.5, lines[0]["amount"])
+		assert.Equal(t, true, lines[0]["credit"])
+		assert.Equal(t, float64(1500*time.Millisecond), lines[0]["elapsed"])
+		assert.Equal(t, "boom", lines[0]["error"])
+	})
+
+	t.Run("Debug - Filtered below configured level", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.NewSlogLogger(&buf, logger.WarnLevel, logger.JSONFormat)
+
+		log.Debug("dropped")
+		log.Info("dropped")
+		log.Warn("kept")
+
+		lines := decodeLines(t, &buf)
+		require.Len(t, lines, 1)
+		assert.Equal(t, "kept", lines[0]["msg"])
+	})
+
+	t.Run("With - Child logger carries fields", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.NewSlogLogger(&buf, logger.InfoLevel, logger.JSONFormat)
+
+		child := log.With(logger.String("request_id", "req-1"))
+		child.Info("first")
+		log.Info("second")
+
+		lines := decodeLines(t, &buf)
+		require.Len(t, lines, 2)
+		assert.Equal(t, "req-1", lines[0]["request_id"])
+		assert.NotContains(t, lines[1], "request_id")
+	})
+
+	t.Run("Sensitive - Masks values", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.NewSlogLogger(&buf, logger.InfoLevel, logger.JSONFormat)
+
+		log.Info("account created",
+			logger.String("document_number", "12345678900"),
+			logger.Sensitive("card", "4111111111111111"),
+		)
+		log.With(logger.Sensitive("pin", "1234")).Info("child")
+
+		lines := decodeLines(t, &buf)
+		require.Len(t, lines, 2)
+		assert.Equal(t, "*******8900", lines[0]["document_number"])
+		assert.Equal(t, "************1111", lines[0]["card"])
+		assert.Equal(t, "****", lines[1]["pin"])
+		assert.NotContains(t, buf.String(), "12345678900")
+	})
+
+	t.Run("Text - Writes key=value pairs", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.NewSlogLogger(&buf, logger.InfoLevel, logger.TextFormat)
+
+		log.Info("server started", logger.String("port", "8080"))
+
+		assert.Contains(t, buf.String(), "level=INFO")
+		assert.Contains(t, buf.String(), `msg="server started"`)
+		assert.Contains(t, buf.String(), "port=8080")
+	})
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := logger.ParseLevel("warning")
+	assert.NoError(t, err)
+	assert.Equal(t, logger.WarnLevel, level)
+
+	level, err = logger.ParseLevel(" Debug ")
+	assert.NoError(t, err)
+	assert.Equal(t, logger.DebugLevel, level)
+
+	_, err = logger.ParseLevel("verbose")
+	assert.Error(t, err)
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := logger.ParseFormat("JSON")
+	assert.NoError(t, err)
+	assert.Equal(t, logger.JSONFormat, format)
+
+	_, err = logger.ParseFormat("xml")
+	assert.Error(t, err)
+}